@@ -5,7 +5,6 @@ import (
 	"path/filepath"
 
 	"github.com/sourabhrathourr/hun/internal/config"
-	"github.com/sourabhrathourr/hun/internal/state"
 	"github.com/spf13/cobra"
 )
 
@@ -33,7 +32,7 @@ var addCmd = &cobra.Command{
 			return err
 		}
 
-		st, err := state.Load()
+		st, err := loadState()
 		if err != nil {
 			return err
 		}
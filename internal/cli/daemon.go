@@ -1,18 +1,25 @@
 package cli
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sourabhrathourr/hun/internal/client"
 	"github.com/sourabhrathourr/hun/internal/daemon"
 	"github.com/spf13/cobra"
 )
 
 func init() {
-	daemonCmd.Hidden = true
 	rootCmd.AddCommand(daemonCmd)
 }
 
 var daemonCmd = &cobra.Command{
 	Use:   "daemon",
-	Short: "Run the background daemon (internal)",
+	Short: "Run the background daemon in the foreground, or manage it with a subcommand",
+	Long: "Without a subcommand, run the daemon in the foreground; hun starts it this way on demand.\n" +
+		"The subcommands inspect, stop, or restart the one that is already running.",
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		d, err := daemon.New()
 		if err != nil {
@@ -21,3 +28,23 @@ var daemonCmd = &cobra.Command{
 		return d.Run()
 	},
 }
+
+// readOnlyClient returns a client for commands that only look at the daemon.
+// It won't restart an outdated daemon that has projects running, so a routine
+// `hun status` after an upgrade can't tear down a session.
+func readOnlyClient() (*client.Client, error) {
+	c, err := client.New()
+	if err != nil {
+		return nil, err
+	}
+	c.GuardRestart()
+	return c, nil
+}
+
+// reportDaemonRestart tells the user when reaching the daemon meant restarting
+// an outdated one that had projects running.
+func reportDaemonRestart(c *client.Client) {
+	if running, restarted := c.RestartedDaemon(); restarted && len(running) > 0 {
+		fmt.Fprintf(os.Stderr, "Restarted the daemon from an older hun, which stopped %s; start them again with `hun run`.\n", strings.Join(running, ", "))
+	}
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sourabhrathourr/hun/internal/client"
+	"github.com/sourabhrathourr/hun/internal/daemon"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	daemonStopCmd.Flags().BoolP("force", "f", false, "Stop protected projects without asking")
+	daemonRestartCmd.Flags().BoolP("force", "f", false, "Stop protected projects without asking")
+	daemonCmd.AddCommand(daemonStatusCmd)
+	daemonCmd.AddCommand(daemonStopCmd)
+	daemonCmd.AddCommand(daemonRestartCmd)
+}
+
+var daemonStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether the daemon is running, its version, and whether its protocol matches this hun",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.New()
+		if err != nil {
+			return err
+		}
+		info, ok := c.Daemon()
+		if !ok {
+			fmt.Println("Daemon is not running; hun starts it on demand.")
+			return nil
+		}
+		printDaemonStatus(os.Stdout, info, time.Now())
+		return nil
+	},
+}
+
+var daemonStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the daemon and every service it runs",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.New()
+		if err != nil {
+			return err
+		}
+		if ok, err := confirmDaemonStop(cmd, c); err != nil || !ok {
+			return err
+		}
+		running, stopped, err := c.StopDaemon()
+		if err != nil {
+			return err
+		}
+		if !stopped {
+			fmt.Println("Daemon is not running.")
+			return nil
+		}
+		fmt.Printf("%s Stopped the daemon\n", checkmark())
+		if len(running) > 0 {
+			fmt.Printf("  Its services were stopped too: %s\n", strings.Join(running, ", "))
+		}
+		return nil
+	},
+}
+
+var daemonRestartCmd = &cobra.Command{
+	Use:   "restart",
+	Short: "Replace the running daemon with one from this hun build",
+	Long: "Stop the running daemon, which stops its services, and start one from this build.\n" +
+		"Use it after an upgrade when the TUI reports a stale daemon.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.New()
+		if err != nil {
+			return err
+		}
+		if ok, err := confirmDaemonStop(cmd, c); err != nil || !ok {
+			return err
+		}
+		running, err := c.RestartDaemon()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s Daemon restarted (protocol %d)\n", checkmark(), daemon.CurrentProtocolVersion)
+		if len(running) > 0 {
+			fmt.Printf("  It stopped %s; start them again with `hun run`.\n", strings.Join(running, ", "))
+		}
+		return nil
+	},
+}
+
+// confirmDaemonStop guards stopping the daemon the way `hun stop --all` is
+// guarded: running protected projects need --force or a yes at the prompt.
+func confirmDaemonStop(cmd *cobra.Command, c *client.Client) (bool, error) {
+	if force, _ := cmd.Flags().GetBool("force"); force {
+		return true, nil
+	}
+	if _, running := c.Daemon(); !running {
+		return true, nil
+	}
+	ok, err := confirmProtectedStop("")
+	if err == nil && !ok {
+		fmt.Println("Aborted.")
+	}
+	return ok, err
+}
+
+// printDaemonStatus describes a running daemon, flagging a protocol that
+// differs from this build's.
+func printDaemonStatus(w io.Writer, info client.DaemonInfo, now time.Time) {
+	running := "running"
+	if info.PID > 0 {
+		running += fmt.Sprintf(" (pid %d", info.PID)
+		if !info.StartedAt.IsZero() {
+			running += ", up " + formatUptime(info.StartedAt, now)
+		}
+		running += ")"
+	}
+	fmt.Fprintf(w, "Daemon:    %s\n", running)
+	if info.Version != "" {
+		version := info.Version
+		if info.Commit != "" {
+			version += " (" + info.Commit + ")"
+		}
+		fmt.Fprintf(w, "Version:   %s\n", version)
+	}
+	if info.Protocol == daemon.CurrentProtocolVersion {
+		fmt.Fprintf(w, "Protocol:  %d, matches this hun\n", info.Protocol)
+		return
+	}
+	fmt.Fprintf(w, "Protocol:  %d, but this hun expects %d; run `hun daemon restart`\n", info.Protocol, daemon.CurrentProtocolVersion)
+}
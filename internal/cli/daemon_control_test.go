@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourabhrathourr/hun/internal/client"
+	"github.com/sourabhrathourr/hun/internal/daemon"
+)
+
+func TestPrintDaemonStatusFlagsProtocolMismatch(t *testing.T) {
+	now := time.Date(2026, time.July, 11, 14, 0, 0, 0, time.UTC)
+	info := client.DaemonInfo{
+		Protocol:  daemon.CurrentProtocolVersion,
+		Version:   "v0.4.0",
+		Commit:    "abc1234",
+		PID:       4242,
+		StartedAt: now.Add(-2*time.Hour - 3*time.Minute),
+	}
+
+	var b strings.Builder
+	printDaemonStatus(&b, info, now)
+	for _, want := range []string{"running (pid 4242, up 2h03m)", "v0.4.0 (abc1234)", "matches this hun"} {
+		if !strings.Contains(b.String(), want) {
+			t.Fatalf("status missing %q:\n%s", want, b.String())
+		}
+	}
+
+	b.Reset()
+	printDaemonStatus(&b, client.DaemonInfo{Protocol: daemon.LegacyProtocolVersion}, now)
+	if !strings.Contains(b.String(), "hun daemon restart") || strings.Contains(b.String(), "Version") {
+		t.Fatalf("stale legacy daemon status:\n%s", b.String())
+	}
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/sourabhrathourr/hun/internal/daemon"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	daemonStatsCmd.Flags().Bool("json", false, "Print the stats as JSON")
+	daemonCmd.AddCommand(daemonStatsCmd)
+}
+
+var daemonStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show the daemon's uptime, log subscribers, goroutines, and log buffer sizes",
+	Long: "Report the running daemon's own health: uptime, running projects, attached log\n" +
+		"subscribers, goroutines, and how full each project's in-memory log buffers are.\n" +
+		"Subscriber or goroutine counts that keep climbing point at a leak.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		c, err := readOnlyClient()
+		if err != nil {
+			return err
+		}
+		resp, err := c.Send(daemon.Request{Action: "stats"})
+		if err != nil {
+			return err
+		}
+		if !resp.OK {
+			return fmt.Errorf("%s", resp.Error)
+		}
+		var stats daemon.DaemonStats
+		if err := json.Unmarshal(resp.Data, &stats); err != nil {
+			return err
+		}
+		if asJSON {
+			out, err := json.MarshalIndent(stats, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = os.Stdout.Write(append(out, '\n'))
+			return err
+		}
+		printDaemonStats(os.Stdout, stats)
+		return nil
+	},
+}
+
+func printDaemonStats(w io.Writer, stats daemon.DaemonStats) {
+	running := "none"
+	if len(stats.RunningProjects) > 0 {
+		running = strings.Join(stats.RunningProjects, ", ")
+	}
+	fmt.Fprintf(w, "PID:          %d\n", stats.PID)
+	fmt.Fprintf(w, "Uptime:       %s\n", stats.Uptime)
+	fmt.Fprintf(w, "Running:      %s\n", running)
+	fmt.Fprintf(w, "Subscribers:  %d\n", stats.Subscribers)
+	fmt.Fprintf(w, "Goroutines:   %d\n", stats.Goroutines)
+	if len(stats.LogBuffers) == 0 {
+		fmt.Fprintln(w, "Log buffers:  none")
+		return
+	}
+
+	projects := make([]string, 0, len(stats.LogBuffers))
+	width := len("PROJECT")
+	for project := range stats.LogBuffers {
+		projects = append(projects, project)
+		width = max(width, len(project))
+	}
+	sort.Strings(projects)
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%-*s  %8s  %8s  %8s\n", width, "PROJECT", "SERVICES", "LINES", "CAPACITY")
+	for _, project := range projects {
+		buf := stats.LogBuffers[project]
+		fmt.Fprintf(w, "%-*s  %8d  %8d  %8d\n", width, project, buf.Services, buf.Lines, buf.Capacity)
+	}
+}
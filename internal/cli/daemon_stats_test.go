@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sourabhrathourr/hun/internal/daemon"
+)
+
+func TestPrintDaemonStatsListsBuffersByProject(t *testing.T) {
+	var b strings.Builder
+	printDaemonStats(&b, daemon.DaemonStats{
+		PID:             4242,
+		Uptime:          "2h3m0s",
+		RunningProjects: []string{"blog", "shop"},
+		Subscribers:     3,
+		Goroutines:      57,
+		LogBuffers: map[string]daemon.LogBufferStats{
+			"shop": {Services: 2, Lines: 1500, Capacity: 20000},
+			"blog": {Services: 1, Lines: 12, Capacity: 10000},
+		},
+	})
+	out := b.String()
+	for _, want := range []string{"Uptime:       2h3m0s", "Running:      blog, shop", "Subscribers:  3", "Goroutines:   57"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q:\n%s", want, out)
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if got := strings.Fields(lines[len(lines)-2]); strings.Join(got, " ") != "blog 1 12 10000" {
+		t.Fatalf("blog row = %v\n%s", got, out)
+	}
+	if got := strings.Fields(lines[len(lines)-1]); strings.Join(got, " ") != "shop 2 1500 20000" {
+		t.Fatalf("shop row = %v\n%s", got, out)
+	}
+
+	b.Reset()
+	printDaemonStats(&b, daemon.DaemonStats{})
+	if !strings.Contains(b.String(), "Running:      none") || !strings.Contains(b.String(), "Log buffers:  none") {
+		t.Fatalf("idle daemon output:\n%s", b.String())
+	}
+}
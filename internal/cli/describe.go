@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sourabhrathourr/hun/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var describeMarkdown bool
+
+func init() {
+	describeCmd.Flags().BoolVar(&describeMarkdown, "markdown", false, "Emit a Markdown table suitable for a README")
+	rootCmd.AddCommand(describeCmd)
+}
+
+var describeCmd = &cobra.Command{
+	Use:   "describe <project|path>",
+	Short: "Summarize a project's services as a table",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := describeProjectDir(args[0])
+		if err != nil {
+			return err
+		}
+		proj, err := config.LoadProject(dir)
+		if err != nil {
+			return err
+		}
+		if describeMarkdown {
+			fmt.Print(describeProjectMarkdown(proj))
+		} else {
+			fmt.Print(describeProjectText(proj))
+		}
+		return nil
+	},
+}
+
+// describeProjectDir resolves a registered project name, falling back to a
+// directory containing .hun.yml.
+func describeProjectDir(arg string) (string, error) {
+	if st, err := loadState(); err == nil {
+		if path, ok := st.Registry[arg]; ok {
+			return path, nil
+		}
+	}
+	if info, err := os.Stat(arg); err == nil && info.IsDir() && config.ProjectExists(arg) {
+		return filepath.Abs(arg)
+	}
+	return "", fmt.Errorf("project %q not found in registry", arg)
+}
+
+var describeColumns = []string{"Service", "Command", "Port", "Ready", "Depends on"}
+
+func describeRows(proj *config.Project) [][]string {
+	names := make([]string, 0, len(proj.Services))
+	for name := range proj.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		svc := proj.Services[name]
+		cmd := svc.Cmd
+		if svc.Cwd != "" {
+			cmd = "cd " + svc.Cwd + " && " + cmd
+		}
+		port := ""
+		if svc.Port > 0 {
+			port = strconv.Itoa(svc.Port)
+		}
+		rows = append(rows, []string{name, cmd, port, svc.Ready, strings.Join(svc.DependsOn, ", ")})
+	}
+	return rows
+}
+
+func describeProjectText(proj *config.Project) string {
+	rows := describeRows(proj)
+	widths := make([]int, len(describeColumns))
+	for i, col := range describeColumns {
+		widths[i] = len(col)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len(cell))
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%d services)\n\n", proj.Name, len(rows))
+	writeRow := func(cells []string) {
+		parts := make([]string, len(cells))
+		for i, cell := range cells {
+			parts[i] = fmt.Sprintf("%-*s", widths[i], cell)
+		}
+		b.WriteString(strings.TrimRight(strings.Join(parts, "  "), " ") + "\n")
+	}
+	writeRow(describeColumns)
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return b.String()
+}
+
+func describeProjectMarkdown(proj *config.Project) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Running %s locally\n\n", proj.Name)
+	b.WriteString("Start everything with `hun run " + proj.Name + "`.\n\n")
+	b.WriteString("| " + strings.Join(describeColumns, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(describeColumns)) + "\n")
+	for _, row := range describeRows(proj) {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			switch {
+			case cell == "":
+				cells[i] = "-"
+			case i == 1 || i == 3:
+				cells[i] = "`" + markdownEscape(cell) + "`"
+			default:
+				cells[i] = markdownEscape(cell)
+			}
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	return b.String()
+}
+
+func markdownEscape(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
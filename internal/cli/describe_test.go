@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sourabhrathourr/hun/internal/config"
+)
+
+func TestDescribeProjectMarkdownListsServices(t *testing.T) {
+	proj := &config.Project{
+		Name: "shop",
+		Services: map[string]*config.Service{
+			"web": {Cmd: "npm run dev", Cwd: "frontend", Port: 3000, Ready: "ready in", DependsOn: []string{"api"}},
+			"api": {Cmd: "go run . | tee api.log", Port: 4000},
+		},
+	}
+
+	got := describeProjectMarkdown(proj)
+	for _, want := range []string{
+		"| Service | Command | Port | Ready | Depends on |",
+		"| api | `go run . \\| tee api.log` | 4000 | - | - |",
+		"| web | `cd frontend && npm run dev` | 3000 | `ready in` | api |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("markdown missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "| api |") > strings.Index(got, "| web |") {
+		t.Fatalf("expected services sorted by name:\n%s", got)
+	}
+
+	text := describeProjectText(proj)
+	if !strings.Contains(text, "shop (2 services)") || !strings.Contains(text, "cd frontend && npm run dev") {
+		t.Fatalf("unexpected text output:\n%s", text)
+	}
+}
@@ -11,7 +11,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sourabhrathourr/hun/internal/client"
 	"github.com/sourabhrathourr/hun/internal/config"
+	"github.com/sourabhrathourr/hun/internal/daemon"
 	"github.com/sourabhrathourr/hun/internal/state"
 	"github.com/spf13/cobra"
 )
@@ -29,7 +31,7 @@ var doctorCmd = &cobra.Command{
 		allOK := true
 
 		// Check hun directory
-		dir, err := config.HunDir()
+		dir, err := config.CheckHunDirWritable()
 		if err != nil {
 			printCheck(false, "hun directory", err.Error())
 			allOK = false
@@ -38,8 +40,12 @@ var doctorCmd = &cobra.Command{
 		}
 
 		// Check daemon socket
-		sockPath := filepath.Join(dir, "daemon.sock")
-		if _, err := os.Stat(sockPath); err != nil {
+		daemonUp := false
+		sockPath, err := daemon.SocketPath()
+		if err != nil {
+			printCheck(false, "daemon socket", err.Error())
+			allOK = false
+		} else if _, err := os.Stat(sockPath); err != nil {
 			printCheck(false, "daemon socket", "not found (daemon not running)")
 			allOK = false
 		} else {
@@ -50,13 +56,35 @@ var doctorCmd = &cobra.Command{
 				allOK = false
 			} else {
 				conn.Close()
+				daemonUp = true
 				printCheck(true, "daemon", "running and responsive")
 			}
 		}
 
-		// Check state file
-		st, err := state.Load()
-		if err != nil {
+		// Check the shell and PATH services are launched with
+		svcEnv, from := doctorServiceEnvironment(daemonUp)
+		if svcEnv.ShellError != "" {
+			printCheck(false, "service shell", svcEnv.ShellError+" (services run via $SHELL -c; set SHELL to a valid shell)")
+			allOK = false
+		} else {
+			printCheck(true, "service shell", svcEnv.Shell)
+		}
+		missing := missingServicePathDirs(os.Getenv("PATH"), svcEnv.Path)
+		if len(missing) > 0 {
+			printCheck(false, "service PATH", fmt.Sprintf("missing %s%s", strings.Join(missing, ", "), from))
+			allOK = false
+		} else {
+			printCheck(true, "service PATH", "has this terminal's tool directories"+from)
+		}
+		fmt.Printf("    %s\n", svcEnv.Path)
+
+		// Check state file. Load would back up a corrupt file, and doctor
+		// only reports.
+		var st *state.State
+		if err := state.Verify(); err != nil {
+			printCheck(false, "state file", err.Error()+" (the next hun command backs it up and starts fresh; re-register projects with `hun add`)")
+			allOK = false
+		} else if st, err = loadState(); err != nil {
 			printCheck(false, "state file", err.Error())
 			allOK = false
 		} else {
@@ -106,7 +134,11 @@ var doctorCmd = &cobra.Command{
 				printCheck(false, "global config", fmt.Sprintf("unsupported keys configured: %s", strings.Join(unsupported, ", ")))
 				allOK = false
 			} else {
-				printCheck(true, "global config", fmt.Sprintf("ports.default_offset=%d", globalCfg.Ports.DefaultOffset))
+				detail := fmt.Sprintf("ports.default_offset=%d", globalCfg.Ports.DefaultOffset)
+				if path, overridden, _ := config.GlobalPath(); overridden {
+					detail += " (from " + path + ")"
+				}
+				printCheck(true, "global config", detail)
 			}
 		}
 
@@ -144,6 +176,111 @@ func printCheck(ok bool, label, detail string) {
 	fmt.Printf("  %s %-25s %s\n", mark, label, detail)
 }
 
+// doctorServiceEnvironment asks the running daemon for the shell and PATH it
+// gives services. Without a daemon, or with one too old to answer, it reports
+// this process's own, which is what a daemon started from here would inherit,
+// and says why.
+func doctorServiceEnvironment(daemonUp bool) (daemon.ServiceEnvironment, string) {
+	own := daemon.CurrentServiceEnvironment()
+	if !daemonUp {
+		return own, " (this shell; daemon not running)"
+	}
+	c, err := client.New()
+	if err != nil {
+		return own, " (this shell; " + err.Error() + ")"
+	}
+	info, ok := c.Daemon()
+	if !ok {
+		return own, " (this shell; daemon not running)"
+	}
+	mismatch := fmt.Sprintf(" (this shell; the daemon speaks protocol %d, this hun needs %d: run `hun daemon restart`)", info.Protocol, daemon.CurrentProtocolVersion)
+	if info.Protocol != daemon.CurrentProtocolVersion {
+		return own, mismatch
+	}
+	c.GuardRestart()
+	resp, err := c.Send(daemon.Request{Action: "environment"})
+	if err != nil {
+		return own, " (this shell; " + err.Error() + ")"
+	}
+	if !resp.OK {
+		if strings.HasPrefix(resp.Error, "unknown action") {
+			return own, mismatch
+		}
+		return own, " (this shell; daemon: " + resp.Error + ")"
+	}
+	var env daemon.ServiceEnvironment
+	if err := json.Unmarshal(resp.Data, &env); err != nil {
+		return own, " (this shell; " + err.Error() + ")"
+	}
+	return env, " (daemon)"
+}
+
+// toolManagerDirs are version managers whose shims or bins services need on
+// PATH; each is checked only when its root directory exists.
+var toolManagerDirs = []struct {
+	root string
+	bins []string
+}{
+	{".nvm", []string{".nvm/versions/node/*/bin"}},
+	{".asdf", []string{".asdf/shims"}},
+	{".volta", []string{".volta/bin"}},
+	{".local/share/mise", []string{".local/share/mise/shims", ".mise/shims"}},
+	{".pyenv", []string{".pyenv/shims"}},
+	{".rbenv", []string{".rbenv/shims"}},
+	{".bun", []string{".bun/bin"}},
+}
+
+// missingServicePathDirs lists directories services won't find: ones on the
+// terminal's PATH that the service PATH lacks, and installed version managers
+// with nothing on the service PATH. This is the usual cause of a command that
+// works in a terminal but not under hun.
+func missingServicePathDirs(terminalPath, servicePath string) []string {
+	have := make(map[string]bool)
+	for _, dir := range filepath.SplitList(servicePath) {
+		have[filepath.Clean(dir)] = true
+	}
+
+	var missing []string
+	seen := make(map[string]bool)
+	add := func(dir string) {
+		if !seen[dir] {
+			seen[dir] = true
+			missing = append(missing, dir)
+		}
+	}
+	for _, dir := range filepath.SplitList(terminalPath) {
+		if dir == "" || have[filepath.Clean(dir)] {
+			continue
+		}
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			add(dir)
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return missing
+	}
+	for _, tm := range toolManagerDirs {
+		if info, err := os.Stat(filepath.Join(home, tm.root)); err != nil || !info.IsDir() {
+			continue
+		}
+		found := false
+		for _, pattern := range tm.bins {
+			matches, _ := filepath.Glob(filepath.Join(home, pattern))
+			for _, m := range matches {
+				if have[filepath.Clean(m)] {
+					found = true
+				}
+			}
+		}
+		if !found {
+			add(filepath.Join(home, tm.bins[0]))
+		}
+	}
+	return missing
+}
+
 func fetchLatestReleaseTag(timeout time.Duration) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
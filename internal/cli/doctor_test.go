@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMissingServicePathDirsFindsTerminalAndVersionManagerDirs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	toolBin := filepath.Join(home, "tools", "bin")
+	shims := filepath.Join(home, ".asdf", "shims")
+	nvmBin := filepath.Join(home, ".nvm", "versions", "node", "v20.0.0", "bin")
+	for _, dir := range []string{toolBin, shims, nvmBin} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	terminal := strings.Join([]string{toolBin, "/usr/bin", filepath.Join(home, "gone")}, string(os.PathListSeparator))
+	service := strings.Join([]string{"/usr/bin", nvmBin + "/"}, string(os.PathListSeparator))
+
+	got := missingServicePathDirs(terminal, service)
+	want := []string{toolBin, shims}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("missing = %v, want %v", got, want)
+	}
+}
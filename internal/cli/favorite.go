@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sourabhrathourr/hun/internal/client"
+	"github.com/sourabhrathourr/hun/internal/daemon"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	favoriteCmd.Flags().Bool("remove", false, "Unmark the project instead")
+	rootCmd.AddCommand(favoriteCmd)
+}
+
+var favoriteCmd = &cobra.Command{
+	Use:   "favorite [project]",
+	Short: "Mark a project as a favorite so it sorts first in the TUI picker (no args lists them)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return printFavorites()
+		}
+		remove, _ := cmd.Flags().GetBool("remove")
+		action := "set_project_favorite"
+		if remove {
+			action = "clear_project_favorite"
+		}
+
+		c, err := client.New()
+		if err != nil {
+			return err
+		}
+		resp, err := c.Send(daemon.Request{Action: action, Project: args[0]})
+		if err != nil {
+			return err
+		}
+		if !resp.OK {
+			return fmt.Errorf("%s", resp.Error)
+		}
+		if remove {
+			fmt.Printf("%s Removed %s from favorites\n", checkmark(), args[0])
+		} else {
+			fmt.Printf("%s Added %s to favorites\n", checkmark(), args[0])
+		}
+		return nil
+	},
+}
+
+func printFavorites() error {
+	st, err := loadState()
+	if err != nil {
+		return err
+	}
+	var names []string
+	for name, ps := range st.Projects {
+		if ps.Favorite && st.IsRegistered(name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		fmt.Println("No favorite projects. Add one with 'hun favorite <project>'.")
+		return nil
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("★ %-20s %s\n", name, st.Registry[name])
+	}
+	return nil
+}
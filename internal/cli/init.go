@@ -4,22 +4,25 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/sourabhrathourr/hun/internal/config"
 	"github.com/sourabhrathourr/hun/internal/detect"
-	"github.com/sourabhrathourr/hun/internal/state"
 	"github.com/spf13/cobra"
 )
 
 func init() {
 	initCmd.Flags().String("name", "", "Project name (defaults to directory name)")
 	initCmd.Flags().String("profile", "", "Detection profile: local|compose|hybrid")
+	initCmd.Flags().StringSlice("compose-profile", nil, "Include compose services in these profiles (repeatable; default: profile-less services only)")
 	initCmd.Flags().BoolP("yes", "y", false, "Accept detected configuration without prompting")
 	initCmd.Flags().Bool("no-register", false, "Create or update .hun.yml without registering the project")
+	initCmd.Flags().String("remote", "", "Detect services in user@host:/path over ssh and run them there with ports forwarded")
 	initCmd.Flags().Bool("reconfigure", false, "Regenerate .hun.yml even if one already exists (creates .hun.yml.bak.<timestamp>)")
+	initCmd.Flags().Bool("merge", false, "Add newly detected services to an existing .hun.yml, keeping current definitions")
 	rootCmd.AddCommand(initCmd)
 }
 
@@ -33,10 +36,35 @@ var initCmd = &cobra.Command{
 		}
 
 		reconfigure, _ := cmd.Flags().GetBool("reconfigure")
+		merge, _ := cmd.Flags().GetBool("merge")
+		if merge && reconfigure {
+			return fmt.Errorf("--merge and --reconfigure cannot be used together")
+		}
 		autoApprove, _ := cmd.Flags().GetBool("yes")
 		noRegister, _ := cmd.Flags().GetBool("no-register")
 		rawProfile, _ := cmd.Flags().GetString("profile")
 		requestedProfile := strings.TrimSpace(rawProfile)
+		// nil leaves the choice to a prompt or the existing .hun.yml; an
+		// explicit flag, even an empty one, is used as given.
+		var composeProfiles []string
+		if cmd.Flags().Changed("compose-profile") {
+			raw, _ := cmd.Flags().GetStringSlice("compose-profile")
+			composeProfiles = []string{}
+			for _, p := range raw {
+				if p = strings.TrimSpace(p); p != "" {
+					composeProfiles = append(composeProfiles, p)
+				}
+			}
+		}
+		rawRemote, _ := cmd.Flags().GetString("remote")
+		var remote *remoteTarget
+		if strings.TrimSpace(rawRemote) != "" {
+			target, err := parseRemoteTarget(rawRemote)
+			if err != nil {
+				return err
+			}
+			remote = &target
+		}
 
 		var existing *config.Project
 		if config.ProjectExists(dir) {
@@ -45,6 +73,12 @@ var initCmd = &cobra.Command{
 				return err
 			}
 			existing = proj
+			if merge {
+				if remote != nil {
+					return fmt.Errorf("--merge does not support --remote; rerun with --reconfigure instead")
+				}
+				return mergeProjectConfig(proj, dir, requestedProfile, composeProfiles, autoApprove, noRegister)
+			}
 			if !reconfigure {
 				if isInteractiveTerminal() {
 					question := fmt.Sprintf(".hun.yml already exists (project: %s). Override it? [y/N] ", proj.Name)
@@ -77,12 +111,28 @@ var initCmd = &cobra.Command{
 		if name == "" {
 			if existing != nil && existing.Name != "" {
 				name = existing.Name
+			} else if remote != nil {
+				name = path.Base(remote.Path)
 			} else {
 				name = filepath.Base(dir)
 			}
 		}
 
-		proj, aborted, err := prepareProjectFromDetection(name, dir, requestedProfile, reconfigure, autoApprove)
+		detectDir := dir
+		if remote != nil {
+			fmt.Printf("Inspecting %s over ssh...\n", remote)
+			mirror, err := mirrorRemoteProject(*remote)
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(mirror)
+			detectDir = mirror
+		}
+
+		if composeProfiles == nil && existing != nil && existing.Detect.ComposeProfiles != nil {
+			composeProfiles = existing.Detect.ComposeProfiles
+		}
+		proj, aborted, err := prepareProjectFromDetection(name, detectDir, requestedProfile, composeProfiles, reconfigure, autoApprove)
 		if err != nil {
 			return err
 		}
@@ -90,6 +140,10 @@ var initCmd = &cobra.Command{
 			fmt.Println("Aborted.")
 			return nil
 		}
+		if remote != nil {
+			remoteizeProject(proj, *remote)
+			fmt.Printf("Services will run on %s via ssh; configured ports are forwarded to localhost.\n", remote.Host)
+		}
 
 		if reconfigure && existing != nil {
 			backup, err := backupProjectConfig(dir)
@@ -99,7 +153,7 @@ var initCmd = &cobra.Command{
 			fmt.Printf("%s Backed up existing config: %s\n", checkmark(), filepath.Base(backup))
 		}
 
-		if err := config.WriteProject(dir, proj); err != nil {
+		if err := config.WriteProject(dir, proj, reconfigure); err != nil {
 			return err
 		}
 		fmt.Printf("%s Created .hun.yml\n", checkmark())
@@ -111,8 +165,38 @@ var initCmd = &cobra.Command{
 	},
 }
 
+// mergeProjectConfig adds newly detected services to the existing .hun.yml in
+// dir, backing the file up first since rewriting it drops YAML comments.
+func mergeProjectConfig(existing *config.Project, dir, requestedProfile string, composeProfiles []string, autoApprove, noRegister bool) error {
+	proj, added, aborted, err := mergeDetectedServices(existing, dir, requestedProfile, composeProfiles, autoApprove)
+	if err != nil {
+		return err
+	}
+	if aborted {
+		fmt.Println("Aborted.")
+		return nil
+	}
+	if len(added) == 0 {
+		fmt.Println("No new services detected; .hun.yml unchanged.")
+	} else {
+		backup, err := backupProjectConfig(dir)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s Backed up existing config: %s\n", checkmark(), filepath.Base(backup))
+		if err := config.WriteProject(dir, proj, true); err != nil {
+			return err
+		}
+		fmt.Printf("%s Added %d service(s) to .hun.yml: %s\n", checkmark(), len(added), strings.Join(added, ", "))
+	}
+	if noRegister {
+		return nil
+	}
+	return registerProject(proj.Name, dir)
+}
+
 func registerProject(name, dir string) error {
-	st, err := state.Load()
+	st, err := loadState()
 	if err != nil {
 		return err
 	}
@@ -139,8 +223,9 @@ func detectedToProject(name string, result detect.Result) *config.Project {
 		Name:     name,
 		Services: make(map[string]*config.Service),
 		Detect: config.DetectConfig{
-			Version: "v2",
-			Profile: result.Profile,
+			Version:         "v2",
+			Profile:         result.Profile,
+			ComposeProfiles: result.ComposeProfiles,
 		},
 	}
 	for _, svc := range result.Services {
@@ -158,6 +243,9 @@ func detectedToProject(name string, result detect.Result) *config.Project {
 		if svc.Cwd != "" {
 			s.Cwd = svc.Cwd
 		}
+		if svc.Class != "" {
+			s.Tags = []string{svc.Class}
+		}
 		proj.Services[svc.Name] = s
 	}
 	return proj
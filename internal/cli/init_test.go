@@ -3,6 +3,7 @@ package cli
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/sourabhrathourr/hun/internal/config"
@@ -56,7 +57,7 @@ func TestPrepareProjectFromDetectionRequiresApprovalWhenNonInteractive(t *testin
 		t.Fatalf("write package: %v", err)
 	}
 
-	_, _, err := prepareProjectFromDetection("demo", dir, "hybrid", false, false)
+	_, _, err := prepareProjectFromDetection("demo", dir, "hybrid", nil, false, false)
 	if err == nil {
 		t.Fatalf("expected non-interactive detection to require approval")
 	}
@@ -65,7 +66,7 @@ func TestPrepareProjectFromDetectionRequiresApprovalWhenNonInteractive(t *testin
 func TestPrepareProjectFromDetectionRequiresApprovalForMinimalConfig(t *testing.T) {
 	dir := t.TempDir()
 
-	_, _, err := prepareProjectFromDetection("demo", dir, "hybrid", false, false)
+	_, _, err := prepareProjectFromDetection("demo", dir, "hybrid", nil, false, false)
 	if err == nil {
 		t.Fatalf("expected non-interactive minimal config generation to require approval")
 	}
@@ -77,7 +78,7 @@ func TestPrepareProjectFromDetectionAllowsExplicitYes(t *testing.T) {
 		t.Fatalf("write package: %v", err)
 	}
 
-	proj, aborted, err := prepareProjectFromDetection("demo", dir, "hybrid", false, true)
+	proj, aborted, err := prepareProjectFromDetection("demo", dir, "hybrid", nil, false, true)
 	if err != nil {
 		t.Fatalf("prepare: %v", err)
 	}
@@ -92,7 +93,7 @@ func TestPrepareProjectFromDetectionAllowsExplicitYes(t *testing.T) {
 func TestPrepareProjectFromDetectionAllowsExplicitYesForMinimalConfig(t *testing.T) {
 	dir := t.TempDir()
 
-	proj, aborted, err := prepareProjectFromDetection("demo", dir, "hybrid", false, true)
+	proj, aborted, err := prepareProjectFromDetection("demo", dir, "hybrid", nil, false, true)
 	if err != nil {
 		t.Fatalf("prepare: %v", err)
 	}
@@ -104,6 +105,75 @@ func TestPrepareProjectFromDetectionAllowsExplicitYesForMinimalConfig(t *testing
 	}
 }
 
+func TestPrepareProjectFromDetectionPersistsComposeProfiles(t *testing.T) {
+	dir := t.TempDir()
+	compose := "services:\n  db:\n    image: postgres:16\n  mailhog:\n    image: mailhog/mailhog\n    profiles: [debug]\n"
+	if err := writeFile(t, filepath.Join(dir, "compose.yml"), compose); err != nil {
+		t.Fatalf("write compose: %v", err)
+	}
+
+	proj, _, err := prepareProjectFromDetection("demo", dir, "compose", []string{"debug"}, false, true)
+	if err != nil {
+		t.Fatalf("prepare: %v", err)
+	}
+	if proj.Services["mailhog"] == nil || proj.Services["db"] == nil {
+		t.Fatalf("services = %v, want db and mailhog", proj.Services)
+	}
+	if got := proj.Detect.ComposeProfiles; len(got) != 1 || got[0] != "debug" {
+		t.Fatalf("detect.compose_profiles = %v, want [debug]", got)
+	}
+
+	proj, _, err = prepareProjectFromDetection("demo", dir, "compose", nil, false, true)
+	if err != nil {
+		t.Fatalf("prepare: %v", err)
+	}
+	if proj.Services["mailhog"] != nil || proj.Detect.ComposeProfiles != nil {
+		t.Fatalf("without profiles got services %v, compose_profiles %v; want only profile-less services", proj.Services, proj.Detect.ComposeProfiles)
+	}
+
+	if _, _, err := prepareProjectFromDetection("demo", dir, "compose", []string{"nope"}, false, true); err == nil || !strings.Contains(err.Error(), "available: debug") {
+		t.Fatalf("unknown profile err = %v, want it to list available profiles", err)
+	}
+}
+
+func TestMergeDetectedServicesAddsOnlyNewServices(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"package.json":             `{"private":true,"workspaces":["apps/*"]}`,
+		"apps/web/package.json":    `{"name":"web","scripts":{"dev":"vite"}}`,
+		"apps/worker/package.json": `{"name":"worker","scripts":{"dev":"node worker.js"}}`,
+	}
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := writeFile(t, path, contents); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	existing := &config.Project{
+		Name: "demo",
+		Services: map[string]*config.Service{
+			"web": {Cmd: "npm run dev -- --open", Cwd: "./apps/web"},
+		},
+	}
+
+	proj, added, aborted, err := mergeDetectedServices(existing, dir, "hybrid", nil, true)
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if aborted {
+		t.Fatalf("unexpected abort")
+	}
+	if len(added) != 1 || added[0] != "worker" {
+		t.Fatalf("added = %v, want [worker]", added)
+	}
+	if got := proj.Services["web"].Cmd; got != "npm run dev -- --open" {
+		t.Fatalf("web cmd = %q, want existing definition kept", got)
+	}
+}
+
 func TestInitNoRegisterWritesConfigWithoutStateRegistration(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
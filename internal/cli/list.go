@@ -5,7 +5,6 @@ import (
 	"sort"
 
 	"github.com/sourabhrathourr/hun/internal/discovery"
-	"github.com/sourabhrathourr/hun/internal/state"
 	"github.com/spf13/cobra"
 )
 
@@ -17,7 +16,7 @@ var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all known projects",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		st, err := state.Load()
+		st, err := loadState()
 		if err != nil {
 			return err
 		}
@@ -1,41 +1,102 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/sourabhrathourr/hun/internal/client"
+	"github.com/sourabhrathourr/hun/internal/config"
 	"github.com/sourabhrathourr/hun/internal/daemon"
 	"github.com/spf13/cobra"
 )
 
 func init() {
-	logsCmd.Flags().IntP("lines", "n", 500, "Number of lines to show")
+	registerLogsFlags(logsCmd)
+	logsConfigCmd.Flags().String("max-size", "", "Rotate log files at this size (e.g. 50MB, 1GB)")
+	logsConfigCmd.Flags().Int("max-files", 0, "Number of rotated files to keep")
+	logsConfigCmd.Flags().String("retention", "", "Delete rotated logs older than this (e.g. 14d)")
+	logsCmd.AddCommand(logsConfigCmd)
 	rootCmd.AddCommand(logsCmd)
 }
 
-var logsCmd = &cobra.Command{
-	Use:   "logs <project>:<service>",
-	Short: "Dump logs to stdout (pipe-friendly)",
+// registerLogsFlags defines the hun logs flags on cmd.
+func registerLogsFlags(cmd *cobra.Command) {
+	cmd.Flags().IntP("lines", "n", 500, "Number of lines to show")
+	cmd.Flags().BoolP("follow", "f", false, "Keep streaming new output after the buffered lines until Ctrl+C")
+	cmd.Flags().Bool("json", false, "Print each line as a JSON LogLine (timestamp, service, project, text, is_err)")
+	cmd.Flags().Int("tail", 0, "Show the last N buffered lines, then keep streaming new output (0 streams only new lines)")
+	cmd.Flags().Bool("no-buffer", false, "Skip buffered history and only stream lines emitted from now on")
+	cmd.Flags().String("since", "", "Only show buffered lines at or after this time (RFC3339 or a duration like 10m)")
+	cmd.Flags().String("until", "", "Only show buffered lines at or before this time (RFC3339 or a duration like 1m)")
+	cmd.Flags().StringArray("grep", nil, "Only show lines matching a pattern spec: a,b matches either, a&b needs both (repeatable)")
+	cmd.Flags().StringArray("grep-v", nil, "Hide lines matching a pattern spec, e.g. healthz,/metrics (repeatable)")
+	cmd.Flags().String("project", "", "Project to show, or \"all\" for every running project")
+	cmd.Flags().String("service", "", "Service to show, or \"all\" for every service of the project")
+}
+
+// logFollowPlan reads --lines, --follow, --tail and --no-buffer: whether to
+// keep streaming, and how many buffered lines to print first. allProjects
+// streams only new lines unless --tail asks for history.
+func logFollowPlan(cmd *cobra.Command, allProjects bool) (follow bool, lines int, err error) {
+	lines, _ = cmd.Flags().GetInt("lines")
+	noBuffer, _ := cmd.Flags().GetBool("no-buffer")
+	followFlag, _ := cmd.Flags().GetBool("follow")
+	follow = followFlag || noBuffer || cmd.Flags().Changed("tail")
+	if cmd.Flags().Changed("tail") {
+		lines, _ = cmd.Flags().GetInt("tail")
+		if lines < 0 {
+			return false, 0, fmt.Errorf("--tail must be 0 or greater")
+		}
+	}
+	if noBuffer {
+		lines = 0
+	}
+	if allProjects && !cmd.Flags().Changed("tail") {
+		// Everything, everywhere is only useful live.
+		follow, lines = true, 0
+	}
+	if follow && lines == 0 && (cmd.Flags().Changed("since") || cmd.Flags().Changed("until")) {
+		// Nothing buffered is fetched, and streamed lines are always new.
+		return false, 0, fmt.Errorf("--since and --until filter buffered lines; use --tail N or --follow instead of streaming only new lines")
+	}
+	return follow, lines, nil
+}
+
+var logsConfigCmd = &cobra.Command{
+	Use:   "config <project>",
+	Short: "Update log rotation settings in a project's .hun.yml",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		project, service := parseTarget(args[0])
-		if service == "" {
-			return fmt.Errorf("specify service: hun logs project:service")
+		maxSize, _ := cmd.Flags().GetString("max-size")
+		maxFiles, _ := cmd.Flags().GetInt("max-files")
+		retention, _ := cmd.Flags().GetString("retention")
+		if maxSize == "" && maxFiles == 0 && retention == "" {
+			return fmt.Errorf("specify at least one of --max-size, --max-files, --retention")
+		}
+		if maxFiles < 0 {
+			return fmt.Errorf("--max-files must be 0 or greater")
 		}
-
-		lines, _ := cmd.Flags().GetInt("lines")
 
 		c, err := client.New()
 		if err != nil {
 			return err
 		}
-
 		resp, err := c.Send(daemon.Request{
-			Action:  "logs",
-			Project: project,
-			Service: service,
-			Lines:   lines,
+			Action:  "set_logs_config",
+			Project: args[0],
+			Logs: &config.LogsConfig{
+				MaxSize:   maxSize,
+				MaxFiles:  maxFiles,
+				Retention: retention,
+			},
 		})
 		if err != nil {
 			return err
@@ -44,16 +105,226 @@ var logsCmd = &cobra.Command{
 			return fmt.Errorf("%s", resp.Error)
 		}
 
-		var logLines []daemon.LogLine
-		if err := json.Unmarshal(resp.Data, &logLines); err != nil {
+		var applied config.LogsConfig
+		if err := json.Unmarshal(resp.Data, &applied); err != nil {
 			return err
 		}
+		fmt.Printf("%s Updated logs config for %s (max_size: %s, max_files: %d, retention: %s)\n",
+			checkmark(), args[0], orDefault(applied.MaxSize), applied.MaxFiles, orDefault(applied.Retention))
+		return nil
+	},
+}
 
-		for _, line := range logLines {
-			ts := line.Timestamp.Format("15:04:05")
-			fmt.Printf("[%s] %s\n", ts, line.Text)
+func orDefault(value string) string {
+	if value == "" {
+		return "default"
+	}
+	return value
+}
+
+var logsCmd = &cobra.Command{
+	Use:   "logs <project>:<service> | <project> <service>",
+	Short: "Dump logs to stdout (pipe-friendly)",
+	Long: "Dump a service's logs. Use all for the service (shop:all) to merge a project's services,\n" +
+		"or --project all --service all to follow every running project with [project][service] prefixes.\n" +
+		"--follow keeps streaming until Ctrl+C; --json prints one LogLine object per line for scripts.",
+	Args: cobra.MaximumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target, err := targetFromArgs(args)
+		if err != nil {
+			return err
 		}
+		projectFlag, _ := cmd.Flags().GetString("project")
+		serviceFlag, _ := cmd.Flags().GetString("service")
+		scope, err := resolveLogScope(target, projectFlag, serviceFlag)
+		if err != nil {
+			return err
+		}
+		project, service := scope.project, scope.service
 
-		return nil
+		follow, lines, err := logFollowPlan(cmd, scope.allProjects)
+		if err != nil {
+			return err
+		}
+		jsonOut, _ := cmd.Flags().GetBool("json")
+		sinceRaw, _ := cmd.Flags().GetString("since")
+		untilRaw, _ := cmd.Flags().GetString("until")
+		now := time.Now()
+		since, err := parseLogTimeFlag("since", sinceRaw, now)
+		if err != nil {
+			return err
+		}
+		until, err := parseLogTimeFlag("until", untilRaw, now)
+		if err != nil {
+			return err
+		}
+
+		grep, _ := cmd.Flags().GetStringArray("grep")
+		grepV, _ := cmd.Flags().GetStringArray("grep-v")
+		specs := append([]string(nil), grep...)
+		for _, spec := range grepV {
+			specs = append(specs, "!"+spec)
+		}
+
+		c, err := readOnlyClient()
+		if err != nil {
+			return err
+		}
+
+		emit := scope.printer(isInteractiveTerminal() && os.Getenv("NO_COLOR") == "")
+		if jsonOut {
+			emit = jsonLogPrinter(os.Stdout)
+		}
+
+		// When following, zero history lines means no fetch at all; the daemon
+		// would otherwise treat 0 as "return the whole buffer".
+		if !follow || lines > 0 {
+			logLines, err := fetchLogLines(c, project, service, lines, since, until, specs)
+			if err != nil {
+				return err
+			}
+			for _, line := range logLines {
+				emit(line)
+			}
+		}
+
+		if !follow {
+			return nil
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return c.SubscribeWithContext(ctx, project, service, func(line daemon.LogLine) {
+			if len(daemon.MatchLines([]daemon.LogLine{line}, specs)) == 1 {
+				emit(line)
+			}
+		})
 	},
 }
+
+func fetchLogLines(c *client.Client, project, service string, lines int, since, until string, grep []string) ([]daemon.LogLine, error) {
+	resp, err := c.Send(daemon.Request{
+		Action:  "logs",
+		Project: project,
+		Service: service,
+		Lines:   lines,
+		Since:   since,
+		Until:   until,
+		Grep:    grep,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.OK {
+		return nil, fmt.Errorf("%s", resp.Error)
+	}
+
+	var logLines []daemon.LogLine
+	if err := json.Unmarshal(resp.Data, &logLines); err != nil {
+		return nil, err
+	}
+	return logLines, nil
+}
+
+func printLogLine(line daemon.LogLine) {
+	ts := line.Timestamp.Format("15:04:05")
+	fmt.Printf("[%s] %s\n", ts, line.Text)
+}
+
+// jsonLogPrinter writes each line as one JSON object, so scripts get exact
+// timestamps and the stream (is_err) without parsing the text format.
+func jsonLogPrinter(w io.Writer) func(daemon.LogLine) {
+	enc := json.NewEncoder(w)
+	return func(line daemon.LogLine) {
+		_ = enc.Encode(line)
+	}
+}
+
+// logScope is what `hun logs` shows. Empty project or service mean all of
+// them, matching how the daemon reads an empty logs/subscribe target.
+type logScope struct {
+	project     string
+	service     string
+	allProjects bool
+}
+
+// resolveLogScope combines the positional project:service target with the
+// --project/--service flags, which win when both are given.
+func resolveLogScope(target, projectFlag, serviceFlag string) (logScope, error) {
+	project, service := parseTarget(target)
+	if projectFlag != "" {
+		project = projectFlag
+	}
+	if serviceFlag != "" {
+		service = serviceFlag
+	}
+	if project == "" {
+		return logScope{}, fmt.Errorf("specify a target: hun logs project:service, project:all, or --project all --service all")
+	}
+	if service == "" {
+		return logScope{}, fmt.Errorf("specify service: hun logs project:service (or project:all)")
+	}
+
+	scope := logScope{project: project, service: service}
+	if strings.EqualFold(service, "all") {
+		scope.service = ""
+	}
+	if strings.EqualFold(project, "all") {
+		if scope.service != "" {
+			return logScope{}, fmt.Errorf("--project all needs --service all")
+		}
+		scope.project = ""
+		scope.allProjects = true
+	}
+	return scope, nil
+}
+
+// printer returns how lines are written for the scope: bare for one service,
+// [service]-prefixed for a project, and [project][service]-prefixed with a
+// stable per-project color for everything.
+func (s logScope) printer(color bool) func(daemon.LogLine) {
+	return func(line daemon.LogLine) {
+		ts := line.Timestamp.Format("15:04:05")
+		switch {
+		case s.allProjects:
+			label := "[" + line.Project + "]"
+			if color {
+				label = projectColor(line.Project) + label + "\033[0m"
+			}
+			fmt.Printf("[%s] %s[%s] %s\n", ts, label, line.Service, line.Text)
+		case s.service == "":
+			fmt.Printf("[%s] [%s] %s\n", ts, line.Service, line.Text)
+		default:
+			fmt.Printf("[%s] %s\n", ts, line.Text)
+		}
+	}
+}
+
+// projectColors are ANSI foregrounds that read on light and dark terminals.
+var projectColors = []string{"\033[36m", "\033[35m", "\033[33m", "\033[32m", "\033[34m", "\033[96m", "\033[95m", "\033[93m"}
+
+// projectColor picks a color from the project name so it stays the same
+// across runs and machines.
+func projectColor(project string) string {
+	h := fnv.New32a()
+	h.Write([]byte(project))
+	return projectColors[h.Sum32()%uint32(len(projectColors))]
+}
+
+// parseLogTimeFlag accepts an RFC3339 timestamp or a duration measured back
+// from now, and returns the RFC3339 form the daemon expects.
+func parseLogTimeFlag(name, value string, now time.Time) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return "", fmt.Errorf("--%s duration must be positive", name)
+		}
+		return now.Add(-d).Format(time.RFC3339Nano), nil
+	}
+	ts, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return "", fmt.Errorf("--%s must be an RFC3339 timestamp or a duration like 10m", name)
+	}
+	return ts.Format(time.RFC3339Nano), nil
+}
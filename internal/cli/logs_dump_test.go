@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sourabhrathourr/hun/internal/daemon"
+)
+
+func TestDumpProjectLogsMergesDiskAndBufferAcrossServices(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".hun", "logs", "shop")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(name, data string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// api's file overlaps its buffer from 10:00:05; worker stopped and only has a file.
+	write("api.log", "[2024-03-01 10:00:00] [out] booting\n[2024-03-01 10:00:05] [out] evicted twin\n[2024-03-01 10:00:05] [out] ready\n")
+	write("worker.log", "[2024-03-01 09:59:00] [out] too early\n[2024-03-01 10:00:03] [err] job failed\n")
+
+	at := func(sec, ms int) time.Time {
+		return time.Date(2024, 3, 1, 10, 0, sec, ms*int(time.Millisecond), time.Local)
+	}
+	buffered := []daemon.LogLine{
+		{Service: "api", Text: "ready", Timestamp: at(5, 200), Seq: 1},
+		{Service: "api", Text: "GET /", Timestamp: at(6, 0), Seq: 2},
+	}
+
+	lines, err := dumpProjectLogs("shop", buffered, at(0, 0), time.Time{})
+	if err != nil {
+		t.Fatalf("dump: %v", err)
+	}
+	want := []string{"api booting", "worker job failed", "api evicted twin", "api ready", "api GET /"}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d: %+v", len(lines), len(want), lines)
+	}
+	for i, line := range lines {
+		if got := line.Service + " " + line.Text; got != want[i] {
+			t.Fatalf("line %d = %q, want %q", i, got, want[i])
+		}
+	}
+}
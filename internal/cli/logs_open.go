@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sourabhrathourr/hun/internal/daemon"
+	"github.com/sourabhrathourr/hun/internal/opener"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	logsCmd.AddCommand(logsOpenCmd)
+}
+
+var logsOpenCmd = &cobra.Command{
+	Use:   "open <project>[:<service>]",
+	Short: "Open a project's log folder, or reveal one service's log file, in the file manager",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		project, service := parseTarget(args[0])
+		path, reveal, err := logsOpenTarget(project, service)
+		if err != nil {
+			return err
+		}
+		if reveal {
+			fmt.Printf("Revealing %s\n", path)
+			return opener.Reveal(path)
+		}
+		fmt.Printf("Opening %s\n", path)
+		return opener.Open(path)
+	},
+}
+
+// logsOpenTarget picks what `hun logs open` shows: the service's log file
+// to reveal, or the project's log folder to open.
+func logsOpenTarget(project, service string) (path string, reveal bool, err error) {
+	if service != "" {
+		path, err = daemon.LogFilePath(project, service)
+		reveal = true
+	} else {
+		path, err = daemon.ProjectLogDir(project)
+	}
+	if err != nil {
+		return "", false, err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return "", false, fmt.Errorf("no logs written yet at %s", path)
+	} else if err != nil {
+		return "", false, err
+	}
+	return path, reveal, nil
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sourabhrathourr/hun/internal/daemon"
+	"github.com/spf13/cobra"
+)
+
+func TestResolveLogScope(t *testing.T) {
+	tests := []struct {
+		target, project, service string
+		want                     logScope
+		wantErr                  bool
+	}{
+		{target: "shop:api", want: logScope{project: "shop", service: "api"}},
+		{target: "shop:all", want: logScope{project: "shop"}},
+		{target: "shop", service: "all", want: logScope{project: "shop"}},
+		{project: "all", service: "all", want: logScope{allProjects: true}},
+		{target: "all:all", want: logScope{allProjects: true}},
+		{target: "shop", wantErr: true},
+		{project: "all", service: "api", wantErr: true},
+		{wantErr: true},
+	}
+	for _, tc := range tests {
+		got, err := resolveLogScope(tc.target, tc.project, tc.service)
+		if tc.wantErr {
+			if err == nil {
+				t.Fatalf("resolveLogScope(%q, %q, %q) = %+v, want error", tc.target, tc.project, tc.service, got)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Fatalf("resolveLogScope(%q, %q, %q) = %+v, %v; want %+v", tc.target, tc.project, tc.service, got, err, tc.want)
+		}
+	}
+
+	if projectColor("shop") != projectColor("shop") {
+		t.Fatal("project colors must be stable")
+	}
+}
+
+func TestTargetFromArgsAcceptsProjectAndService(t *testing.T) {
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{nil, ""},
+		{[]string{"shop:api"}, "shop:api"},
+		{[]string{"shop", "api"}, "shop:api"},
+		{[]string{"shop", "all"}, "shop:all"},
+	} {
+		if got, err := targetFromArgs(tc.args); err != nil || got != tc.want {
+			t.Fatalf("targetFromArgs(%q) = %q, %v; want %q", tc.args, got, err, tc.want)
+		}
+	}
+	if _, err := targetFromArgs([]string{"shop:api", "web"}); err == nil {
+		t.Fatal("mixing project:service with a service argument should fail")
+	}
+}
+
+func TestJSONLogPrinterWritesOneObjectPerLine(t *testing.T) {
+	var buf bytes.Buffer
+	emit := jsonLogPrinter(&buf)
+	ts := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	emit(daemon.LogLine{Timestamp: ts, Project: "shop", Service: "api", Text: "boom", IsErr: true})
+	emit(daemon.LogLine{Timestamp: ts, Project: "shop", Service: "api", Text: "ok"})
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines:\n%s", len(lines), buf.String())
+	}
+	var first map[string]any
+	if err := json.Unmarshal(lines[0], &first); err != nil {
+		t.Fatal(err)
+	}
+	if first["is_err"] != true || first["text"] != "boom" || first["timestamp"] != "2024-03-01T10:00:00Z" {
+		t.Fatalf("first line = %v", first)
+	}
+}
+
+func TestLogFollowPlanForTailAndNoBuffer(t *testing.T) {
+	tests := []struct {
+		args        []string
+		allProjects bool
+		wantFollow  bool
+		wantLines   int
+		wantErr     bool
+	}{
+		{args: nil, wantFollow: false, wantLines: 500},
+		{args: []string{"-n", "50"}, wantFollow: false, wantLines: 50},
+		{args: []string{"--tail", "20"}, wantFollow: true, wantLines: 20},
+		{args: []string{"--tail", "0"}, wantFollow: true, wantLines: 0},
+		{args: []string{"--no-buffer"}, wantFollow: true, wantLines: 0},
+		{args: []string{"--no-buffer", "--tail", "20"}, wantFollow: true, wantLines: 0},
+		{args: []string{"--tail", "-1"}, wantErr: true},
+		{args: []string{"--follow", "--since", "10m"}, wantFollow: true, wantLines: 500},
+		{args: []string{"--tail", "0", "--since", "10m"}, wantErr: true},
+		{args: []string{"--no-buffer", "--until", "1m"}, wantErr: true},
+		{args: nil, allProjects: true, wantFollow: true, wantLines: 0},
+		{args: []string{"--since", "10m"}, allProjects: true, wantErr: true},
+		{args: []string{"--tail", "5", "--since", "10m"}, allProjects: true, wantFollow: true, wantLines: 5},
+	}
+	for _, tc := range tests {
+		cmd := &cobra.Command{}
+		registerLogsFlags(cmd)
+		if err := cmd.ParseFlags(tc.args); err != nil {
+			t.Fatalf("parse %q: %v", tc.args, err)
+		}
+		follow, lines, err := logFollowPlan(cmd, tc.allProjects)
+		if tc.wantErr {
+			if err == nil {
+				t.Fatalf("logFollowPlan(%q) = %v, %d; want error", tc.args, follow, lines)
+			}
+			continue
+		}
+		if err != nil || follow != tc.wantFollow || lines != tc.wantLines {
+			t.Fatalf("logFollowPlan(%q) = %v, %d, %v; want %v, %d", tc.args, follow, lines, err, tc.wantFollow, tc.wantLines)
+		}
+	}
+}
@@ -142,26 +142,22 @@ func runOnboardingFlow(opts onboardingOptions) (onboardingResult, error) {
 
 func onboardProjectDir(dir string) (string, error) {
 	if config.ProjectExists(dir) {
-		proj, err := config.LoadProject(dir)
-		if err != nil {
-			return "", err
-		}
-		if err := registerProject(proj.Name, dir); err != nil {
-			return "", err
-		}
-		fmt.Printf("%s Ready: %s\n", checkmark(), proj.Name)
-		return proj.Name, nil
+		return registerExistingProject(dir)
 	}
 
 	name := filepath.Base(dir)
-	proj, aborted, err := prepareProjectFromDetection(name, dir, "", false, false)
+	proj, aborted, err := prepareProjectFromDetection(name, dir, "", nil, false, false)
 	if err != nil {
 		return "", err
 	}
 	if aborted {
 		return "", errOnboardingCanceled
 	}
-	if err := config.WriteProject(dir, proj); err != nil {
+	if err := config.WriteProject(dir, proj, false); err != nil {
+		if errors.Is(err, config.ErrProjectExists) {
+			// Another run wrote .hun.yml while we were detecting; keep it.
+			return registerExistingProject(dir)
+		}
 		return "", err
 	}
 	fmt.Printf("%s Created .hun.yml\n", checkmark())
@@ -171,6 +167,18 @@ func onboardProjectDir(dir string) (string, error) {
 	return proj.Name, nil
 }
 
+func registerExistingProject(dir string) (string, error) {
+	proj, err := config.LoadProject(dir)
+	if err != nil {
+		return "", err
+	}
+	if err := registerProject(proj.Name, dir); err != nil {
+		return "", err
+	}
+	fmt.Printf("%s Ready: %s\n", checkmark(), proj.Name)
+	return proj.Name, nil
+}
+
 func startProjectInFocus(project string) error {
 	c, err := client.New()
 	if err != nil {
@@ -468,7 +476,10 @@ func projectRelevanceScore(path, cwd string) int {
 	}
 	for _, marker := range []string{
 		"package.json",
+		"deno.json",
+		"deno.jsonc",
 		"go.mod",
+		"Cargo.toml",
 		"pyproject.toml",
 		"requirements.txt",
 		"manage.py",
@@ -542,7 +553,10 @@ func looksLikeProjectDir(dir string) bool {
 
 	markers := []string{
 		"package.json",
+		"deno.json",
+		"deno.jsonc",
 		"go.mod",
+		"Cargo.toml",
 		"pyproject.toml",
 		"requirements.txt",
 		"manage.py",
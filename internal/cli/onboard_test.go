@@ -31,6 +31,32 @@ func TestShouldPromptAutoOnboard(t *testing.T) {
 	}
 }
 
+func TestUseCLIDashboardHonorsFlagsOverGlobalUI(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("HUN_HOME", "")
+
+	if useCLIDashboard(false, false) {
+		t.Fatalf("bare hun should open the TUI by default")
+	}
+	if !useCLIDashboard(true, false) {
+		t.Fatalf("--no-tui should print status")
+	}
+
+	if err := os.MkdirAll(filepath.Join(home, ".hun"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".hun", "config.yml"), []byte("ui: cli\n"), 0o644); err != nil {
+		t.Fatalf("write global config: %v", err)
+	}
+	if !useCLIDashboard(false, false) {
+		t.Fatalf("ui: cli should make bare hun print status")
+	}
+	if useCLIDashboard(false, true) {
+		t.Fatalf("--tui should override ui: cli")
+	}
+}
+
 func TestResolveOnboardingPath(t *testing.T) {
 	dir := t.TempDir()
 	got, err := resolveOnboardingPath(dir)
@@ -3,12 +3,10 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
-	"os/exec"
-	"runtime"
 	"sort"
 
-	"github.com/sourabhrathourr/hun/internal/client"
 	"github.com/sourabhrathourr/hun/internal/daemon"
+	"github.com/sourabhrathourr/hun/internal/opener"
 	"github.com/spf13/cobra"
 )
 
@@ -20,7 +18,7 @@ var openCmd = &cobra.Command{
 	Use:   "open [service]",
 	Short: "Open a service URL in the browser",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		c, err := client.New()
+		c, err := readOnlyClient()
 		if err != nil {
 			return err
 		}
@@ -56,7 +54,7 @@ var openCmd = &cobra.Command{
 				if target == "" || svc == target {
 					url := fmt.Sprintf("http://localhost:%d", port)
 					fmt.Printf("Opening %s:%s at %s\n", proj, svc, url)
-					return openBrowser(url)
+					return opener.Open(url)
 				}
 			}
 		}
@@ -67,14 +65,3 @@ var openCmd = &cobra.Command{
 		return fmt.Errorf("no running services with ports")
 	},
 }
-
-func openBrowser(url string) error {
-	switch runtime.GOOS {
-	case "darwin":
-		return exec.Command("open", url).Start()
-	case "linux":
-		return exec.Command("xdg-open", url).Start()
-	default:
-		return fmt.Errorf("unsupported platform")
-	}
-}
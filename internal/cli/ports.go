@@ -5,9 +5,7 @@ import (
 	"fmt"
 	"sort"
 
-	"github.com/sourabhrathourr/hun/internal/client"
 	"github.com/sourabhrathourr/hun/internal/daemon"
-	"github.com/sourabhrathourr/hun/internal/state"
 	"github.com/spf13/cobra"
 )
 
@@ -19,7 +17,7 @@ var portsCmd = &cobra.Command{
 	Use:   "ports",
 	Short: "Show port map for all running services",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		c, err := client.New()
+		c, err := readOnlyClient()
 		if err != nil {
 			return err
 		}
@@ -42,7 +40,7 @@ var portsCmd = &cobra.Command{
 			return nil
 		}
 
-		st, _ := state.Load()
+		st, _ := loadState()
 
 		projects := make([]string, 0, len(ports))
 		for name := range ports {
@@ -1,37 +1,25 @@
 package cli
 
 import (
+	"bufio"
 	"fmt"
+	"os"
+	"slices"
+	"strings"
 
 	"github.com/sourabhrathourr/hun/internal/config"
 	"github.com/sourabhrathourr/hun/internal/detect"
 )
 
 // prepareProjectFromDetection runs service detection and returns a generated project config.
-// If the user declines generation, aborted is true and err is nil.
-func prepareProjectFromDetection(name, dir, requestedProfile string, reconfigure bool, autoApprove bool) (proj *config.Project, aborted bool, err error) {
-	if requestedProfile != "" {
-		normalized := detect.NormalizeProfile(requestedProfile)
-		if normalized == "" {
-			return nil, false, fmt.Errorf("invalid --profile %q (expected local|compose|hybrid)", requestedProfile)
-		}
-		requestedProfile = normalized
+// composeProfiles selects compose profiles to include; nil means ask, or none
+// when the terminal can't prompt. If the user declines generation, aborted is
+// true and err is nil.
+func prepareProjectFromDetection(name, dir, requestedProfile string, composeProfiles []string, reconfigure bool, autoApprove bool) (proj *config.Project, aborted bool, err error) {
+	result, err := resolveDetection(dir, requestedProfile, composeProfiles)
+	if err != nil {
+		return nil, false, err
 	}
-
-	analysis := detect.Analyze(dir)
-	profile := requestedProfile
-	if profile == "" {
-		profile = detect.ProfileHybrid
-		if len(analysis.Conflicts) > 0 && isInteractiveTerminal() {
-			selected, selectErr := promptProfileSelection(analysis.Conflicts)
-			if selectErr != nil {
-				return nil, false, selectErr
-			}
-			profile = selected
-		}
-	}
-
-	result := detect.Resolve(analysis, profile)
 	if len(result.Services) == 0 {
 		fmt.Println("No project structure detected.")
 		fmt.Println("Creating minimal .hun.yml...")
@@ -52,7 +40,7 @@ func prepareProjectFromDetection(name, dir, requestedProfile string, reconfigure
 			Services: map[string]*config.Service{
 				"app": {Cmd: "echo 'replace with your command'"},
 			},
-			Detect: config.DetectConfig{Version: "v2", Profile: result.Profile},
+			Detect: config.DetectConfig{Version: "v2", Profile: result.Profile, ComposeProfiles: result.ComposeProfiles},
 		}, false, nil
 	}
 
@@ -78,6 +66,133 @@ func prepareProjectFromDetection(name, dir, requestedProfile string, reconfigure
 	return detectedToProject(name, result), false, nil
 }
 
+// resolveDetection analyzes dir and resolves compose/local overlaps using
+// requestedProfile, prompting for one when it is empty and the terminal allows.
+// Compose services gated behind profiles are kept only when their profile is
+// in composeProfiles, which is likewise prompted for when nil.
+func resolveDetection(dir, requestedProfile string, composeProfiles []string) (detect.Result, error) {
+	if requestedProfile != "" {
+		normalized := detect.NormalizeProfile(requestedProfile)
+		if normalized == "" {
+			return detect.Result{}, fmt.Errorf("invalid --profile %q (expected local|compose|hybrid)", requestedProfile)
+		}
+		requestedProfile = normalized
+	}
+
+	analysis := analyzeWithSpinner(dir)
+	available := detect.ComposeProfiles(analysis)
+	for _, p := range composeProfiles {
+		if !slices.Contains(available, p) {
+			return detect.Result{}, fmt.Errorf("unknown compose profile %q (available: %s)", p, composeProfileList(available))
+		}
+	}
+	if composeProfiles == nil && len(available) > 0 && isInteractiveTerminal() {
+		selected, err := promptComposeProfiles(available)
+		if err != nil {
+			return detect.Result{}, err
+		}
+		composeProfiles = selected
+	}
+	analysis = detect.WithComposeProfiles(analysis, composeProfiles)
+
+	profile := requestedProfile
+	if profile == "" {
+		profile = detect.ProfileHybrid
+		if len(analysis.Conflicts) > 0 && isInteractiveTerminal() {
+			selected, err := promptProfileSelection(analysis.Conflicts)
+			if err != nil {
+				return detect.Result{}, err
+			}
+			profile = selected
+		}
+	}
+	result := detect.Resolve(analysis, profile)
+	if len(composeProfiles) > 0 {
+		result.ComposeProfiles = composeProfiles
+	}
+	return result, nil
+}
+
+// promptComposeProfiles asks which of the available compose profiles to
+// include. A blank answer keeps only profile-less services.
+func promptComposeProfiles(available []string) ([]string, error) {
+	fmt.Printf("Compose services are gated behind profiles: %s\n", strings.Join(available, ", "))
+	fmt.Print("Include profiles (comma-separated, blank for none): ")
+
+	reader := bufio.NewReader(os.Stdin)
+	answer, err := reader.ReadString('\n')
+	if err != nil && !strings.Contains(err.Error(), "EOF") {
+		return nil, err
+	}
+	var selected []string
+	for _, p := range strings.Split(answer, ",") {
+		p = strings.TrimSpace(p)
+		switch {
+		case p == "" || slices.Contains(selected, p):
+		case slices.Contains(available, p):
+			selected = append(selected, p)
+		default:
+			fmt.Printf("Unknown profile %q, skipping.\n", p)
+		}
+	}
+	return selected, nil
+}
+
+func composeProfileList(profiles []string) string {
+	if len(profiles) == 0 {
+		return "none"
+	}
+	return strings.Join(profiles, ", ")
+}
+
+// mergeDetectedServices runs detection and returns existing extended with the
+// services it does not define yet, leaving existing definitions untouched.
+// added is empty when there is nothing new.
+func mergeDetectedServices(existing *config.Project, dir, requestedProfile string, composeProfiles []string, autoApprove bool) (proj *config.Project, added []string, aborted bool, err error) {
+	if composeProfiles == nil && existing.Detect.ComposeProfiles != nil {
+		composeProfiles = existing.Detect.ComposeProfiles
+	}
+	result, err := resolveDetection(dir, requestedProfile, composeProfiles)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	detected := detectedToProject(existing.Name, result)
+	proj, added, err = config.ProjectWithNewServices(existing, detected.Services)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if len(added) == 0 {
+		return proj, nil, false, nil
+	}
+	proj.Detect.ComposeProfiles = result.ComposeProfiles
+
+	fmt.Println("New services detected:")
+	fmt.Println()
+	for _, name := range added {
+		svc := proj.Services[name]
+		port := ""
+		if svc.Port > 0 {
+			port = fmt.Sprintf(" (port %d)", svc.Port)
+		}
+		fmt.Printf("  + %s%s\n", name, port)
+		fmt.Printf("    -> %s\n", svc.Cmd)
+		fmt.Println()
+	}
+	if !autoApprove {
+		if !isInteractiveTerminal() {
+			return nil, nil, false, fmt.Errorf("detected new services but cannot prompt in non-interactive mode; rerun in a terminal or pass --yes")
+		}
+		ok, confirmErr := confirmPrompt("Add these services to .hun.yml? [Y/n] ")
+		if confirmErr != nil {
+			return nil, nil, false, confirmErr
+		}
+		if !ok {
+			return nil, nil, true, nil
+		}
+	}
+	return proj, added, false, nil
+}
+
 func printDetectionSummary(result detect.Result) {
 	fmt.Println("Detected project structure:")
 	fmt.Println()
@@ -100,3 +215,18 @@ func printDetectionSummary(result detect.Result) {
 		fmt.Printf("Resolved %d compose/local conflicts.\n\n", len(result.Conflicts))
 	}
 }
+
+// analyzeWithSpinner runs detection, showing the current step on stderr in an
+// interactive terminal so large monorepos don't look hung. Stderr must be the
+// terminal too, or `2>file` would collect the spinner's control sequences.
+func analyzeWithSpinner(dir string) detect.Analysis {
+	if !isInteractiveTerminal() {
+		return detect.Analyze(dir)
+	}
+	if info, err := os.Stderr.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return detect.Analyze(dir)
+	}
+	sp := startSpinner(os.Stderr, "detecting services")
+	defer sp.Stop()
+	return detect.AnalyzeWithProgress(dir, sp.Step)
+}
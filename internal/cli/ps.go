@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/sourabhrathourr/hun/internal/daemon"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	psCmd.Flags().Bool("json", false, "Print the daemon's status map as JSON")
+	rootCmd.AddCommand(psCmd)
+}
+
+var psCmd = &cobra.Command{
+	Use:   "ps",
+	Short: "List the services of running projects as a table",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		c, err := readOnlyClient()
+		if err != nil {
+			return err
+		}
+		status, err := fetchStatus(c)
+		if err != nil {
+			return err
+		}
+		if asJSON {
+			if status == nil {
+				status = map[string]map[string]daemon.ServiceInfo{}
+			}
+			out, err := json.MarshalIndent(status, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = os.Stdout.Write(append(out, '\n'))
+			return err
+		}
+		printPS(os.Stdout, status)
+		return nil
+	},
+}
+
+// printPS writes one aligned row per service, ordered by project then
+// service.
+func printPS(w io.Writer, status map[string]map[string]daemon.ServiceInfo) {
+	if len(status) == 0 {
+		fmt.Fprintln(w, "No running projects.")
+		return
+	}
+	type psRow struct {
+		name string
+		info daemon.ServiceInfo
+	}
+	var rows []psRow
+	width := len("SERVICE")
+	for project, services := range status {
+		for service, info := range services {
+			name := project + ":" + service
+			rows = append(rows, psRow{name: name, info: info})
+			width = max(width, len(name))
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].name < rows[j].name })
+
+	fmt.Fprintf(w, "%-*s  %7s  %6s  %-8s  %s\n", width, "SERVICE", "PID", "PORT", "STATUS", "READY")
+	for _, row := range rows {
+		pid, port, ready := "-", "-", "no"
+		if row.info.PID > 0 {
+			pid = fmt.Sprint(row.info.PID)
+		}
+		if row.info.Port > 0 {
+			port = fmt.Sprintf(":%d", row.info.Port)
+		}
+		if row.info.Ready {
+			ready = "yes"
+		}
+		fmt.Fprintf(w, "%-*s  %7s  %6s  %-8s  %s\n", width, row.name, pid, port, serviceStatusText(row.info), ready)
+	}
+}
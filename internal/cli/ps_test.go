@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sourabhrathourr/hun/internal/daemon"
+)
+
+func TestPrintPSAlignsOneRowPerService(t *testing.T) {
+	status := map[string]map[string]daemon.ServiceInfo{
+		"shop": {
+			"api":    {PID: 4242, Port: 3000, Running: true, Ready: true, Status: "running"},
+			"worker": {Status: "crashed"},
+		},
+		"blog": {
+			"db": {PID: 77, Running: true},
+		},
+	}
+
+	var b strings.Builder
+	printPS(&b, status)
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	want := []string{
+		"SERVICE          PID    PORT  STATUS    READY",
+		"blog:db           77       -  running   no",
+		"shop:api        4242   :3000  running   yes",
+		"shop:worker        -       -  crashed   no",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("ps output:\n%s\nwant:\n%s", b.String(), strings.Join(want, "\n"))
+	}
+
+	b.Reset()
+	printPS(&b, nil)
+	if strings.TrimSpace(b.String()) != "No running projects." {
+		t.Fatalf("empty ps = %q", b.String())
+	}
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/sourabhrathourr/hun/internal/daemon"
+	"github.com/spf13/cobra"
+)
+
+var (
+	readyQuiet bool
+	readyJSON  bool
+)
+
+// errNotReady makes `hun ready` exit non-zero without printing usage.
+var errNotReady = errors.New("not ready")
+
+func init() {
+	readyCmd.Flags().BoolVarP(&readyQuiet, "quiet", "q", false, "Print nothing; only set the exit code")
+	readyCmd.Flags().BoolVar(&readyJSON, "json", false, "Print the readiness report as JSON")
+	rootCmd.AddCommand(readyCmd)
+}
+
+var readyCmd = &cobra.Command{
+	Use:   "ready <project> [service|tag:<name>...]",
+	Short: "Exit 0 when every (or each listed) service is ready",
+	Long: "Report whether a project's services are running and ready. The exit code is 0 only when\n" +
+		"all selected services are ready, so it can gate scripts:\n\n" +
+		"  until hun ready shop; do sleep 1; done",
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := readOnlyClient()
+		if err != nil {
+			return err
+		}
+		resp, err := c.Send(daemon.Request{Action: "ready", Project: args[0], Only: args[1:]})
+		if err != nil {
+			return err
+		}
+		if !resp.OK {
+			return fmt.Errorf("%s", resp.Error)
+		}
+
+		var report daemon.ReadyReport
+		if err := json.Unmarshal(resp.Data, &report); err != nil {
+			return err
+		}
+
+		switch {
+		case readyJSON:
+			out, _ := json.MarshalIndent(report, "", "  ")
+			fmt.Println(string(out))
+		case !readyQuiet:
+			printReadyReport(report)
+		}
+		if !report.Ready {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			return errNotReady
+		}
+		return nil
+	},
+}
+
+func printReadyReport(report daemon.ReadyReport) {
+	names := make([]string, 0, len(report.Services))
+	for name := range report.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		detail := report.Services[name]
+		mark := "✗"
+		if detail.Ready {
+			mark = checkmark()
+		}
+		status := detail.Status
+		if detail.Running && !detail.Ready {
+			status = "starting"
+		}
+		fmt.Fprintf(os.Stdout, "  %s %-20s %s\n", mark, name, status)
+	}
+	if report.Ready {
+		fmt.Printf("%s %s ready\n", checkmark(), report.Project)
+	} else {
+		fmt.Printf("✗ %s not ready\n", report.Project)
+	}
+}
@@ -0,0 +1,257 @@
+package cli
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sourabhrathourr/hun/internal/config"
+	"github.com/sourabhrathourr/hun/internal/detect"
+)
+
+// remoteTarget is a parsed user@host:/path init target.
+type remoteTarget struct {
+	Host string // ssh destination, including an optional user@ prefix
+	Path string // absolute or home-relative path on the remote machine
+}
+
+// remoteMarkerFiles are fetched with their content, as are the env_file
+// targets of fetched compose files; every other file found on the remote side
+// is mirrored as an empty placeholder so detectors that only check for
+// existence (lockfiles, main.go, cmd/) still see it.
+var remoteMarkerFiles = map[string]bool{
+	"package.json": true, "pnpm-workspace.yaml": true, "go.mod": true,
+	".env": true, ".env.local": true, ".env.development": true,
+	"deno.json": true, "deno.jsonc": true, "Cargo.toml": true, "main.rs": true,
+	"pyproject.toml": true, "requirements.txt": true,
+	"docker-compose.yml": true, "docker-compose.yaml": true, "compose.yml": true, "compose.yaml": true,
+	"docker-compose.override.yml": true, "docker-compose.override.yaml": true, "compose.override.yml": true, "compose.override.yaml": true,
+	"Makefile": true, "makefile": true, "GNUmakefile": true,
+	"Procfile": true, "Procfile.dev": true,
+}
+
+const remoteMirrorLimit = 5000
+
+func parseRemoteTarget(raw string) (remoteTarget, error) {
+	host, dir, ok := strings.Cut(strings.TrimSpace(raw), ":")
+	if !ok || host == "" || dir == "" || strings.Contains(host, "/") {
+		return remoteTarget{}, fmt.Errorf("invalid --remote %q (expected user@host:/path)", raw)
+	}
+	return remoteTarget{Host: host, Path: strings.TrimRight(dir, "/")}, nil
+}
+
+func (t remoteTarget) String() string {
+	return t.Host + ":" + t.Path
+}
+
+// mirrorRemoteProject copies enough of the remote tree into a temp dir for the
+// local detectors to run against it. The caller removes the returned dir.
+func mirrorRemoteProject(t remoteTarget) (string, error) {
+	if _, err := exec.LookPath("ssh"); err != nil {
+		return "", errors.New("ssh not found in PATH; install an OpenSSH client or run `hun init` on the remote machine")
+	}
+
+	list := fmt.Sprintf(
+		"cd %s && find . -maxdepth 4 \\( -name node_modules -o -name .git -o -name .venv -o -name vendor -o -name dist \\) -prune -o -type f -print | head -n %d",
+		remoteDirArg(t.Path), remoteMirrorLimit)
+	out, err := runSSH(t.Host, list)
+	if err != nil {
+		return "", fmt.Errorf("listing %s: %w", t, err)
+	}
+
+	var markers, placeholders []string
+	listed := make(map[string]bool)
+	for _, line := range strings.Split(string(out), "\n") {
+		rel := path.Clean(strings.TrimSpace(line))
+		if rel == "." || rel == "" || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		listed[rel] = true
+		if remoteMarkerFiles[path.Base(rel)] {
+			markers = append(markers, rel)
+		} else {
+			placeholders = append(placeholders, rel)
+		}
+	}
+	if len(markers) == 0 && len(placeholders) == 0 {
+		return "", fmt.Errorf("no files found at %s", t)
+	}
+
+	dir, err := os.MkdirTemp("", "hun-remote-*")
+	if err != nil {
+		return "", err
+	}
+	for _, rel := range placeholders {
+		if err := writeMirrorFile(dir, rel, nil); err != nil {
+			os.RemoveAll(dir)
+			return "", err
+		}
+	}
+	if err := fetchRemoteFiles(t, dir, markers); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	// env_file targets can have any name, so they are only known once the
+	// compose files are here.
+	if err := fetchRemoteFiles(t, dir, remoteComposeEnvFiles(dir, markers, listed)); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
+}
+
+// fetchRemoteFiles copies the content of the remote files rels into dir.
+func fetchRemoteFiles(t remoteTarget, dir string, rels []string) error {
+	if len(rels) == 0 {
+		return nil
+	}
+	sort.Strings(rels)
+	quoted := make([]string, len(rels))
+	for i, rel := range rels {
+		quoted[i] = shellQuote(rel)
+	}
+	archive, err := runSSH(t.Host, fmt.Sprintf("cd %s && tar cf - %s", remoteDirArg(t.Path), strings.Join(quoted, " ")))
+	if err != nil {
+		return fmt.Errorf("fetching project files from %s: %w", t, err)
+	}
+	return extractMirrorArchive(dir, archive)
+}
+
+// remoteComposeEnvFiles returns the env_file targets named by the compose
+// files mirrored into dir that were listed on the remote side and not fetched
+// already.
+func remoteComposeEnvFiles(dir string, markers []string, listed map[string]bool) []string {
+	fetched := make(map[string]bool, len(markers))
+	composeDirs := make(map[string]bool)
+	for _, rel := range markers {
+		fetched[rel] = true
+		switch path.Base(rel) {
+		case "docker-compose.yml", "docker-compose.yaml", "compose.yml", "compose.yaml":
+			composeDirs[path.Dir(rel)] = true
+		}
+	}
+	var files []string
+	for composeDir := range composeDirs {
+		for _, file := range detect.ComposeEnvFiles(filepath.Join(dir, filepath.FromSlash(composeDir))) {
+			rel := path.Join(composeDir, file)
+			if listed[rel] && !fetched[rel] {
+				fetched[rel] = true
+				files = append(files, rel)
+			}
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+func runSSH(host, command string) ([]byte, error) {
+	cmd := exec.Command("ssh", "-o", "BatchMode=yes", host, command)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+func extractMirrorArchive(dir string, archive []byte) error {
+	tr := tar.NewReader(bytes.NewReader(archive))
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading remote archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, 1<<20))
+		if err != nil {
+			return fmt.Errorf("reading remote archive: %w", err)
+		}
+		if err := writeMirrorFile(dir, hdr.Name, data); err != nil {
+			return err
+		}
+	}
+}
+
+func writeMirrorFile(root, rel string, data []byte) error {
+	rel = path.Clean(rel)
+	if path.IsAbs(rel) || strings.HasPrefix(rel, "..") {
+		return fmt.Errorf("refusing remote path outside project: %s", rel)
+	}
+	dest := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(dest, data, 0o644)
+}
+
+// remoteizeProject rewrites detected services to run on the remote host over
+// ssh, forwarding each service port back to localhost.
+func remoteizeProject(proj *config.Project, t remoteTarget) {
+	for _, svc := range proj.Services {
+		svc.Cmd = remoteServiceCmd(t, svc)
+		svc.Cwd = ""
+		svc.PortEnv = ""
+	}
+}
+
+func remoteServiceCmd(t remoteTarget, svc *config.Service) string {
+	dir := t.Path
+	if svc.Cwd != "" {
+		dir = path.Join(t.Path, filepath.ToSlash(svc.Cwd))
+	}
+	remote := "cd " + remoteDirArg(dir) + " && "
+	if svc.Port > 0 {
+		portEnv := svc.PortEnv
+		if portEnv == "" {
+			portEnv = "PORT"
+		}
+		remote += fmt.Sprintf("%s=%d ", portEnv, svc.Port)
+	}
+	remote += svc.Cmd
+
+	// -tt gives the remote command a pty so it dies with the ssh session on stop.
+	args := []string{"ssh", "-tt", "-o", "ExitOnForwardFailure=yes"}
+	if svc.Port > 0 {
+		args = append(args, "-L", fmt.Sprintf("%d:localhost:%d", svc.Port, svc.Port))
+	}
+	args = append(args, shellQuote(t.Host), shellQuote(remote))
+	return strings.Join(args, " ")
+}
+
+// remoteDirArg quotes a remote directory while leaving a leading ~ unquoted so
+// the remote shell still expands it.
+func remoteDirArg(dir string) string {
+	if dir == "~" {
+		return dir
+	}
+	if rest, ok := strings.CutPrefix(dir, "~/"); ok {
+		return "~/" + shellQuote(rest)
+	}
+	return shellQuote(dir)
+}
+
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("@%_-+=:,./", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/sourabhrathourr/hun/internal/config"
+)
+
+func TestRemoteServiceCmdForwardsPortAndRunsInRemoteDir(t *testing.T) {
+	target, err := parseRemoteTarget("dev@box:~/src/shop/")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if target.Host != "dev@box" || target.Path != "~/src/shop" {
+		t.Fatalf("target = %+v", target)
+	}
+	if _, err := parseRemoteTarget("/just/a/path"); err == nil {
+		t.Fatal("expected a plain path to be rejected")
+	}
+
+	proj := &config.Project{
+		Name: "shop",
+		Services: map[string]*config.Service{
+			"api":    {Cmd: "npm run dev", Cwd: "backend", Port: 4000, PortEnv: "API_PORT"},
+			"worker": {Cmd: "python worker.py"},
+		},
+	}
+	remoteizeProject(proj, target)
+
+	want := `ssh -tt -o ExitOnForwardFailure=yes -L 4000:localhost:4000 dev@box 'cd ~/src/shop/backend && API_PORT=4000 npm run dev'`
+	if got := proj.Services["api"].Cmd; got != want {
+		t.Fatalf("api cmd =\n%s\nwant\n%s", got, want)
+	}
+	if proj.Services["api"].Cwd != "" || proj.Services["api"].PortEnv != "" {
+		t.Fatalf("expected cwd and port_env cleared for remote service, got %+v", proj.Services["api"])
+	}
+	if got := proj.Services["worker"].Cmd; got != `ssh -tt -o ExitOnForwardFailure=yes dev@box 'cd ~/src/shop && python worker.py'` {
+		t.Fatalf("worker cmd = %s", got)
+	}
+}
+
+func TestRemoteComposeEnvFilesListsFetchableEnvFileTargets(t *testing.T) {
+	dir := t.TempDir()
+	compose := "services:\n  api:\n    env_file: [config/api.env, ../outside.env]\n  web:\n    env_file: .env\n"
+	if err := writeMirrorFile(dir, "stack/compose.yml", []byte(compose)); err != nil {
+		t.Fatal(err)
+	}
+	markers := []string{"stack/compose.yml", "stack/.env"}
+	listed := map[string]bool{"stack/compose.yml": true, "stack/.env": true, "stack/config/api.env": true}
+
+	got := remoteComposeEnvFiles(dir, markers, listed)
+	if len(got) != 1 || got[0] != "stack/config/api.env" {
+		t.Fatalf("remoteComposeEnvFiles = %q, want only stack/config/api.env", got)
+	}
+	for _, name := range []string{".env", ".env.local", ".env.development", "main.rs"} {
+		if !remoteMarkerFiles[name] {
+			t.Fatalf("%s should be fetched with its content", name)
+		}
+	}
+}
@@ -3,7 +3,6 @@ package cli
 import (
 	"fmt"
 
-	"github.com/sourabhrathourr/hun/internal/state"
 	"github.com/spf13/cobra"
 )
 
@@ -18,7 +17,7 @@ var removeCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 
-		st, err := state.Load()
+		st, err := loadState()
 		if err != nil {
 			return err
 		}
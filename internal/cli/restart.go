@@ -14,17 +14,27 @@ func init() {
 }
 
 var restartCmd = &cobra.Command{
-	Use:   "restart <project>[:<service>]",
-	Short: "Restart a project or specific service",
-	Args:  cobra.ExactArgs(1),
+	Use:   "restart <project>[:<service>] | <project> <service>",
+	Short: "Restart a running project or specific service",
+	Args:  cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		target := args[0]
+		target, err := targetFromArgs(args)
+		if err != nil {
+			return err
+		}
 		project, service := parseTarget(target)
 
 		c, err := client.New()
 		if err != nil {
 			return err
 		}
+		status, err := fetchStatus(c)
+		if err != nil {
+			return err
+		}
+		if _, running := status[project]; !running {
+			return fmt.Errorf("project %s is not running (start it with hun run %s)", project, project)
+		}
 
 		resp, err := c.Send(daemon.Request{
 			Action:  "restart",
@@ -34,6 +44,7 @@ var restartCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		reportDaemonRestart(c)
 		if !resp.OK {
 			return fmt.Errorf("%s", resp.Error)
 		}
@@ -47,6 +58,21 @@ var restartCmd = &cobra.Command{
 	},
 }
 
+// targetFromArgs joins `<project> <service>` arguments into the
+// project:service form; a single argument is passed through as is.
+func targetFromArgs(args []string) (string, error) {
+	switch len(args) {
+	case 0:
+		return "", nil
+	case 1:
+		return args[0], nil
+	}
+	if strings.Contains(args[0], ":") {
+		return "", fmt.Errorf("give either project:service or project service, not both")
+	}
+	return args[0] + ":" + args[1], nil
+}
+
 func parseTarget(target string) (project, service string) {
 	parts := strings.SplitN(target, ":", 2)
 	project = parts[0]
@@ -2,20 +2,40 @@ package cli
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 
+	"github.com/sourabhrathourr/hun/internal/config"
 	"github.com/sourabhrathourr/hun/internal/discovery"
-	"github.com/sourabhrathourr/hun/internal/state"
 	"github.com/spf13/cobra"
 )
 
-var multiFlag bool
+var (
+	multiFlag bool
+	noTUIFlag bool
+	tuiFlag   bool
+
+	configFlag string
+)
 
 var rootCmd = &cobra.Command{
 	Use:   "hun",
 	Short: "Seamless project context switching for developers",
 	Long:  "hun.sh manages your development services, captures logs, and lets you switch between projects instantly.",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return applyConfigOverride(configFlag)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if st, err := state.Load(); err == nil {
+		if useCLIDashboard(noTUIFlag, tuiFlag) {
+			if err := printStatus(); err != nil {
+				return err
+			}
+			fmt.Println("Open the dashboard with hun --tui.")
+			return nil
+		}
+
+		if st, err := loadState(); err == nil {
 			if _, dirty, reconcileErr := discovery.ReconcileState(st); reconcileErr == nil && dirty {
 				_ = st.Save()
 			}
@@ -46,6 +66,40 @@ var rootCmd = &cobra.Command{
 
 func init() {
 	rootCmd.Flags().BoolVar(&multiFlag, "multi", false, "Open TUI in Multitask Mode")
+	rootCmd.Flags().BoolVar(&noTUIFlag, "no-tui", false, "Print running projects and services instead of opening the TUI")
+	rootCmd.Flags().BoolVar(&tuiFlag, "tui", false, "Open the TUI even when ui: cli is set in ~/.hun/config.yml")
+	rootCmd.MarkFlagsMutuallyExclusive("no-tui", "tui")
+	rootCmd.PersistentFlags().StringVar(&configFlag, "config", "", "Use this global config file instead of ~/.hun/config.yml (same as HUN_CONFIG)")
+}
+
+// applyConfigOverride exports --config as HUN_CONFIG, so config.LoadGlobal in
+// this process and in any daemon it starts reads the same file. A daemon that
+// is already running keeps the config it was started with.
+func applyConfigOverride(path string) error {
+	if path == "" {
+		return nil
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(abs); err != nil {
+		return fmt.Errorf("--config: %w", err)
+	}
+	return os.Setenv("HUN_CONFIG", abs)
+}
+
+// useCLIDashboard reports whether a bare `hun` should print status and exit
+// rather than take over the terminal. Flags win over the global ui setting.
+func useCLIDashboard(noTUI, forceTUI bool) bool {
+	switch {
+	case noTUI:
+		return true
+	case forceTUI:
+		return false
+	}
+	g, err := config.LoadGlobal()
+	return err == nil && g.UI == "cli"
 }
 
 func Execute() error {
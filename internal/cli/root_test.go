@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigFlagOverridesGlobalConfigForThisRun(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("HUN_HOME", "")
+	t.Setenv("HUN_CONFIG", "")
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "ci.yml"), []byte("ui: cli\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	if err := applyConfigOverride(filepath.Join(dir, "missing.yml")); err == nil {
+		t.Fatal("--config with a missing file should fail")
+	}
+	if err := applyConfigOverride(filepath.Join(dir, "ci.yml")); err != nil {
+		t.Fatalf("applyConfigOverride: %v", err)
+	}
+	if got := os.Getenv("HUN_CONFIG"); got != filepath.Join(dir, "ci.yml") {
+		t.Fatalf("HUN_CONFIG = %q, want it exported for a daemon this run starts", got)
+	}
+	if !useCLIDashboard(false, false) {
+		t.Fatal("the overriding config's ui: cli should apply")
+	}
+}
@@ -9,6 +9,8 @@ import (
 )
 
 func init() {
+	runCmd.Flags().StringSlice("only", nil, "Start only these services (names or tag:<name>) and their dependencies")
+	runCmd.Flags().Bool("safe", false, "Debug start: one service at a time, no auto-restart, stop at the first crash")
 	rootCmd.AddCommand(runCmd)
 }
 
@@ -18,6 +20,8 @@ var runCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		project := args[0]
+		only, _ := cmd.Flags().GetStringSlice("only")
+		safe, _ := cmd.Flags().GetBool("safe")
 
 		c, err := client.New()
 		if err != nil {
@@ -28,10 +32,13 @@ var runCmd = &cobra.Command{
 			Action:  "start",
 			Project: project,
 			Mode:    "parallel",
+			Only:    only,
+			Safe:    safe,
 		})
 		if err != nil {
 			return err
 		}
+		reportDaemonRestart(c)
 		if !resp.OK {
 			return fmt.Errorf("%s", resp.Error)
 		}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinner draws a single self-overwriting status line until stopped.
+type spinner struct {
+	w    io.Writer
+	mu   sync.Mutex
+	msg  string
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func startSpinner(w io.Writer, msg string) *spinner {
+	s := &spinner{w: w, msg: msg, done: make(chan struct{})}
+	s.wg.Add(1)
+	go s.run()
+	return s
+}
+
+func (s *spinner) run() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for frame := 0; ; frame++ {
+		s.mu.Lock()
+		fmt.Fprintf(s.w, "\r\033[K%s %s…", spinnerFrames[frame%len(spinnerFrames)], s.msg)
+		s.mu.Unlock()
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Step replaces the message shown next to the spinner.
+func (s *spinner) Step(msg string) {
+	s.mu.Lock()
+	s.msg = msg
+	s.mu.Unlock()
+}
+
+// Stop halts the spinner and clears its line.
+func (s *spinner) Stop() {
+	close(s.done)
+	s.wg.Wait()
+	fmt.Fprint(s.w, "\r\033[K")
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/sourabhrathourr/hun/internal/client"
+	"github.com/sourabhrathourr/hun/internal/daemon"
+	"github.com/sourabhrathourr/hun/internal/state"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	stateCmd.AddCommand(stateExportCmd)
+	stateCmd.AddCommand(stateImportCmd)
+	rootCmd.AddCommand(stateCmd)
+}
+
+var stateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "Export or import hun's project registry and state",
+}
+
+var stateExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Write the daemon's full state as JSON (stdout by default)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := readOnlyClient()
+		if err != nil {
+			return err
+		}
+		resp, err := c.Send(daemon.Request{Action: "export_state"})
+		if err != nil {
+			return err
+		}
+		if !resp.OK {
+			return fmt.Errorf("%s", resp.Error)
+		}
+
+		out, err := json.MarshalIndent(resp.Data, "", "  ")
+		if err != nil {
+			return err
+		}
+		out = append(out, '\n')
+		if len(args) == 0 || args[0] == "-" {
+			_, err := os.Stdout.Write(out)
+			return err
+		}
+		if err := os.WriteFile(args[0], out, 0o644); err != nil {
+			return err
+		}
+		fmt.Printf("%s Exported state to %s\n", checkmark(), args[0])
+		return nil
+	},
+}
+
+var stateImportCmd = &cobra.Command{
+	Use:   "import <file|->",
+	Short: "Replace the daemon's state with an exported file (no projects may be running)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var (
+			data []byte
+			err  error
+		)
+		if args[0] == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(args[0])
+		}
+		if err != nil {
+			return err
+		}
+		if !json.Valid(data) {
+			return fmt.Errorf("%s is not valid JSON", args[0])
+		}
+
+		c, err := client.New()
+		if err != nil {
+			return err
+		}
+		resp, err := c.Send(daemon.Request{Action: "import_state", State: data})
+		if err != nil {
+			return err
+		}
+		if !resp.OK {
+			return fmt.Errorf("%s", resp.Error)
+		}
+
+		var result struct {
+			Projects int      `json:"projects"`
+			Skipped  []string `json:"skipped"`
+		}
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			return err
+		}
+		fmt.Printf("%s Imported state with %d projects\n", checkmark(), result.Projects)
+		if len(result.Skipped) > 0 {
+			fmt.Printf("  Skipped (no .hun.yml at the recorded path): %s\n", strings.Join(result.Skipped, ", "))
+		}
+		return nil
+	},
+}
+
+// loadState loads hun's state and warns on stderr when Load had to back up a
+// corrupt state file, which only the first command after the damage sees.
+func loadState() (*state.State, error) {
+	st, err := state.Load()
+	if err == nil && st.RecoveryWarning != "" {
+		fmt.Fprintln(os.Stderr, "hun: warning: "+st.RecoveryWarning)
+	}
+	return st, err
+}
@@ -18,67 +18,85 @@ var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show running projects and services",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		c, err := client.New()
-		if err != nil {
-			return err
-		}
+		return printStatus()
+	},
+}
 
-		resp, err := c.Send(daemon.Request{Action: "status"})
-		if err != nil {
-			return err
-		}
-		if !resp.OK {
-			return fmt.Errorf("%s", resp.Error)
-		}
+// fetchStatus returns the daemon's status: each running project's services.
+func fetchStatus(c *client.Client) (map[string]map[string]daemon.ServiceInfo, error) {
+	resp, err := c.Send(daemon.Request{Action: "status"})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.OK {
+		return nil, fmt.Errorf("%s", resp.Error)
+	}
+	var status map[string]map[string]daemon.ServiceInfo
+	if err := json.Unmarshal(resp.Data, &status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
 
-		var status map[string]map[string]daemon.ServiceInfo
-		if err := json.Unmarshal(resp.Data, &status); err != nil {
-			return err
-		}
+// printStatus prints running projects and their services' ports and states.
+func printStatus() error {
+	c, err := readOnlyClient()
+	if err != nil {
+		return err
+	}
+	status, err := fetchStatus(c)
+	if err != nil {
+		return err
+	}
 
-		if len(status) == 0 {
-			fmt.Println("No running projects.")
-			return nil
-		}
+	if len(status) == 0 {
+		fmt.Println("No running projects.")
+		return nil
+	}
 
-		projects := make([]string, 0, len(status))
-		for name := range status {
-			projects = append(projects, name)
-		}
-		sort.Strings(projects)
+	projects := make([]string, 0, len(status))
+	for name := range status {
+		projects = append(projects, name)
+	}
+	sort.Strings(projects)
 
-		for _, proj := range projects {
-			services := status[proj]
-			fmt.Printf("\u25cf %s\n", proj)
+	for _, proj := range projects {
+		services := status[proj]
+		fmt.Printf("\u25cf %s\n", proj)
 
-			svcNames := make([]string, 0, len(services))
-			for name := range services {
-				svcNames = append(svcNames, name)
-			}
-			sort.Strings(svcNames)
+		svcNames := make([]string, 0, len(services))
+		for name := range services {
+			svcNames = append(svcNames, name)
+		}
+		sort.Strings(svcNames)
 
-			for _, svc := range svcNames {
-				info := services[svc]
-				statusStr := info.Status
-				if statusStr == "" {
-					statusStr = "running"
-					if !info.Running {
-						statusStr = "stopped"
-					}
-				}
-				readyMark := " "
-				if info.Ready {
-					readyMark = "\u2713"
-				}
-				port := ""
-				if info.Port > 0 {
-					port = fmt.Sprintf(":%d", info.Port)
-				}
-				fmt.Printf("  %-20s %s %-6s %s\n", svc, readyMark, port, statusStr)
+		for _, svc := range svcNames {
+			info := services[svc]
+			statusStr := serviceStatusText(info)
+			readyMark := " "
+			if info.Ready {
+				readyMark = "\u2713"
+			}
+			port := ""
+			if info.Port > 0 {
+				port = fmt.Sprintf(":%d", info.Port)
 			}
-			fmt.Println()
+			fmt.Printf("  %-20s %s %-6s %s\n", svc, readyMark, port, statusStr)
 		}
+		fmt.Println()
+	}
 
-		return nil
-	},
+	return nil
+}
+
+// serviceStatusText is the daemon's status for a service, or running/stopped
+// when an older daemon didn't report one.
+func serviceStatusText(info daemon.ServiceInfo) string {
+	if info.Status != "" {
+		return info.Status
+	}
+	if info.Running {
+		return "running"
+	}
+	return "stopped"
 }
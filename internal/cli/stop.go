@@ -2,14 +2,18 @@ package cli
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/sourabhrathourr/hun/internal/client"
+	"github.com/sourabhrathourr/hun/internal/config"
 	"github.com/sourabhrathourr/hun/internal/daemon"
 	"github.com/spf13/cobra"
 )
 
 func init() {
 	stopCmd.Flags().Bool("all", false, "Stop all running projects")
+	stopCmd.Flags().BoolP("force", "f", false, "Stop protected projects without asking")
 	rootCmd.AddCommand(stopCmd)
 }
 
@@ -33,6 +37,18 @@ var stopCmd = &cobra.Command{
 			return fmt.Errorf("specify a project name or use --all")
 		}
 
+		force, _ := cmd.Flags().GetBool("force")
+		if !force {
+			ok, err := confirmProtectedStop(project)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+
 		resp, err := c.Send(daemon.Request{
 			Action:  "stop",
 			Project: project,
@@ -40,6 +56,7 @@ var stopCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		reportDaemonRestart(c)
 		if !resp.OK {
 			return fmt.Errorf("%s", resp.Error)
 		}
@@ -52,3 +69,47 @@ var stopCmd = &cobra.Command{
 		return nil
 	},
 }
+
+// protectedProjects returns the projects a stop would hit that set protect in
+// their .hun.yml: project itself, or every running project when it is empty.
+func protectedProjects(project string) []string {
+	st, err := loadState()
+	if err != nil {
+		return nil
+	}
+	names := []string{project}
+	if project == "" {
+		names = names[:0]
+		for name, ps := range st.Projects {
+			if ps.Status == "running" {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+	}
+	var protected []string
+	for _, name := range names {
+		path, ok := st.Registry[name]
+		if !ok {
+			continue
+		}
+		if proj, err := config.LoadProject(path); err == nil && proj.Protects("") {
+			protected = append(protected, name)
+		}
+	}
+	return protected
+}
+
+// confirmProtectedStop asks before stopping protected projects. Without a
+// terminal to ask on it refuses, so scripts must pass --force.
+func confirmProtectedStop(project string) (bool, error) {
+	protected := protectedProjects(project)
+	if len(protected) == 0 {
+		return true, nil
+	}
+	list := strings.Join(protected, ", ")
+	if !isInteractiveTerminal() {
+		return false, fmt.Errorf("%s is protected; pass --force to stop it", list)
+	}
+	return confirmPromptWithDefault(fmt.Sprintf("%s is protected. Stop anyway? [y/N] ", list), false)
+}
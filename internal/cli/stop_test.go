@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sourabhrathourr/hun/internal/client"
+	"github.com/sourabhrathourr/hun/internal/daemon"
+	"github.com/sourabhrathourr/hun/internal/state"
+	"github.com/spf13/cobra"
+)
+
+func TestProtectedProjectsOnlyCountsRunningProjectsForAll(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("HUN_HOME", "")
+
+	st, err := state.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, status := range map[string]string{"api": "running", "infra": "stopped"} {
+		dir := filepath.Join(home, name)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		yml := "name: " + name + "\nprotect: true\nservices:\n  app:\n    cmd: echo ok\n"
+		if err := os.WriteFile(filepath.Join(dir, ".hun.yml"), []byte(yml), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		st.Register(name, dir)
+		st.Projects[name] = state.ProjectState{Status: status, Path: dir}
+	}
+	if err := st.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.Join(protectedProjects(""), ","); got != "api" {
+		t.Fatalf("protectedProjects(all) = %q, want only the running api", got)
+	}
+	if got := strings.Join(protectedProjects("infra"), ","); got != "infra" {
+		t.Fatalf("protectedProjects(infra) = %q, want infra when named", got)
+	}
+}
+
+func TestDaemonStopRefusesProtectedProjectsWithoutForce(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("HUN_HOME", "")
+
+	st, err := state.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(home, "api")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	yml := "name: api\nprotect: true\nservices:\n  app:\n    cmd: echo ok\n"
+	if err := os.WriteFile(filepath.Join(dir, ".hun.yml"), []byte(yml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	st.Register("api", dir)
+	st.Projects["api"] = state.ProjectState{Status: "running", Path: dir}
+	if err := st.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := client.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := confirmDaemonStop(daemonStopCmd, c); !ok || err != nil {
+		t.Fatalf("no daemon running: confirmDaemonStop = %v, %v; want a go-ahead", ok, err)
+	}
+
+	sockPath, err := daemon.SocketPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			if bufio.NewScanner(conn).Scan() {
+				raw, _ := json.Marshal(map[string]any{"status": "pong", "protocol": daemon.CurrentProtocolVersion, "uid": os.Getuid()})
+				out, _ := json.Marshal(daemon.Response{OK: true, Data: raw})
+				conn.Write(append(out, '\n'))
+			}
+			conn.Close()
+		}
+	}()
+
+	for _, cmd := range []*cobra.Command{daemonStopCmd, daemonRestartCmd} {
+		if _, err := confirmDaemonStop(cmd, c); err == nil || !strings.Contains(err.Error(), "--force") {
+			t.Fatalf("%s without a terminal: err = %v, want a --force hint", cmd.Name(), err)
+		}
+		if err := cmd.Flags().Set("force", "true"); err != nil {
+			t.Fatal(err)
+		}
+		ok, err := confirmDaemonStop(cmd, c)
+		cmd.Flags().Set("force", "false")
+		if !ok || err != nil {
+			t.Fatalf("%s --force: confirmDaemonStop = %v, %v; want a go-ahead", cmd.Name(), ok, err)
+		}
+	}
+}
@@ -5,12 +5,13 @@ import (
 
 	"github.com/sourabhrathourr/hun/internal/client"
 	"github.com/sourabhrathourr/hun/internal/daemon"
-	"github.com/sourabhrathourr/hun/internal/state"
 	"github.com/spf13/cobra"
 )
 
 func init() {
 	switchCmd.Flags().StringP("message", "m", "", "Note to save for current project before switching")
+	switchCmd.Flags().StringSlice("only", nil, "Start only these services (names or tag:<name>) and their dependencies")
+	switchCmd.Flags().Bool("safe", false, "Debug start: one service at a time, no auto-restart, stop at the first crash")
 	rootCmd.AddCommand(switchCmd)
 }
 
@@ -21,10 +22,12 @@ var switchCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		project := args[0]
 		note, _ := cmd.Flags().GetString("message")
+		only, _ := cmd.Flags().GetStringSlice("only")
+		safe, _ := cmd.Flags().GetBool("safe")
 
 		// Save note for current project if provided
 		if note != "" {
-			st, err := state.Load()
+			st, err := loadState()
 			if err == nil {
 				target := st.ActiveProject
 				if target == "" {
@@ -53,16 +56,19 @@ var switchCmd = &cobra.Command{
 			Action:  "start",
 			Project: project,
 			Mode:    "exclusive",
+			Only:    only,
+			Safe:    safe,
 		})
 		if err != nil {
 			return err
 		}
+		reportDaemonRestart(c)
 		if !resp.OK {
 			return fmt.Errorf("%s", resp.Error)
 		}
 
 		// Show previous session info
-		st, _ := state.Load()
+		st, _ := loadState()
 		if st != nil {
 			if ps, ok := st.Projects[project]; ok {
 				if ps.GitBranch != "" || ps.LastNote != "" {
@@ -3,8 +3,6 @@ package cli
 import (
 	"fmt"
 
-	"github.com/sourabhrathourr/hun/internal/client"
-	"github.com/sourabhrathourr/hun/internal/daemon"
 	"github.com/spf13/cobra"
 )
 
@@ -22,16 +20,13 @@ var tailCmd = &cobra.Command{
 			return fmt.Errorf("specify service: hun tail project:service")
 		}
 
-		c, err := client.New()
+		c, err := readOnlyClient()
 		if err != nil {
 			return err
 		}
 
 		fmt.Printf("Streaming logs for %s:%s (Ctrl+C to stop)\n\n", project, service)
 
-		return c.Subscribe(project, service, func(line daemon.LogLine) {
-			ts := line.Timestamp.Format("15:04:05")
-			fmt.Printf("[%s] %s\n", ts, line.Text)
-		})
+		return c.Subscribe(project, service, printLogLine)
 	},
 }
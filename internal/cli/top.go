@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/sourabhrathourr/hun/internal/client"
+	"github.com/sourabhrathourr/hun/internal/daemon"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	topCmd.Flags().Duration("interval", 2*time.Second, "How often to refresh")
+	topCmd.Flags().Bool("once", false, "Print one snapshot and exit")
+	rootCmd.AddCommand(topCmd)
+}
+
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Live CPU, memory, and uptime of running services, busiest first",
+	Long: "Show every running service with its CPU%, memory, uptime, restarts, and port, sorted by\n" +
+		"CPU and refreshed on an interval. Usage covers the service's whole process group, so a\n" +
+		"shell and whatever it spawned count together. Output that isn't a terminal gets one snapshot.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		interval, _ := cmd.Flags().GetDuration("interval")
+		once, _ := cmd.Flags().GetBool("once")
+		if interval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+
+		c, err := readOnlyClient()
+		if err != nil {
+			return err
+		}
+		if once || !isInteractiveTerminal() {
+			rows, err := fetchTopRows(c)
+			if err != nil {
+				return err
+			}
+			printTop(os.Stdout, rows, time.Now())
+			return nil
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			rows, err := fetchTopRows(c)
+			if err != nil {
+				return err
+			}
+			// Home and clear, then redraw, so the table updates in place.
+			fmt.Print("\033[H\033[2J")
+			fmt.Printf("hun top · every %s · Ctrl+C to quit\n\n", interval)
+			printTop(os.Stdout, rows, time.Now())
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+// topRow is one running service in `hun top`.
+type topRow struct {
+	project string
+	service string
+	info    daemon.ServiceInfo
+}
+
+func fetchTopRows(c *client.Client) ([]topRow, error) {
+	status, err := fetchStatus(c)
+	if err != nil {
+		return nil, err
+	}
+	return topRows(status), nil
+}
+
+// topRows lists the running services in status, busiest CPU first, then by
+// memory, then by name so idle services keep a stable order.
+func topRows(status map[string]map[string]daemon.ServiceInfo) []topRow {
+	var rows []topRow
+	for project, services := range status {
+		for service, info := range services {
+			if info.Running {
+				rows = append(rows, topRow{project: project, service: service, info: info})
+			}
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.info.CPU != b.info.CPU {
+			return a.info.CPU > b.info.CPU
+		}
+		if a.info.MemBytes != b.info.MemBytes {
+			return a.info.MemBytes > b.info.MemBytes
+		}
+		if a.project != b.project {
+			return a.project < b.project
+		}
+		return a.service < b.service
+	})
+	return rows
+}
+
+func printTop(w io.Writer, rows []topRow, now time.Time) {
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "No running services.")
+		return
+	}
+	width := len("SERVICE")
+	for _, row := range rows {
+		width = max(width, len(row.project)+1+len(row.service))
+	}
+	fmt.Fprintf(w, "%-*s  %6s  %7s  %7s  %8s  %s\n", width, "SERVICE", "CPU%", "MEM", "UPTIME", "RESTARTS", "PORT")
+	for _, row := range rows {
+		port := "-"
+		if row.info.Port > 0 {
+			port = fmt.Sprintf(":%d", row.info.Port)
+		}
+		name := row.project + ":" + row.service
+		if row.info.Paused {
+			port += " (paused)"
+		}
+		fmt.Fprintf(w, "%-*s  %6.1f  %7s  %7s  %8d  %s\n",
+			width, name, row.info.CPU, formatMemory(row.info.MemBytes),
+			formatUptime(row.info.StartedAt, now), row.info.Restarts, port)
+	}
+}
+
+// formatMemory renders bytes with a binary unit suffix, e.g. 143.2M.
+func formatMemory(bytes int64) string {
+	const k = 1024
+	switch {
+	case bytes <= 0:
+		return "-"
+	case bytes < k:
+		return fmt.Sprintf("%dB", bytes)
+	case bytes < k*k:
+		return fmt.Sprintf("%.1fK", float64(bytes)/k)
+	case bytes < k*k*k:
+		return fmt.Sprintf("%.1fM", float64(bytes)/(k*k))
+	default:
+		return fmt.Sprintf("%.1fG", float64(bytes)/(k*k*k))
+	}
+}
+
+// formatUptime renders how long ago started was in its two largest units,
+// e.g. 45s, 12m03s, 1h02m, 3d04h.
+func formatUptime(started, now time.Time) string {
+	if started.IsZero() {
+		return "-"
+	}
+	secs := int64(max(now.Sub(started), 0) / time.Second)
+	switch {
+	case secs < 60:
+		return fmt.Sprintf("%ds", secs)
+	case secs < 3600:
+		return fmt.Sprintf("%dm%02ds", secs/60, secs%60)
+	case secs < 86400:
+		return fmt.Sprintf("%dh%02dm", secs/3600, secs%3600/60)
+	default:
+		return fmt.Sprintf("%dd%02dh", secs/86400, secs%86400/3600)
+	}
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourabhrathourr/hun/internal/daemon"
+)
+
+func TestTopListsRunningServicesBusiestFirst(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	status := map[string]map[string]daemon.ServiceInfo{
+		"shop": {
+			"api":    {Running: true, Port: 3000, CPU: 2.5, MemBytes: 150 << 20, StartedAt: now.Add(-62 * time.Minute), Restarts: 1},
+			"web":    {Running: true, Port: 5173, CPU: 87.4, MemBytes: 512 << 20, StartedAt: now.Add(-45 * time.Second)},
+			"worker": {Status: "crashed"},
+		},
+		"blog": {
+			"db": {Running: true, MemBytes: 40 << 10, StartedAt: now.Add(-50 * time.Hour)},
+		},
+	}
+
+	var b strings.Builder
+	printTop(&b, topRows(status), now)
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected header and 3 running services, got:\n%s", b.String())
+	}
+	for i, want := range [][]string{
+		{"shop:web", "87.4", "512.0M", "45s", ":5173"},
+		{"shop:api", "2.5", "150.0M", "1h02m", "1", ":3000"},
+		{"blog:db", "0.0", "40.0K", "2d02h", "-"},
+	} {
+		fields := strings.Fields(lines[i+1])
+		got := strings.Join(fields, " ")
+		for _, w := range want {
+			if !strings.Contains(" "+got+" ", " "+w+" ") {
+				t.Fatalf("row %d = %q, missing %q", i, got, w)
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -19,7 +20,42 @@ func launchTUI(multi bool) error {
 	}
 
 	m := tui.New(multi)
+	if dir := welcomeOnboardCandidate(); dir != "" {
+		m.OfferOnboarding(dir)
+	}
 	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
-	_, err = p.Run()
-	return err
+	final, err := p.Run()
+	if err != nil {
+		return err
+	}
+
+	fm, ok := final.(tui.Model)
+	if !ok || fm.OnboardRequested() == "" {
+		return nil
+	}
+	project, err := onboardProjectDir(fm.OnboardRequested())
+	if err != nil {
+		if errors.Is(err, errOnboardingCanceled) {
+			return launchTUI(multi)
+		}
+		return err
+	}
+	if err := startProjectInFocus(project); err != nil {
+		return err
+	}
+	return launchTUI(multi)
+}
+
+// welcomeOnboardCandidate returns the cwd when nothing is registered yet and
+// it looks like a project, so the welcome screen can offer to onboard it.
+func welcomeOnboardCandidate() string {
+	st, err := loadState()
+	if err != nil || len(st.Registry) > 0 {
+		return ""
+	}
+	cwd, err := os.Getwd()
+	if err != nil || !looksLikeProjectDir(cwd) {
+		return ""
+	}
+	return cwd
 }
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/sourabhrathourr/hun/internal/config"
+	"github.com/sourabhrathourr/hun/internal/daemon"
 	"github.com/spf13/cobra"
 )
 
@@ -38,6 +40,24 @@ var validateCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		names := make([]string, 0, len(project.Services))
+		for name := range project.Services {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			svc := *project.Services[name]
+			cwd, needsPort := daemon.ExpandHunNames(svc.Cwd, project.Name, name)
+			if needsPort {
+				// Depends on the port selected at start; checked then.
+				continue
+			}
+			svc.Cwd = cwd
+			dir := svc.WorkDir(abs)
+			if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+				return fmt.Errorf("service %q: cwd %s is not a directory", name, dir)
+			}
+		}
 		fmt.Printf("%s .hun.yml valid (project: %s, services: %d)\n", checkmark(), project.Name, len(project.Services))
 		return nil
 	},
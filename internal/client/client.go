@@ -1,14 +1,15 @@
 package client
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"os/exec"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
@@ -17,14 +18,33 @@ import (
 	"github.com/sourabhrathourr/hun/internal/daemon"
 )
 
-// Client communicates with the hun daemon over a Unix socket.
+// Client communicates with the hun daemon over a Unix socket, or through
+// another Transport when built with NewWithTransport.
 type Client struct {
-	sockPath string
+	sockPath  string
+	transport Transport
+
+	guardRestart     bool     // refuse to restart an outdated daemon that has projects running
+	restartedRunning []string // projects that were running when EnsureDaemon restarted an outdated daemon
+	restarted        bool
+}
+
+// StaleDaemonError is returned when the running daemon is from an older hun,
+// has projects running, and the client was told not to restart it.
+type StaleDaemonError struct {
+	Protocol int      // protocol the running daemon speaks
+	Running  []string // projects a restart would stop
+}
+
+func (e *StaleDaemonError) Error() string {
+	return fmt.Sprintf("the running daemon is from an older hun (protocol %d, this build needs %d) and restarting it would stop %s; "+
+		"run a lifecycle command such as `hun run` or `hun stop` to restart it", e.Protocol, daemon.CurrentProtocolVersion, strings.Join(e.Running, ", "))
 }
 
 type daemonProbe struct {
 	ok       bool
 	protocol int
+	uid      int // -1 when the daemon predates ownership reporting
 }
 
 // New creates a new daemon client.
@@ -36,16 +56,51 @@ func New() (*Client, error) {
 	return &Client{sockPath: sockPath}, nil
 }
 
-// EnsureDaemon starts the daemon if not running.
+// GuardRestart makes EnsureDaemon return a *StaleDaemonError instead of
+// restarting an outdated daemon that has projects running. Read-only commands
+// use it so a routine status check never tears down a running session.
+func (c *Client) GuardRestart() {
+	c.guardRestart = true
+}
+
+// RestartedDaemon reports whether EnsureDaemon restarted an outdated daemon,
+// and which projects were running in it at the time.
+func (c *Client) RestartedDaemon() (running []string, restarted bool) {
+	return c.restartedRunning, c.restarted
+}
+
+// EnsureDaemon starts the daemon if not running. An outdated daemon is
+// restarted, unless GuardRestart is set and it has projects running.
 func (c *Client) EnsureDaemon() error {
+	if c.transport != nil {
+		return nil
+	}
 	probe := c.pingProbe()
+	if probe.ok && probe.uid >= 0 && probe.uid != os.Getuid() {
+		// Another user's daemon answered on the shared socket; never drive it.
+		sockPath, err := daemon.UserSocketPath()
+		if err != nil {
+			return err
+		}
+		if sockPath == c.sockPath {
+			return fmt.Errorf("daemon at %s belongs to uid %d, not the current user", c.sockPath, probe.uid)
+		}
+		c.sockPath = sockPath
+		probe = c.pingProbe()
+	}
 	if probe.ok && probe.protocol == daemon.CurrentProtocolVersion {
 		return nil
 	}
 	if probe.ok && probe.protocol != daemon.CurrentProtocolVersion {
+		running := c.runningProjects()
+		if c.guardRestart && len(running) > 0 {
+			return &StaleDaemonError{Protocol: probe.protocol, Running: running}
+		}
 		if err := c.restartDaemon(); err != nil {
 			return fmt.Errorf("restarting stale daemon: %w", err)
 		}
+		c.restarted = true
+		c.restartedRunning = running
 		return nil
 	}
 
@@ -58,6 +113,54 @@ func (c *Client) EnsureDaemon() error {
 	return nil
 }
 
+// DaemonInfo is what a running daemon reports about itself when pinged.
+// Daemons predating a field leave it zero.
+type DaemonInfo struct {
+	Protocol  int
+	Version   string
+	Commit    string
+	PID       int
+	StartedAt time.Time
+}
+
+// Daemon pings the daemon without starting one, reporting false when none
+// answers.
+func (c *Client) Daemon() (DaemonInfo, bool) {
+	resp, ok := c.pingResponse()
+	if !ok {
+		return DaemonInfo{}, false
+	}
+	return parsePingInfo(resp.Data), true
+}
+
+// StopDaemon stops the running daemon, which stops every service it runs,
+// and returns the projects that were running. It reports false when no
+// daemon was running.
+func (c *Client) StopDaemon() (running []string, stopped bool, err error) {
+	if !c.ping() {
+		return nil, false, nil
+	}
+	running = c.runningProjects()
+	if err := c.stopDaemonProcess(); err != nil {
+		return running, false, err
+	}
+	return running, true, nil
+}
+
+// RestartDaemon replaces the running daemon, if any, with one from this
+// build and waits for it to answer. It returns the projects the old daemon
+// was running.
+func (c *Client) RestartDaemon() (running []string, err error) {
+	if !c.ping() {
+		if err := c.startDaemonProcess(); err != nil {
+			return nil, fmt.Errorf("starting daemon: %w", err)
+		}
+		return nil, c.waitForDaemonProtocol(daemon.CurrentProtocolVersion, 5*time.Second)
+	}
+	running = c.runningProjects()
+	return running, c.restartDaemon()
+}
+
 func (c *Client) startDaemonProcess() error {
 	exe, err := os.Executable()
 	if err != nil {
@@ -106,7 +209,11 @@ func (c *Client) stopDaemonProcess() error {
 	if err != nil {
 		return fmt.Errorf("reading daemon pid: %w", err)
 	}
-	pid, err := strconv.Atoi(strings.TrimSpace(string(raw)))
+	fields := strings.Fields(string(raw))
+	if len(fields) == 0 {
+		return fmt.Errorf("invalid daemon pid file")
+	}
+	pid, err := strconv.Atoi(fields[0])
 	if err != nil || pid <= 0 {
 		return fmt.Errorf("invalid daemon pid file")
 	}
@@ -139,11 +246,21 @@ func (c *Client) waitForDaemonDown(timeout time.Duration) bool {
 	return false
 }
 
+// whenActions evaluate services' when: conditions, which must see the
+// caller's environment rather than the long-lived daemon's.
+var whenActions = map[string]bool{"start": true, "start_service": true, "restart": true, "ready": true}
+
 // Send sends a request to the daemon and returns the response.
 func (c *Client) Send(req daemon.Request) (*daemon.Response, error) {
 	if os.Getenv("HUN_HOOK") == "1" {
 		req.Origin = "hook"
 	}
+	if req.Env == nil && whenActions[req.Action] {
+		req.Env = os.Environ()
+	}
+	if c.transport != nil {
+		return c.transport.Send(req)
+	}
 	if err := c.EnsureDaemon(); err != nil {
 		return nil, err
 	}
@@ -160,15 +277,14 @@ func (c *Client) Send(req daemon.Request) (*daemon.Response, error) {
 	}
 	conn.Write(append(data, '\n'))
 
-	scanner := bufio.NewScanner(conn)
-	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
-	if !scanner.Scan() {
-		return nil, fmt.Errorf("no response from daemon")
-	}
-
+	// Responses are decoded as a stream rather than scanned as one line, so
+	// a status or state export of any size comes through intact.
 	var resp daemon.Response
-	if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("no response from daemon")
+		}
+		return nil, fmt.Errorf("reading response: %w", err)
 	}
 
 	return &resp, nil
@@ -181,6 +297,9 @@ func (c *Client) Subscribe(project, service string, callback func(daemon.LogLine
 
 // SubscribeWithContext connects to the daemon and streams log lines until context cancellation.
 func (c *Client) SubscribeWithContext(ctx context.Context, project, service string, callback func(daemon.LogLine)) error {
+	if c.transport != nil {
+		return c.transport.SubscribeWithContext(ctx, project, service, callback)
+	}
 	if err := c.EnsureDaemon(); err != nil {
 		return err
 	}
@@ -203,36 +322,71 @@ func (c *Client) SubscribeWithContext(ctx context.Context, project, service stri
 	data, _ := json.Marshal(req)
 	conn.Write(append(data, '\n'))
 
-	scanner := bufio.NewScanner(conn)
-	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	dec := json.NewDecoder(conn)
 
-	// First line is the OK response
-	if !scanner.Scan() {
+	// First value is the OK response
+	var ack daemon.Response
+	if err := dec.Decode(&ack); err != nil {
 		if errors.Is(ctx.Err(), context.Canceled) {
 			return nil
 		}
 		return fmt.Errorf("no response from daemon")
 	}
-	var ack daemon.Response
-	if err := json.Unmarshal(scanner.Bytes(), &ack); err == nil && !ack.OK {
+	if !ack.OK {
 		return fmt.Errorf("subscribe rejected: %s", ack.Error)
 	}
 
 	// Stream log lines
-	for scanner.Scan() {
+	for {
+		var raw json.RawMessage
+		if err = dec.Decode(&raw); err != nil {
+			break
+		}
 		var line daemon.LogLine
-		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+		if json.Unmarshal(raw, &line) != nil {
 			continue
 		}
 		callback(line)
 	}
-	if errors.Is(ctx.Err(), context.Canceled) {
+	if errors.Is(ctx.Err(), context.Canceled) || errors.Is(err, io.EOF) {
 		return nil
 	}
-	if err := scanner.Err(); err != nil {
-		return err
+	return err
+}
+
+// runningProjects asks the daemon, whatever its protocol, which projects have
+// a running service. A daemon that can't answer is treated as running nothing.
+func (c *Client) runningProjects() []string {
+	conn, err := net.DialTimeout("unix", c.sockPath, 250*time.Millisecond)
+	if err != nil {
+		return nil
 	}
-	return nil
+	defer conn.Close()
+
+	data, _ := json.Marshal(daemon.Request{Action: "status"})
+	conn.Write(append(data, '\n'))
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	var resp daemon.Response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil || !resp.OK {
+		return nil
+	}
+	var status map[string]map[string]struct {
+		Running bool `json:"running"`
+	}
+	if err := json.Unmarshal(resp.Data, &status); err != nil {
+		return nil
+	}
+	var running []string
+	for project, services := range status {
+		for _, svc := range services {
+			if svc.Running {
+				running = append(running, project)
+				break
+			}
+		}
+	}
+	sort.Strings(running)
+	return running
 }
 
 func (c *Client) ping() bool {
@@ -240,10 +394,23 @@ func (c *Client) ping() bool {
 }
 
 func (c *Client) pingProbe() daemonProbe {
-	probe := daemonProbe{ok: false, protocol: 0}
+	probe := daemonProbe{ok: false, protocol: 0, uid: -1}
+	resp, ok := c.pingResponse()
+	if !ok {
+		return probe
+	}
+	probe.ok = true
+	probe.protocol = parsePingProtocol(resp.Data)
+	probe.uid = parsePingUID(resp.Data)
+	return probe
+}
+
+// pingResponse sends a ping straight over the socket, so it works against
+// daemons of any protocol and never starts one.
+func (c *Client) pingResponse() (daemon.Response, bool) {
 	conn, err := net.DialTimeout("unix", c.sockPath, 250*time.Millisecond)
 	if err != nil {
-		return probe
+		return daemon.Response{}, false
 	}
 	defer conn.Close()
 
@@ -252,21 +419,38 @@ func (c *Client) pingProbe() daemonProbe {
 	conn.Write(append(data, '\n'))
 
 	conn.SetReadDeadline(time.Now().Add(250 * time.Millisecond))
-	scanner := bufio.NewScanner(conn)
-	if !scanner.Scan() {
-		return probe
+	var resp daemon.Response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil || !resp.OK {
+		return daemon.Response{}, false
 	}
+	return resp, true
+}
 
-	var resp daemon.Response
-	if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
-		return probe
+func parsePingInfo(data json.RawMessage) DaemonInfo {
+	var payload struct {
+		Version   string    `json:"version"`
+		Commit    string    `json:"commit"`
+		PID       int       `json:"pid"`
+		StartedAt time.Time `json:"started_at"`
+	}
+	_ = json.Unmarshal(data, &payload) // legacy daemons reply with a bare "pong"
+	return DaemonInfo{
+		Protocol:  parsePingProtocol(data),
+		Version:   payload.Version,
+		Commit:    payload.Commit,
+		PID:       payload.PID,
+		StartedAt: payload.StartedAt,
 	}
-	if !resp.OK {
-		return probe
+}
+
+func parsePingUID(data json.RawMessage) int {
+	var payload struct {
+		UID *int `json:"uid"`
 	}
-	probe.ok = true
-	probe.protocol = parsePingProtocol(resp.Data)
-	return probe
+	if err := json.Unmarshal(data, &payload); err != nil || payload.UID == nil {
+		return -1
+	}
+	return *payload.UID
 }
 
 func parsePingProtocol(data json.RawMessage) int {
@@ -1,8 +1,15 @@
 package client
 
 import (
+	"bufio"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/sourabhrathourr/hun/internal/daemon"
 )
@@ -49,3 +56,135 @@ func TestParsePingProtocol(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePingUID(t *testing.T) {
+	if got := parsePingUID(json.RawMessage(`{"status":"pong","protocol":14,"uid":501}`)); got != 501 {
+		t.Fatalf("uid = %d, want 501", got)
+	}
+	if got := parsePingUID(json.RawMessage(`{"status":"pong","protocol":13,"uid":0}`)); got != 0 {
+		t.Fatalf("uid = %d, want 0 for root daemon", got)
+	}
+	if got := parsePingUID(json.RawMessage(`{"status":"pong","protocol":13}`)); got != -1 {
+		t.Fatalf("uid = %d, want -1 for daemon without ownership info", got)
+	}
+}
+
+func TestParsePingInfo(t *testing.T) {
+	info := parsePingInfo(json.RawMessage(`{"status":"pong","protocol":27,"version":"v0.4.0","commit":"abc1234","pid":4242,"started_at":"2026-07-11T12:00:00Z"}`))
+	want := DaemonInfo{Protocol: 27, Version: "v0.4.0", Commit: "abc1234", PID: 4242, StartedAt: time.Date(2026, time.July, 11, 12, 0, 0, 0, time.UTC)}
+	if !info.StartedAt.Equal(want.StartedAt) || info.Protocol != want.Protocol || info.Version != want.Version || info.Commit != want.Commit || info.PID != want.PID {
+		t.Fatalf("info = %+v, want %+v", info, want)
+	}
+	if legacy := parsePingInfo(json.RawMessage(`"pong"`)); legacy.Protocol != daemon.LegacyProtocolVersion || legacy.PID != 0 {
+		t.Fatalf("legacy info = %+v, want only the legacy protocol", legacy)
+	}
+}
+
+func TestDaemonReportsNotRunningWithoutStartingOne(t *testing.T) {
+	c := &Client{sockPath: filepath.Join(t.TempDir(), "missing.sock")}
+	if info, ok := c.Daemon(); ok {
+		t.Fatalf("Daemon() = %+v, want no daemon", info)
+	}
+	if running, stopped, err := c.StopDaemon(); stopped || err != nil || running != nil {
+		t.Fatalf("StopDaemon() = %v, %v, %v; want a no-op", running, stopped, err)
+	}
+}
+
+func TestGuardRestartRefusesToRestartOutdatedDaemonWithRunningProjects(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "d.sock")
+	ln, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			scanner := bufio.NewScanner(conn)
+			if scanner.Scan() {
+				var req daemon.Request
+				_ = json.Unmarshal(scanner.Bytes(), &req)
+				var data any = map[string]any{"status": "pong", "protocol": daemon.CurrentProtocolVersion - 1, "uid": os.Getuid()}
+				if req.Action == "status" {
+					data = map[string]map[string]daemon.ServiceInfo{
+						"shop": {"api": {Running: true}},
+						"idle": {"web": {Running: false}},
+					}
+				}
+				raw, _ := json.Marshal(data)
+				out, _ := json.Marshal(daemon.Response{OK: true, Data: raw})
+				conn.Write(append(out, '\n'))
+			}
+			conn.Close()
+		}
+	}()
+
+	c := &Client{sockPath: sockPath}
+	c.GuardRestart()
+	err = c.EnsureDaemon()
+	var stale *StaleDaemonError
+	if !errors.As(err, &stale) {
+		t.Fatalf("expected a StaleDaemonError, got %v", err)
+	}
+	if len(stale.Running) != 1 || stale.Running[0] != "shop" {
+		t.Fatalf("running = %v, want [shop]", stale.Running)
+	}
+	if _, restarted := c.RestartedDaemon(); restarted {
+		t.Fatal("guarded client must not restart the daemon")
+	}
+}
+
+func TestSendReadsResponsesLargerThanAMegabyte(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "d.sock")
+	ln, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	status := map[string]map[string]daemon.ServiceInfo{}
+	for i := 0; i < 400; i++ {
+		services := map[string]daemon.ServiceInfo{}
+		for j := 0; j < 40; j++ {
+			services[fmt.Sprintf("service-%03d", j)] = daemon.ServiceInfo{Running: true, PID: 10000 + j, Port: 3000 + j, Status: "running"}
+		}
+		status[fmt.Sprintf("project-%03d", i)] = services
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			var req daemon.Request
+			_ = json.NewDecoder(conn).Decode(&req)
+			var data any = map[string]any{"status": "pong", "protocol": daemon.CurrentProtocolVersion, "uid": os.Getuid()}
+			if req.Action == "status" {
+				data = status
+			}
+			raw, _ := json.Marshal(data)
+			out, _ := json.Marshal(daemon.Response{OK: true, Data: raw})
+			conn.Write(append(out, '\n'))
+			conn.Close()
+		}
+	}()
+
+	c := &Client{sockPath: sockPath}
+	resp, err := c.Send(daemon.Request{Action: "status"})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if len(resp.Data) <= 1024*1024 {
+		t.Fatalf("test payload should exceed 1MB, got %d bytes", len(resp.Data))
+	}
+	var got map[string]map[string]daemon.ServiceInfo
+	if err := json.Unmarshal(resp.Data, &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != 400 || got["project-399"]["service-039"].Port != 3039 {
+		t.Fatalf("large status came back incomplete: %d projects", len(got))
+	}
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sourabhrathourr/hun/internal/daemon"
+)
+
+// Transport carries requests and log subscriptions to a daemon. The default
+// Client talks to the daemon process over its Unix socket; NewWithTransport
+// swaps that for another implementation such as InProcess.
+type Transport interface {
+	Send(req daemon.Request) (*daemon.Response, error)
+	SubscribeWithContext(ctx context.Context, project, service string, callback func(daemon.LogLine)) error
+}
+
+// NewWithTransport returns a client that sends everything through t and never
+// starts a daemon process.
+func NewWithTransport(t Transport) *Client {
+	return &Client{transport: t}
+}
+
+// InProcess returns a Transport that serves requests with d directly. Requests,
+// responses, and log lines still go through JSON, so callers see exactly what
+// the socket would have carried.
+func InProcess(d *daemon.Daemon) Transport {
+	return inProcessTransport{d: d}
+}
+
+type inProcessTransport struct {
+	d *daemon.Daemon
+}
+
+func (t inProcessTransport) Send(req daemon.Request) (*daemon.Response, error) {
+	var wireReq daemon.Request
+	if err := roundTrip(req, &wireReq); err != nil {
+		return nil, err
+	}
+	if wireReq.Action == "subscribe" {
+		return nil, fmt.Errorf("subscribe must go through SubscribeWithContext")
+	}
+	var resp daemon.Response
+	if err := roundTrip(t.d.HandleRequest(wireReq), &resp); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	return &resp, nil
+}
+
+func (t inProcessTransport) SubscribeWithContext(ctx context.Context, project, service string, callback func(daemon.LogLine)) error {
+	lines, _, cancel := t.d.Subscribe(project, service)
+	defer cancel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case line, ok := <-lines:
+			if !ok {
+				return nil
+			}
+			var wire daemon.LogLine
+			if err := roundTrip(line, &wire); err != nil {
+				continue
+			}
+			callback(wire)
+		}
+	}
+}
+
+func roundTrip(in, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
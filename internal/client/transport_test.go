@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sourabhrathourr/hun/internal/config"
+	"github.com/sourabhrathourr/hun/internal/daemon"
+)
+
+func TestInProcessTransportServesRequestsAndLogs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m, err := daemon.NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+	c := NewWithTransport(InProcess(daemon.NewInProcess(m)))
+
+	resp, err := c.Send(daemon.Request{Action: "ping"})
+	if err != nil || !resp.OK {
+		t.Fatalf("ping = %+v, %v", resp, err)
+	}
+	if got := parsePingProtocol(resp.Data); got != daemon.CurrentProtocolVersion {
+		t.Fatalf("ping protocol = %d, want %d", got, daemon.CurrentProtocolVersion)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	got := make(chan daemon.LogLine, 8)
+	done := make(chan error, 1)
+	go func() {
+		done <- c.SubscribeWithContext(ctx, "inproc", "", func(line daemon.LogLine) { got <- line })
+	}()
+	time.Sleep(50 * time.Millisecond) // let the subscription register
+
+	proj := &config.Project{Name: "inproc", Services: map[string]*config.Service{
+		"web": {Cmd: "echo hello-from-web; sleep 5"},
+	}}
+	if err := m.StartProject("inproc", proj, t.TempDir(), false); err != nil {
+		t.Fatalf("start project: %v", err)
+	}
+	select {
+	case line := <-got:
+		if line.Service != "web" || line.Text != "hello-from-web" || line.Seq == 0 {
+			t.Fatalf("streamed line = %+v", line)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("no log line streamed through the in-process transport")
+	}
+
+	resp, err = c.Send(daemon.Request{Action: "status"})
+	if err != nil || !resp.OK {
+		t.Fatalf("status = %+v, %v", resp, err)
+	}
+	var status map[string]map[string]daemon.ServiceInfo
+	if err := json.Unmarshal(resp.Data, &status); err != nil {
+		t.Fatalf("decode status: %v", err)
+	}
+	if !status["inproc"]["web"].Running {
+		t.Fatalf("status should report web running: %+v", status)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("subscription ended with %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("subscription did not stop on cancel")
+	}
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,34 +11,92 @@ import (
 
 // HunDir returns the configured Hun data directory, defaulting to ~/.hun, and creates it if needed.
 func HunDir() (string, error) {
+	dir, err := hunDirPath()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", hunDirError(dir, err)
+	}
+	return dir, nil
+}
+
+// CheckHunDirWritable resolves the Hun data directory and verifies that files
+// can be created in it and in its logs subdirectory. The returned error names
+// the path and suggests HUN_HOME as a way out.
+func CheckHunDirWritable() (string, error) {
+	dir, err := HunDir()
+	if err != nil {
+		return "", err
+	}
+	for _, target := range []string{dir, filepath.Join(dir, "logs")} {
+		if err := os.MkdirAll(target, 0755); err != nil {
+			return dir, hunDirError(target, err)
+		}
+		probe, err := os.CreateTemp(target, ".write-check-*")
+		if err != nil {
+			return dir, hunDirError(target, err)
+		}
+		name := probe.Name()
+		_ = probe.Close()
+		_ = os.Remove(name)
+	}
+	return dir, nil
+}
+
+func hunDirPath() (string, error) {
 	dir := strings.TrimSpace(os.Getenv("HUN_HOME"))
 	if dir == "" {
 		home, err := os.UserHomeDir()
 		if err != nil {
-			return "", err
+			return "", fmt.Errorf("resolving home directory: %w (set HUN_HOME to choose a data directory)", err)
 		}
 		dir = filepath.Join(home, ".hun")
 	}
-	dir = filepath.Clean(dir)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return "", err
+	return filepath.Clean(dir), nil
+}
+
+func hunDirError(path string, err error) error {
+	return fmt.Errorf("hun directory %s is not writable: %w (set HUN_HOME to a writable directory)", path, err)
+}
+
+// GlobalPath returns the global config file in use: $HUN_CONFIG when set,
+// otherwise ~/.hun/config.yml. The bool reports whether it was overridden.
+func GlobalPath() (string, bool, error) {
+	if path := strings.TrimSpace(os.Getenv("HUN_CONFIG")); path != "" {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return "", true, err
+		}
+		return abs, true, nil
 	}
-	return dir, nil
+	dir, err := HunDir()
+	if err != nil {
+		return "", false, err
+	}
+	return filepath.Join(dir, "config.yml"), false, nil
 }
 
-// LoadGlobal reads ~/.hun/config.yml, returning defaults if it doesn't exist.
+// LoadGlobal reads the global config (see GlobalPath), returning defaults if
+// ~/.hun/config.yml doesn't exist. A missing $HUN_CONFIG file is an error, so
+// a mistyped override isn't silently replaced by defaults.
 func LoadGlobal() (*Global, error) {
-	dir, err := HunDir()
+	path, overridden, err := GlobalPath()
 	if err != nil {
+		if overridden {
+			return nil, err
+		}
 		return defaultGlobal(), nil
 	}
 
-	path := filepath.Join(dir, "config.yml")
 	data, err := os.ReadFile(path)
 	if err != nil {
-		if os.IsNotExist(err) {
+		if os.IsNotExist(err) && !overridden {
 			return defaultGlobal(), nil
 		}
+		if overridden {
+			return nil, fmt.Errorf("HUN_CONFIG: %w", err)
+		}
 		return nil, err
 	}
 
@@ -74,6 +133,26 @@ func UnsupportedGlobalSettings(g *Global) []string {
 	if g.Hotkeys.Peek != "" || g.Hotkeys.Switch != "" {
 		unsupported = append(unsupported, "hotkeys")
 	}
+	switch g.UI {
+	case "", "tui", "cli":
+	default:
+		unsupported = append(unsupported, fmt.Sprintf("ui: %s", g.UI))
+	}
+	switch g.Theme {
+	case "", "dark", "light", "auto":
+	default:
+		unsupported = append(unsupported, fmt.Sprintf("theme: %s", g.Theme))
+	}
+	switch g.Picker.Favorites {
+	case "", "top", "grouped":
+	default:
+		unsupported = append(unsupported, fmt.Sprintf("picker.favorites: %s", g.Picker.Favorites))
+	}
+	switch g.Services.Enter {
+	case "", "logs", "start":
+	default:
+		unsupported = append(unsupported, fmt.Sprintf("services.enter: %s", g.Services.Enter))
+	}
 
 	return unsupported
 }
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -17,3 +19,54 @@ func TestHunDirHonorsExplicitHome(t *testing.T) {
 		t.Fatalf("HunDir = %q, want explicit HUN_HOME %q", dir, custom)
 	}
 }
+
+func TestCheckHunDirWritableReportsPathAndHint(t *testing.T) {
+	blocker := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(blocker, []byte("x"), 0o644); err != nil {
+		t.Fatalf("write blocker: %v", err)
+	}
+	custom := filepath.Join(blocker, "hun")
+	t.Setenv("HUN_HOME", custom)
+
+	_, err := CheckHunDirWritable()
+	if err == nil {
+		t.Fatal("expected error for unwritable HUN_HOME")
+	}
+	if !strings.Contains(err.Error(), custom) || !strings.Contains(err.Error(), "HUN_HOME") {
+		t.Fatalf("error = %q, want path and HUN_HOME hint", err)
+	}
+}
+
+func TestLoadGlobalHonorsHunConfigOverride(t *testing.T) {
+	t.Setenv("HUN_HOME", t.TempDir())
+	override := filepath.Join(t.TempDir(), "ci.yml")
+	if err := os.WriteFile(override, []byte("ui: cli\nports:\n  default_offset: 7\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HUN_CONFIG", override)
+
+	g, err := LoadGlobal()
+	if err != nil {
+		t.Fatalf("LoadGlobal: %v", err)
+	}
+	if g.UI != "cli" || g.Ports.DefaultOffset != 7 {
+		t.Fatalf("override not applied: %+v", g)
+	}
+
+	t.Setenv("HUN_CONFIG", filepath.Join(t.TempDir(), "missing.yml"))
+	if _, err := LoadGlobal(); err == nil || !strings.Contains(err.Error(), "HUN_CONFIG") {
+		t.Fatalf("a missing override should be an error naming HUN_CONFIG, got %v", err)
+	}
+}
+
+func TestUnsupportedGlobalSettingsFlagsUnknownTheme(t *testing.T) {
+	g := defaultGlobal()
+	g.Theme = "auto"
+	if got := UnsupportedGlobalSettings(g); len(got) != 0 {
+		t.Fatalf("auto theme flagged: %v", got)
+	}
+	g.Theme = "solarized"
+	if got := UnsupportedGlobalSettings(g); len(got) != 1 || got[0] != "theme: solarized" {
+		t.Fatalf("unsupported = %v", got)
+	}
+}
@@ -1,9 +1,12 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -35,14 +38,37 @@ func ProjectExists(dir string) bool {
 	return err == nil
 }
 
+// ErrProjectExists is returned by WriteProject when .hun.yml already exists and overwrite is false.
+var ErrProjectExists = errors.New(".hun.yml already exists")
+
 // WriteProject writes a Project config to .hun.yml in the given directory.
-func WriteProject(dir string, proj *Project) error {
+// Unless overwrite is set, an existing file is left untouched and ErrProjectExists
+// is returned so the caller can decide whether to back it up first.
+func WriteProject(dir string, proj *Project, overwrite bool) error {
 	path := filepath.Join(dir, ".hun.yml")
 	data, err := yaml.Marshal(proj)
 	if err != nil {
 		return fmt.Errorf("marshaling config: %w", err)
 	}
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if overwrite {
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("%w: %s", ErrProjectExists, path)
+		}
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
 		return fmt.Errorf("writing %s: %w", path, err)
 	}
 	return nil
@@ -74,6 +100,112 @@ func ProjectWithoutService(proj *Project, service string) (*Project, error) {
 	return updated, nil
 }
 
+// ProjectWithNewServices returns a validated copy of proj with every service
+// from detected that it does not already define, plus the added names in
+// sorted order. A detected service counts as already defined when a service
+// of the same name exists or one runs the same command in the same cwd.
+// Existing definitions are never modified.
+func ProjectWithNewServices(proj *Project, detected map[string]*Service) (*Project, []string, error) {
+	if proj == nil {
+		return nil, nil, fmt.Errorf("project config is required")
+	}
+	known := make(map[string]bool, len(proj.Services))
+	for _, svc := range proj.Services {
+		if svc != nil {
+			known[svc.Cwd+"\x00"+svc.Cmd] = true
+		}
+	}
+
+	updated := cloneProject(proj)
+	var added []string
+	for name, svc := range detected {
+		if svc == nil {
+			continue
+		}
+		if _, exists := proj.Services[name]; exists || known[svc.Cwd+"\x00"+svc.Cmd] {
+			continue
+		}
+		cp := *svc
+		updated.Services[name] = &cp
+		added = append(added, name)
+	}
+	sort.Strings(added)
+	for _, name := range added {
+		svc := updated.Services[name]
+		var deps []string
+		for _, dep := range svc.DependsOn {
+			if _, ok := updated.Services[dep]; ok {
+				deps = append(deps, dep)
+			}
+		}
+		svc.DependsOn = deps
+	}
+	if err := validateProject(updated); err != nil {
+		return nil, nil, err
+	}
+	return updated, added, nil
+}
+
+// SelectServices returns a validated copy of proj limited to the services
+// matched by selectors plus everything they depend on. A selector is either a
+// service name or "tag:<name>".
+func SelectServices(proj *Project, selectors []string) (*Project, error) {
+	if proj == nil {
+		return nil, fmt.Errorf("project config is required")
+	}
+	keep := make(map[string]bool)
+	var visit func(name string)
+	visit = func(name string) {
+		if keep[name] {
+			return
+		}
+		keep[name] = true
+		if svc := proj.Services[name]; svc != nil {
+			for _, dep := range svc.DependsOn {
+				visit(dep)
+			}
+		}
+	}
+
+	for _, raw := range selectors {
+		selector := strings.TrimSpace(raw)
+		if selector == "" {
+			continue
+		}
+		if tag, ok := strings.CutPrefix(selector, "tag:"); ok {
+			matched := false
+			for name, svc := range proj.Services {
+				if svc.HasTag(tag) {
+					visit(name)
+					matched = true
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("no services in %q are tagged %q", proj.Name, tag)
+			}
+			continue
+		}
+		if _, ok := proj.Services[selector]; !ok {
+			return nil, fmt.Errorf("service %q not found in %q", selector, proj.Name)
+		}
+		visit(selector)
+	}
+	if len(keep) == 0 {
+		return nil, fmt.Errorf("no services selected")
+	}
+
+	updated := cloneProject(proj)
+	for name := range updated.Services {
+		if !keep[name] {
+			delete(updated.Services, name)
+		}
+	}
+	if err := validateProject(updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
 func cloneProject(proj *Project) *Project {
 	updated := *proj
 	updated.Services = make(map[string]*Service, len(proj.Services))
@@ -91,6 +223,13 @@ func cloneProject(proj *Project) *Project {
 		if svc.DependsOn != nil {
 			clone.DependsOn = append([]string(nil), svc.DependsOn...)
 		}
+		if svc.Tags != nil {
+			clone.Tags = append([]string(nil), svc.Tags...)
+		}
+		if svc.RestartBackoff != nil {
+			backoff := *svc.RestartBackoff
+			clone.RestartBackoff = &backoff
+		}
 		updated.Services[name] = &clone
 	}
 	return &updated
@@ -126,12 +265,53 @@ func validateProject(proj *Project) error {
 	if len(proj.Services) == 0 {
 		return fmt.Errorf("at least one service is required")
 	}
+	if _, err := proj.Hooks.TimeoutDuration(); err != nil {
+		return err
+	}
 	for name, svc := range proj.Services {
+		if name == HookLogService {
+			return fmt.Errorf("service %q: the name is reserved for the project's hook log", name)
+		}
 		if svc.Cmd == "" {
 			return fmt.Errorf("service %q: cmd is required", name)
 		}
-		if svc.Restart != "" && svc.Restart != "on_failure" {
-			return fmt.Errorf("service %q: restart must be \"on_failure\" or empty", name)
+		switch svc.Restart {
+		case "", "on_failure", "always":
+		default:
+			return fmt.Errorf("service %q: restart must be \"on_failure\", \"always\", or empty", name)
+		}
+		switch svc.LogFormat {
+		case "", "json", "logfmt", "plain":
+		default:
+			return fmt.Errorf("service %q: log_format must be json, logfmt, or plain", name)
+		}
+		if svc.RestartMax < 0 {
+			return fmt.Errorf("service %q: restart_max must be 0 or greater", name)
+		}
+		if svc.RestartBackoff != nil {
+			if _, _, _, err := svc.RestartBackoff.Curve(); err != nil {
+				return fmt.Errorf("service %q: %w", name, err)
+			}
+		}
+		if _, _, err := svc.ReadyCmdTiming(); err != nil {
+			return fmt.Errorf("service %q: %w", name, err)
+		}
+		if _, err := svc.StopTimeoutDuration(); err != nil {
+			return fmt.Errorf("service %q: %w", name, err)
+		}
+		if _, err := svc.ReadyHTTPURL(svc.Port); err != nil {
+			return fmt.Errorf("service %q: %w", name, err)
+		}
+		if svc.ReadyTCP && svc.Port <= 0 {
+			return fmt.Errorf("service %q: ready_tcp needs a port", name)
+		}
+		if err := validateSecretRefs(svc.Env); err != nil {
+			return fmt.Errorf("service %q: %w", name, err)
+		}
+		for _, tag := range svc.Tags {
+			if strings.TrimSpace(tag) == "" || strings.ContainsAny(tag, " \t:") {
+				return fmt.Errorf("service %q: invalid tag %q (tags must be single words without ':')", name, tag)
+			}
 		}
 		for _, dep := range svc.DependsOn {
 			if _, ok := proj.Services[dep]; !ok {
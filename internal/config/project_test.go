@@ -0,0 +1,294 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSelectServicesByTagIncludesDependencies(t *testing.T) {
+	proj := &Project{
+		Name: "shop",
+		Services: map[string]*Service{
+			"db":     {Cmd: "postgres", Tags: []string{"infra"}},
+			"api":    {Cmd: "go run .", Tags: []string{"backend", "critical"}, DependsOn: []string{"db"}},
+			"worker": {Cmd: "go run ./worker", Tags: []string{"backend"}},
+			"web":    {Cmd: "bun run dev", Tags: []string{"frontend"}},
+		},
+	}
+
+	selected, err := SelectServices(proj, []string{"tag:critical"})
+	if err != nil {
+		t.Fatalf("SelectServices: %v", err)
+	}
+	names := make([]string, 0, len(selected.Services))
+	for name := range selected.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if strings.Join(names, ",") != "api,db" {
+		t.Fatalf("selected services = %v, want [api db]", names)
+	}
+	if len(proj.Services) != 4 {
+		t.Fatalf("original project mutated: %d services", len(proj.Services))
+	}
+
+	if _, err := SelectServices(proj, []string{"tag:missing"}); err == nil {
+		t.Fatal("expected error for unmatched tag")
+	}
+}
+
+func TestWriteProjectRefusesOverwriteWithoutFlag(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".hun.yml")
+	original := "name: hand-edited\nservices:\n  app:\n    cmd: echo keep\n"
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatalf("write original: %v", err)
+	}
+	proj := &Project{Name: "generated", Services: map[string]*Service{"app": {Cmd: "echo new"}}}
+
+	err := WriteProject(dir, proj, false)
+	if !errors.Is(err, ErrProjectExists) {
+		t.Fatalf("WriteProject error = %v, want ErrProjectExists", err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != original {
+		t.Fatalf("existing config was modified: %q", data)
+	}
+
+	if err := WriteProject(dir, proj, true); err != nil {
+		t.Fatalf("WriteProject with overwrite: %v", err)
+	}
+	loaded, err := LoadProject(dir)
+	if err != nil {
+		t.Fatalf("LoadProject: %v", err)
+	}
+	if loaded.Name != "generated" {
+		t.Fatalf("name = %q, want generated after overwrite", loaded.Name)
+	}
+}
+
+func TestLoadProjectRejectsInvalidRestartBackoff(t *testing.T) {
+	dir := t.TempDir()
+	yml := "name: demo\nservices:\n  api:\n    cmd: go run .\n    restart: on_failure\n    restart_backoff:\n      initial: soon\n"
+	if err := os.WriteFile(filepath.Join(dir, ".hun.yml"), []byte(yml), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	_, err := LoadProject(dir)
+	if err == nil || !strings.Contains(err.Error(), "restart_backoff.initial") {
+		t.Fatalf("expected restart_backoff validation error, got %v", err)
+	}
+}
+
+func TestProjectWithNewServicesKeepsExistingDefinitions(t *testing.T) {
+	proj := &Project{
+		Name: "shop",
+		Services: map[string]*Service{
+			"api":      {Cmd: "npm run dev -- --inspect", Cwd: "./apps/api", Port: 4000},
+			"frontend": {Cmd: "npm run dev", Cwd: "./apps/web"},
+		},
+	}
+	detected := map[string]*Service{
+		"api":    {Cmd: "npm run dev", Cwd: "./apps/api"},
+		"web":    {Cmd: "npm run dev", Cwd: "./apps/web"},
+		"worker": {Cmd: "npm run dev", Cwd: "./apps/worker", DependsOn: []string{"api", "queue"}},
+	}
+
+	updated, added, err := ProjectWithNewServices(proj, detected)
+	if err != nil {
+		t.Fatalf("ProjectWithNewServices: %v", err)
+	}
+	if len(added) != 1 || added[0] != "worker" {
+		t.Fatalf("added = %v, want [worker]", added)
+	}
+	if got := updated.Services["api"].Cmd; got != "npm run dev -- --inspect" {
+		t.Fatalf("existing api cmd = %q, want it untouched", got)
+	}
+	if _, ok := updated.Services["web"]; ok {
+		t.Fatalf("web duplicates frontend's cmd and cwd and should not be added")
+	}
+	if deps := updated.Services["worker"].DependsOn; len(deps) != 1 || deps[0] != "api" {
+		t.Fatalf("worker depends_on = %v, want unknown deps dropped", deps)
+	}
+	if _, ok := proj.Services["worker"]; ok {
+		t.Fatalf("input project should not be mutated")
+	}
+}
+
+func TestServiceWorkDirResolvesAbsoluteHomeAndRelativeCwd(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	root := filepath.Join(home, "src", "shop")
+
+	tests := []struct {
+		cwd  string
+		want string
+	}{
+		{"", root},
+		{"./apps/api", filepath.Join(root, "apps", "api")},
+		{"../tools", filepath.Join(home, "src", "tools")},
+		{"/opt/shared/", "/opt/shared"},
+		{"~", home},
+		{"~/src/auth", filepath.Join(home, "src", "auth")},
+	}
+	for _, tc := range tests {
+		svc := &Service{Cmd: "run", Cwd: tc.cwd}
+		if got := svc.WorkDir(root); got != tc.want {
+			t.Fatalf("WorkDir(%q) = %q, want %q", tc.cwd, got, tc.want)
+		}
+	}
+}
+
+func TestProjectProtectsServiceOrWholeProject(t *testing.T) {
+	proj := &Project{Services: map[string]*Service{
+		"migrate": {Cmd: "make migrate", Protect: true},
+		"web":     {Cmd: "npm run dev"},
+	}}
+	if !proj.Protects("migrate") || proj.Protects("web") {
+		t.Fatalf("service protection should follow each service's flag")
+	}
+	if !proj.Protects("") {
+		t.Fatalf("stopping the project should be protected when any service is")
+	}
+	proj.Protect = true
+	if !proj.Protects("web") {
+		t.Fatalf("project-level protect should cover every service")
+	}
+}
+
+func TestLoadProjectRejectsInvalidReadyCmdTiming(t *testing.T) {
+	dir := t.TempDir()
+	yml := "name: demo\nservices:\n  db:\n    cmd: postgres\n    ready_cmd: pg_isready\n    ready_interval: -1s\n"
+	if err := os.WriteFile(filepath.Join(dir, ".hun.yml"), []byte(yml), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	_, err := LoadProject(dir)
+	if err == nil || !strings.Contains(err.Error(), "ready_interval") {
+		t.Fatalf("expected ready_interval validation error, got %v", err)
+	}
+}
+
+func TestServiceEnabledEvaluatesWhenAgainstEnv(t *testing.T) {
+	svc := &Service{Cmd: "worker", When: "${HUN_TEST_RUN_WORKER:-true}"}
+	t.Setenv("HUN_TEST_RUN_WORKER", "")
+	if !svc.Enabled() {
+		t.Fatalf("unset variable should fall back to the default")
+	}
+	for _, value := range []string{"false", "0", "No", "off"} {
+		t.Setenv("HUN_TEST_RUN_WORKER", value)
+		if svc.Enabled() {
+			t.Fatalf("when=%q should disable the service", value)
+		}
+	}
+	t.Setenv("HUN_TEST_RUN_WORKER", "1")
+	if !svc.Enabled() {
+		t.Fatalf("truthy value should enable the service")
+	}
+	if !(&Service{Cmd: "web"}).Enabled() {
+		t.Fatalf("service without when should always be enabled")
+	}
+}
+
+func TestResolveWhenUsesCallerEnvironmentOverOwn(t *testing.T) {
+	t.Setenv("HUN_TEST_RUN_WORKER", "1")
+	proj := &Project{Services: map[string]*Service{
+		"worker": {Cmd: "worker", When: "$HUN_TEST_RUN_WORKER"},
+		"docs":   {Cmd: "docs", When: "${HUN_TEST_DOCS:-yes}"},
+		"web":    {Cmd: "web"},
+	}}
+	proj.ResolveWhen([]string{"HUN_TEST_RUN_WORKER=off", "PATH=/usr/bin"})
+	if proj.Services["worker"].Enabled() {
+		t.Fatal("worker should follow the caller's HUN_TEST_RUN_WORKER=off, not this process's 1")
+	}
+	if !proj.Services["docs"].Enabled() || !proj.Services["web"].Enabled() {
+		t.Fatalf("docs and web should stay enabled: %+v", proj.Services)
+	}
+
+	own := &Project{Services: map[string]*Service{"worker": {Cmd: "worker", When: "$HUN_TEST_RUN_WORKER"}}}
+	own.ResolveWhen(nil)
+	if !own.Services["worker"].Enabled() {
+		t.Fatal("without a caller environment the process's own should apply")
+	}
+}
+
+func TestLoadProjectRejectsEmptySecretReference(t *testing.T) {
+	dir := t.TempDir()
+	yml := "name: demo\nservices:\n  api:\n    cmd: node server.js\n    env:\n      API_KEY: ${secret:}\n"
+	if err := os.WriteFile(filepath.Join(dir, ".hun.yml"), []byte(yml), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	_, err := LoadProject(dir)
+	if err == nil || !strings.Contains(err.Error(), "secret reference") {
+		t.Fatalf("expected secret reference validation error, got %v", err)
+	}
+}
+
+func TestReadyHTTPURLForms(t *testing.T) {
+	for _, tc := range []struct {
+		value string
+		port  int
+		want  string
+	}{
+		{"", 3000, ""},
+		{"/healthz", 3000, "http://127.0.0.1:3000/healthz"},
+		{":9000/ready", 3000, "http://127.0.0.1:9000/ready"},
+		{"https://api.local.test/up", 0, "https://api.local.test/up"},
+	} {
+		svc := &Service{Cmd: "serve", ReadyHTTP: tc.value}
+		if got, err := svc.ReadyHTTPURL(tc.port); err != nil || got != tc.want {
+			t.Fatalf("ReadyHTTPURL(%q, %d) = %q, %v; want %q", tc.value, tc.port, got, err, tc.want)
+		}
+	}
+	for _, bad := range []string{"/healthz", ":0/x", "healthz", "http://"} {
+		svc := &Service{Cmd: "serve", ReadyHTTP: bad}
+		if _, err := svc.ReadyHTTPURL(0); err == nil {
+			t.Fatalf("ReadyHTTPURL(%q) without a port should fail", bad)
+		}
+	}
+}
+
+func TestValidateProjectReservesTheHookLogName(t *testing.T) {
+	proj := &Project{
+		Name:     "shop",
+		Services: map[string]*Service{HookLogService: {Cmd: "npm start"}},
+	}
+	if err := validateProject(proj); err == nil || !strings.Contains(err.Error(), "reserved") {
+		t.Fatalf("validateProject error = %v, want the hooks name rejected", err)
+	}
+}
+
+func TestValidateProjectChecksHookTimeout(t *testing.T) {
+	proj := &Project{
+		Name:     "shop",
+		Services: map[string]*Service{"web": {Cmd: "npm start"}},
+		Hooks:    Hooks{Timeout: "soon"},
+	}
+	if err := validateProject(proj); err == nil || !strings.Contains(err.Error(), "hooks.timeout") {
+		t.Fatalf("validateProject error = %v, want a hooks.timeout error", err)
+	}
+	proj.Hooks.Timeout = "2m"
+	if timeout, err := proj.Hooks.TimeoutDuration(); err != nil || timeout != 2*time.Minute {
+		t.Fatalf("TimeoutDuration = %v, %v", timeout, err)
+	}
+	if timeout, _ := (Hooks{}).TimeoutDuration(); timeout != DefaultHookTimeout {
+		t.Fatalf("default timeout = %v", timeout)
+	}
+}
+
+func TestValidateProjectChecksStopTimeout(t *testing.T) {
+	proj := &Project{
+		Name:     "shop",
+		Services: map[string]*Service{"web": {Cmd: "npm start", StopTimeout: "-1s"}},
+	}
+	if err := validateProject(proj); err == nil || !strings.Contains(err.Error(), "stop_timeout") {
+		t.Fatalf("validateProject error = %v, want a stop_timeout error", err)
+	}
+	proj.Services["web"].StopTimeout = "20s"
+	if timeout, err := proj.Services["web"].StopTimeoutDuration(); err != nil || timeout != 20*time.Second {
+		t.Fatalf("StopTimeoutDuration = %v, %v", timeout, err)
+	}
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// secretRefRegex matches ${secret:NAME} inside an env value.
+var secretRefRegex = regexp.MustCompile(`\$\{secret:([^}]*)\}`)
+
+// SecretRefs returns the secret names referenced by an env value.
+func SecretRefs(value string) []string {
+	var names []string
+	for _, match := range secretRefRegex.FindAllStringSubmatch(value, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
+
+// ExpandSecrets replaces every ${secret:NAME} in value with lookup(NAME).
+func ExpandSecrets(value string, lookup func(name string) (string, error)) (string, error) {
+	var firstErr error
+	expanded := secretRefRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		if firstErr != nil {
+			return ""
+		}
+		secret, err := lookup(secretRefRegex.FindStringSubmatch(ref)[1])
+		if err != nil {
+			firstErr = err
+		}
+		return secret
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+	return expanded, nil
+}
+
+func validateSecretRefs(env map[string]string) error {
+	for key, value := range env {
+		for _, name := range SecretRefs(value) {
+			if strings.TrimSpace(name) == "" || strings.ContainsAny(name, " \t\"'$") {
+				return fmt.Errorf("env %s: invalid secret reference ${secret:%s}", key, name)
+			}
+		}
+	}
+	return nil
+}
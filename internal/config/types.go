@@ -1,5 +1,15 @@
 package config
 
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
 // Project represents a .hun.yml project configuration.
 type Project struct {
 	Name     string              `yaml:"name"`
@@ -7,6 +17,34 @@ type Project struct {
 	Hooks    Hooks               `yaml:"hooks,omitempty"`
 	Logs     LogsConfig          `yaml:"logs,omitempty"`
 	Detect   DetectConfig        `yaml:"detect,omitempty"`
+	Protect  bool                `yaml:"protect,omitempty"` // confirm before stopping any service
+
+	// SecretCommand resolves ${secret:NAME} references in service env. It runs
+	// in the project directory with NAME in $HUN_SECRET, and its trimmed
+	// stdout is the value, e.g. op read "op://dev/shop/$HUN_SECRET".
+	SecretCommand string `yaml:"secret_command,omitempty"`
+}
+
+// Protects reports whether stopping service needs confirmation. An empty
+// service means the whole project, which is protected when any of its
+// services is.
+func (p *Project) Protects(service string) bool {
+	if p == nil {
+		return false
+	}
+	if p.Protect {
+		return true
+	}
+	if service != "" {
+		svc := p.Services[service]
+		return svc != nil && svc.Protect
+	}
+	for _, svc := range p.Services {
+		if svc != nil && svc.Protect {
+			return true
+		}
+	}
+	return false
 }
 
 // Service represents a single service within a project.
@@ -18,26 +56,283 @@ type Service struct {
 	Ready     string            `yaml:"ready,omitempty"`
 	Env       map[string]string `yaml:"env,omitempty"`
 	DependsOn []string          `yaml:"depends_on,omitempty"`
-	Restart   string            `yaml:"restart,omitempty"` // "on_failure" or ""
+	Restart   string            `yaml:"restart,omitempty"`    // "on_failure", "always", or ""
+	Tags      []string          `yaml:"tags,omitempty"`       // free-form labels, e.g. backend, critical
+	LogFormat string            `yaml:"log_format,omitempty"` // json, logfmt, or plain (default: guess)
+	Protect   bool              `yaml:"protect,omitempty"`    // confirm before stopping, e.g. a long migration
+	When      string            `yaml:"when,omitempty"`       // e.g. ${RUN_WORKER:-true}; falsy skips the service on project start
+
+	// ReadyCmd is run repeatedly in the service's directory until it exits 0,
+	// which marks the service ready (e.g. "pg_isready -h localhost").
+	ReadyCmd      string `yaml:"ready_cmd,omitempty"`
+	ReadyInterval string `yaml:"ready_interval,omitempty"` // between ready_cmd attempts (default 1s)
+	ReadyTimeout  string `yaml:"ready_timeout,omitempty"`  // per ready_cmd attempt (default 5s)
+
+	// ReadyHTTP is polled until it answers 2xx, which marks the service ready:
+	// a full URL, a path on the service's port ("/healthz"), or a path on
+	// another local port (":9000/healthz").
+	ReadyHTTP string `yaml:"ready_http,omitempty"`
+	// ReadyTCP marks the service ready once its port accepts a connection,
+	// for servers and databases that log nothing recognizable.
+	ReadyTCP bool `yaml:"ready_tcp,omitempty"`
+
+	RestartBackoff *RestartBackoff `yaml:"restart_backoff,omitempty"`
+	RestartMax     int             `yaml:"restart_max,omitempty"` // give up after this many restarts in a row (0: never)
+
+	// StopTimeout is how long a stop waits after SIGTERM before sending
+	// SIGKILL, e.g. "20s" for a service that flushes on shutdown.
+	StopTimeout string `yaml:"stop_timeout,omitempty"`
+}
+
+// DefaultStopTimeout is the SIGTERM grace when stop_timeout is unset.
+const DefaultStopTimeout = 5 * time.Second
+
+// StopTimeoutDuration returns the service's SIGTERM grace period.
+func (s *Service) StopTimeoutDuration() (time.Duration, error) {
+	if s == nil || s.StopTimeout == "" {
+		return DefaultStopTimeout, nil
+	}
+	timeout, err := time.ParseDuration(s.StopTimeout)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("stop_timeout %q must be a positive duration", s.StopTimeout)
+	}
+	return timeout, nil
+}
+
+// ReadyCmdTiming returns how often ready_cmd runs and how long each attempt
+// may take, applying the defaults.
+func (s *Service) ReadyCmdTiming() (interval, timeout time.Duration, err error) {
+	interval, timeout = time.Second, 5*time.Second
+	if s == nil {
+		return interval, timeout, nil
+	}
+	if s.ReadyInterval != "" {
+		if interval, err = time.ParseDuration(s.ReadyInterval); err != nil || interval <= 0 {
+			return 0, 0, fmt.Errorf("ready_interval %q must be a positive duration", s.ReadyInterval)
+		}
+	}
+	if s.ReadyTimeout != "" {
+		if timeout, err = time.ParseDuration(s.ReadyTimeout); err != nil || timeout <= 0 {
+			return 0, 0, fmt.Errorf("ready_timeout %q must be a positive duration", s.ReadyTimeout)
+		}
+	}
+	return interval, timeout, nil
+}
+
+// ReadyHTTPURL resolves ReadyHTTP to the URL to poll, with port standing in
+// for the service's port in the path form. It returns "" when ReadyHTTP is
+// unset.
+func (s *Service) ReadyHTTPURL(port int) (string, error) {
+	if s == nil || s.ReadyHTTP == "" {
+		return "", nil
+	}
+	value := strings.TrimSpace(s.ReadyHTTP)
+	switch {
+	case strings.HasPrefix(value, "http://"), strings.HasPrefix(value, "https://"):
+		u, err := url.Parse(value)
+		if err != nil || u.Host == "" {
+			return "", fmt.Errorf("ready_http %q is not a valid URL", s.ReadyHTTP)
+		}
+		return value, nil
+	case strings.HasPrefix(value, ":"):
+		portPart, path, _ := strings.Cut(value[1:], "/")
+		if n, err := strconv.Atoi(portPart); err != nil || n <= 0 || n > 65535 {
+			return "", fmt.Errorf("ready_http %q needs a port between 1 and 65535", s.ReadyHTTP)
+		}
+		return "http://127.0.0.1:" + portPart + "/" + path, nil
+	case strings.HasPrefix(value, "/"):
+		if port <= 0 {
+			return "", fmt.Errorf("ready_http path %q needs the service to have a port", s.ReadyHTTP)
+		}
+		return fmt.Sprintf("http://127.0.0.1:%d%s", port, value), nil
+	}
+	return "", fmt.Errorf("ready_http %q must be a URL, a /path, or :port/path", s.ReadyHTTP)
+}
+
+// HasTag reports whether the service carries tag (case-insensitive).
+func (s *Service) HasTag(tag string) bool {
+	if s == nil {
+		return false
+	}
+	for _, t := range s.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Enabled evaluates When against this process's environment. Shell-style
+// $VAR, ${VAR}, ${VAR:-default} and ${VAR-default} are expanded, and the
+// result is false when it is empty, 0, false, no, or off (any case). A
+// service without When is always enabled.
+func (s *Service) Enabled() bool {
+	return s.enabledIn(os.LookupEnv)
+}
+
+func (s *Service) enabledIn(lookup func(string) (string, bool)) bool {
+	if s == nil || strings.TrimSpace(s.When) == "" {
+		return true
+	}
+	value := os.Expand(s.When, func(ref string) string {
+		return expandWithDefault(ref, lookup)
+	})
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "0", "false", "no", "off":
+		return false
+	}
+	return true
+}
+
+// ResolveWhen evaluates every service's When against env, KEY=VALUE entries
+// as from os.Environ, and replaces it with the outcome. The daemon outlives
+// the shell that asked it to start a project, so conditions are resolved
+// with the caller's environment before the config reaches it. A nil env
+// leaves the conditions to the daemon's own environment.
+func (p *Project) ResolveWhen(env []string) {
+	if p == nil || env == nil {
+		return
+	}
+	vars := make(map[string]string, len(env))
+	for _, kv := range env {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			vars[key] = value
+		}
+	}
+	lookup := func(name string) (string, bool) {
+		value, ok := vars[name]
+		return value, ok
+	}
+	for _, svc := range p.Services {
+		if svc == nil || strings.TrimSpace(svc.When) == "" {
+			continue
+		}
+		if svc.enabledIn(lookup) {
+			svc.When = ""
+		} else {
+			svc.When = "false"
+		}
+	}
 }
 
+// expandWithDefault resolves a variable reference for os.Expand, honoring
+// the ${VAR:-default} (unset or empty) and ${VAR-default} (unset) forms.
+func expandWithDefault(ref string, lookup func(string) (string, bool)) string {
+	if name, def, ok := strings.Cut(ref, ":-"); ok {
+		if value, _ := lookup(name); value != "" {
+			return value
+		}
+		return def
+	}
+	if name, def, ok := strings.Cut(ref, "-"); ok {
+		if value, set := lookup(name); set {
+			return value
+		}
+		return def
+	}
+	value, _ := lookup(ref)
+	return value
+}
+
+// WorkDir returns the directory the service runs in. An empty Cwd means the
+// project root; absolute and ~-prefixed paths are used as-is so a project can
+// run services living outside it; anything else is relative to projectPath.
+func (s *Service) WorkDir(projectPath string) string {
+	if s == nil || s.Cwd == "" {
+		return projectPath
+	}
+	cwd := s.Cwd
+	if cwd == "~" || strings.HasPrefix(cwd, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			cwd = filepath.Join(home, strings.TrimPrefix(cwd[1:], "/"))
+		}
+	}
+	if filepath.IsAbs(cwd) {
+		return filepath.Clean(cwd)
+	}
+	return filepath.Join(projectPath, cwd)
+}
+
+// RestartBackoff shapes the delay between on_failure restarts. Each crash
+// multiplies the delay until it reaches Max; a long healthy run resets it.
+type RestartBackoff struct {
+	Initial    string  `yaml:"initial,omitempty"`    // e.g. "1s"
+	Max        string  `yaml:"max,omitempty"`        // e.g. "30s"
+	Multiplier float64 `yaml:"multiplier,omitempty"` // e.g. 2
+}
+
+// Curve returns the parsed backoff, filling unset fields with 1s, 30s and 2x.
+func (b *RestartBackoff) Curve() (initial, max time.Duration, multiplier float64, err error) {
+	initial, max, multiplier = time.Second, 30*time.Second, 2
+	if b == nil {
+		return initial, max, multiplier, nil
+	}
+	if b.Initial != "" {
+		if initial, err = time.ParseDuration(b.Initial); err != nil || initial <= 0 {
+			return 0, 0, 0, fmt.Errorf("restart_backoff.initial %q must be a positive duration", b.Initial)
+		}
+	}
+	if b.Max != "" {
+		if max, err = time.ParseDuration(b.Max); err != nil || max <= 0 {
+			return 0, 0, 0, fmt.Errorf("restart_backoff.max %q must be a positive duration", b.Max)
+		}
+	}
+	if b.Multiplier != 0 {
+		if b.Multiplier < 1 {
+			return 0, 0, 0, fmt.Errorf("restart_backoff.multiplier must be at least 1")
+		}
+		multiplier = b.Multiplier
+	}
+	if max < initial {
+		max = initial
+	}
+	return initial, max, multiplier, nil
+}
+
+// HookLogService is the log stream a project's hooks write to, next to its
+// services. No service may take the name.
+const HookLogService = "hooks"
+
 // Hooks defines lifecycle hooks for a project.
 type Hooks struct {
-	PreStart string `yaml:"pre_start,omitempty"`
-	PostStop string `yaml:"post_stop,omitempty"`
+	PreStart  string `yaml:"pre_start,omitempty"`
+	PostStart string `yaml:"post_start,omitempty"` // once every service is ready; failures only warn
+	PreStop   string `yaml:"pre_stop,omitempty"`
+	PostStop  string `yaml:"post_stop,omitempty"`
+
+	// Timeout bounds each hook, e.g. "2m"; a hook still running then is
+	// killed along with everything it spawned. Defaults to 30s.
+	Timeout string            `yaml:"timeout,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty"`
+}
+
+// DefaultHookTimeout is how long a hook may run when timeout is unset.
+const DefaultHookTimeout = 30 * time.Second
+
+// TimeoutDuration returns how long each hook may run.
+func (h Hooks) TimeoutDuration() (time.Duration, error) {
+	if h.Timeout == "" {
+		return DefaultHookTimeout, nil
+	}
+	timeout, err := time.ParseDuration(h.Timeout)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("hooks.timeout %q must be a positive duration", h.Timeout)
+	}
+	return timeout, nil
 }
 
 // LogsConfig controls log rotation settings.
 type LogsConfig struct {
-	MaxSize   string `yaml:"max_size,omitempty"`  // e.g. "10MB"
-	MaxFiles  int    `yaml:"max_files,omitempty"` // e.g. 3
-	Retention string `yaml:"retention,omitempty"` // e.g. "7d"
+	MaxSize   string `yaml:"max_size,omitempty" json:"max_size,omitempty"`   // e.g. "10MB"
+	MaxFiles  int    `yaml:"max_files,omitempty" json:"max_files,omitempty"` // e.g. 3
+	Retention string `yaml:"retention,omitempty" json:"retention,omitempty"` // e.g. "7d"
 }
 
 // DetectConfig stores metadata about auto-detection mode used to generate the file.
 type DetectConfig struct {
-	Version string `yaml:"version,omitempty"` // v2
-	Profile string `yaml:"profile,omitempty"` // local, compose, hybrid
+	Version         string   `yaml:"version,omitempty"`          // v2
+	Profile         string   `yaml:"profile,omitempty"`          // local, compose, hybrid
+	ComposeProfiles []string `yaml:"compose_profiles,omitempty"` // compose profiles whose services were included
 }
 
 // Global represents ~/.hun/config.yml global configuration.
@@ -46,6 +341,44 @@ type Global struct {
 	ScanDirs []string       `yaml:"scan_dirs,omitempty"`
 	Ports    PortsConfig    `yaml:"ports,omitempty"`
 	Hotkeys  HotkeysConfig  `yaml:"hotkeys,omitempty"`
+	Logs     GlobalLogs     `yaml:"logs,omitempty"`
+	UI       string         `yaml:"ui,omitempty"`    // what a bare `hun` opens: tui (default) or cli
+	Theme    string         `yaml:"theme,omitempty"` // TUI palette: dark (default), light, or auto
+	Picker   GlobalPicker   `yaml:"picker,omitempty"`
+	Services GlobalServices `yaml:"services,omitempty"`
+
+	// RecoveryOrder maps project names to their restart order when the daemon
+	// recovers running projects; lower starts first, unlisted projects last.
+	RecoveryOrder map[string]int `yaml:"recovery_order,omitempty"`
+
+	// SecretCommand is used for projects that don't set their own.
+	SecretCommand string `yaml:"secret_command,omitempty"`
+}
+
+// GlobalLogs holds daemon-wide log buffering settings.
+type GlobalLogs struct {
+	// IdleBufferLines caps in-memory scrollback per service for projects that
+	// are running but not focused; 0 keeps the full buffer for every project.
+	IdleBufferLines int `yaml:"idle_buffer_lines,omitempty"`
+
+	// HideMeta starts the TUI with hun's own notices (detected ports, dropped
+	// lines, shutdown) hidden from the logs pane.
+	HideMeta bool `yaml:"hide_meta,omitempty"`
+}
+
+// GlobalPicker holds TUI project picker settings.
+type GlobalPicker struct {
+	// Favorites places favorite projects: "top" (default) lists them above
+	// every other project, running or not; "grouped" only sorts them first
+	// among the running and among the stopped projects.
+	Favorites string `yaml:"favorites,omitempty"`
+}
+
+// GlobalServices holds TUI services pane settings.
+type GlobalServices struct {
+	// Enter is what enter does on a stopped or crashed service: "logs"
+	// (default) moves focus to its logs, "start" also starts it.
+	Enter string `yaml:"enter,omitempty"`
 }
 
 // GlobalDefaults holds default behavior settings.
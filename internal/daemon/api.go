@@ -4,22 +4,69 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"runtime"
+	"slices"
 	"sort"
 	"time"
 
 	"github.com/sourabhrathourr/hun/internal/config"
+	"github.com/sourabhrathourr/hun/internal/state"
 )
 
 // Request represents a JSON command from CLI/TUI.
 type Request struct {
-	Action  string `json:"action"`
-	Project string `json:"project,omitempty"`
-	Service string `json:"service,omitempty"`
-	Path    string `json:"path,omitempty"`
-	Mode    string `json:"mode,omitempty"` // "exclusive" or "parallel"
-	Lines   int    `json:"lines,omitempty"`
-	Note    string `json:"note,omitempty"`
-	Origin  string `json:"origin,omitempty"`
+	Action  string             `json:"action"`
+	Project string             `json:"project,omitempty"`
+	Service string             `json:"service,omitempty"`
+	Path    string             `json:"path,omitempty"`
+	Mode    string             `json:"mode,omitempty"` // "exclusive" or "parallel"
+	Lines   int                `json:"lines,omitempty"`
+	Note    string             `json:"note,omitempty"`
+	Origin  string             `json:"origin,omitempty"`
+	Only    []string           `json:"only,omitempty"`  // service names or "tag:<name>" selectors for start and ready
+	Since   string             `json:"since,omitempty"` // RFC3339 lower bound for logs
+	Until   string             `json:"until,omitempty"` // RFC3339 upper bound for logs
+	Grep    []string           `json:"grep,omitempty"`  // log filter specs (see ParseLogMatch); every spec must match
+	Logs    *config.LogsConfig `json:"logs,omitempty"`  // fields to change for set_logs_config
+	Env     []string           `json:"env,omitempty"`   // caller's KEY=VALUE environment for evaluating when:
+	Safe    bool               `json:"safe,omitempty"`  // start one service at a time, no auto-restart, halt on first crash
+	State   json.RawMessage    `json:"state,omitempty"` // full state.State for import_state
+
+	Revision uint64 `json:"revision,omitempty"` // status_since: the revision the client last saw
+}
+
+// ReadyReport answers the ready action: Ready is true only when every
+// selected service is running and has passed its readiness check.
+type ReadyReport struct {
+	Project  string                      `json:"project"`
+	Ready    bool                        `json:"ready"`
+	Services map[string]ServiceReadiness `json:"services"`
+}
+
+// ServiceReadiness is the per-service detail of a ReadyReport.
+type ServiceReadiness struct {
+	Running bool   `json:"running"`
+	Ready   bool   `json:"ready"`
+	Status  string `json:"status"`
+}
+
+// DaemonStats answers the stats action with the daemon's own health, for
+// tracking down leaks such as subscribers that never go away.
+type DaemonStats struct {
+	PID             int                       `json:"pid"`
+	StartedAt       time.Time                 `json:"started_at"`
+	Uptime          string                    `json:"uptime"`
+	RunningProjects []string                  `json:"running_projects"`
+	Subscribers     int                       `json:"subscribers"`
+	Goroutines      int                       `json:"goroutines"`
+	LogBuffers      map[string]LogBufferStats `json:"log_buffers"` // by project
+}
+
+// LogBufferStats totals a project's in-memory log buffers.
+type LogBufferStats struct {
+	Services int `json:"services"`
+	Lines    int `json:"lines"`
+	Capacity int `json:"capacity"`
 }
 
 // Response is the JSON response from the daemon.
@@ -61,6 +108,7 @@ func (d *Daemon) HandleRequest(req Request) Response {
 			"version":    d.version,
 			"commit":     d.commit,
 			"pid":        os.Getpid(),
+			"uid":        os.Getuid(),
 			"started_at": d.startedAt,
 		})
 	case "start":
@@ -73,14 +121,28 @@ func (d *Daemon) HandleRequest(req Request) Response {
 		return d.handleStopService(req)
 	case "remove_service":
 		return d.handleRemoveService(req)
+	case "pause", "resume":
+		return d.handlePauseResume(req)
+	case "set_logs_config":
+		return d.handleSetLogsConfig(req)
 	case "set_project_icon":
 		return d.handleSetProjectIcon(req)
 	case "clear_project_icon":
 		return d.handleClearProjectIcon(req)
+	case "set_project_favorite", "clear_project_favorite":
+		return d.handleProjectFavorite(req)
+	case "pin_service", "unpin_service":
+		return d.handleServicePin(req)
 	case "restart":
 		return d.handleRestart(req)
 	case "status":
 		return d.handleStatus()
+	case "stats":
+		return d.handleStats()
+	case "status_since":
+		return successResponse(d.manager.StatusSince(req.Revision))
+	case "ready":
+		return d.handleReady(req)
 	case "snapshot":
 		return d.handleSnapshot(false)
 	case "refresh":
@@ -93,6 +155,12 @@ func (d *Daemon) HandleRequest(req Request) Response {
 		return d.handlePorts()
 	case "focus":
 		return d.handleFocus(req)
+	case "environment":
+		return successResponse(CurrentServiceEnvironment())
+	case "export_state":
+		return successResponse(d.manager.StateSnapshot())
+	case "import_state":
+		return d.handleImportState(req)
 	case "subscribe":
 		// Handled at connection level, not here
 		return errorResponse("subscribe must be handled at connection level")
@@ -116,8 +184,8 @@ func (d *Daemon) acquireLifecycle(timeout time.Duration) bool {
 
 func serializesLifecycle(action string) bool {
 	switch action {
-	case "start", "start_service", "stop", "stop_service", "remove_service", "restart", "focus",
-		"snapshot", "refresh", "register_project", "add_project":
+	case "start", "start_service", "stop", "stop_service", "remove_service", "set_logs_config", "restart", "focus",
+		"snapshot", "refresh", "register_project", "add_project", "import_state":
 		return true
 	default:
 		return false
@@ -141,6 +209,13 @@ func (d *Daemon) handleStart(req Request) Response {
 	if err != nil {
 		return errorResponse(fmt.Sprintf("loading project config: %v", err))
 	}
+	proj.ResolveWhen(req.Env)
+	if len(req.Only) > 0 {
+		proj, err = config.SelectServices(proj, req.Only)
+		if err != nil {
+			return errorResponse(err.Error())
+		}
+	}
 
 	exclusive := req.Mode != "parallel"
 
@@ -167,7 +242,11 @@ func (d *Daemon) handleStart(req Request) Response {
 		return successResponse(map[string]string{"status": "already_running"})
 	}
 
-	if err := d.manager.StartProject(req.Project, proj, path, exclusive); err != nil {
+	start := d.manager.StartProject
+	if req.Safe {
+		start = d.manager.StartProjectSafe
+	}
+	if err := start(req.Project, proj, path, exclusive); err != nil {
 		return errorResponse(err.Error())
 	}
 
@@ -196,6 +275,7 @@ func (d *Daemon) handleStartService(req Request) Response {
 	if err != nil {
 		return errorResponse(fmt.Sprintf("loading project config: %v", err))
 	}
+	proj.ResolveWhen(req.Env)
 
 	exclusive := req.Mode != "parallel"
 	if exclusive {
@@ -250,6 +330,25 @@ func (d *Daemon) handleStopService(req Request) Response {
 	return successResponse(map[string]string{"status": "service_stopped"})
 }
 
+func (d *Daemon) handlePauseResume(req Request) Response {
+	if req.Project == "" {
+		return errorResponse("project name required")
+	}
+	if req.Service == "" {
+		return errorResponse("service name required")
+	}
+	if req.Action == "pause" {
+		if err := d.manager.PauseService(req.Project, req.Service); err != nil {
+			return errorResponse(err.Error())
+		}
+		return successResponse(map[string]string{"status": "service_paused"})
+	}
+	if err := d.manager.ResumeService(req.Project, req.Service); err != nil {
+		return errorResponse(err.Error())
+	}
+	return successResponse(map[string]string{"status": "service_resumed"})
+}
+
 func (d *Daemon) handleRemoveService(req Request) Response {
 	if req.Project == "" {
 		return errorResponse("project name required")
@@ -279,13 +378,59 @@ func (d *Daemon) handleRemoveService(req Request) Response {
 			return errorResponse(err.Error())
 		}
 	}
-	if err := config.WriteProject(path, updated); err != nil {
+	if err := config.WriteProject(path, updated, true); err != nil {
 		return errorResponse(err.Error())
 	}
 	d.manager.ForgetService(req.Project, req.Service, updated)
 	return successResponse(map[string]string{"status": "service_removed"})
 }
 
+// handleSetLogsConfig merges req.Logs into the project's .hun.yml and applies
+// the new rotation settings to a running project immediately.
+func (d *Daemon) handleSetLogsConfig(req Request) Response {
+	if req.Project == "" {
+		return errorResponse("project name required")
+	}
+	if req.Logs == nil {
+		return errorResponse("no logs settings given")
+	}
+	if req.Logs.MaxSize != "" && parseSizeMB(req.Logs.MaxSize) <= 0 {
+		return errorResponse(fmt.Sprintf("invalid max size %q (use e.g. 50MB or 1GB)", req.Logs.MaxSize))
+	}
+	if req.Logs.MaxFiles < 0 {
+		return errorResponse("max files must be 0 or greater")
+	}
+	if req.Logs.Retention != "" && parseRetentionDays(req.Logs.Retention) <= 0 {
+		return errorResponse(fmt.Sprintf("invalid retention %q (use e.g. 14d)", req.Logs.Retention))
+	}
+
+	if _, err := d.manager.ReconcileDiscovery(true); err != nil {
+		return errorResponse(fmt.Sprintf("refreshing project registry: %v", err))
+	}
+	path, ok := d.manager.ProjectPath(req.Project)
+	if !ok {
+		return errorResponse(fmt.Sprintf("project %q not in registry", req.Project))
+	}
+	proj, err := config.LoadProject(path)
+	if err != nil {
+		return errorResponse(fmt.Sprintf("loading project config: %v", err))
+	}
+	if req.Logs.MaxSize != "" {
+		proj.Logs.MaxSize = req.Logs.MaxSize
+	}
+	if req.Logs.MaxFiles > 0 {
+		proj.Logs.MaxFiles = req.Logs.MaxFiles
+	}
+	if req.Logs.Retention != "" {
+		proj.Logs.Retention = req.Logs.Retention
+	}
+	if err := config.WriteProject(path, proj, true); err != nil {
+		return errorResponse(err.Error())
+	}
+	d.manager.ApplyLogsConfig(req.Project, proj.Logs)
+	return successResponse(proj.Logs)
+}
+
 func (d *Daemon) handleSetProjectIcon(req Request) Response {
 	if req.Project == "" {
 		return errorResponse("project name required")
@@ -316,6 +461,31 @@ func (d *Daemon) handleClearProjectIcon(req Request) Response {
 	return successResponse(map[string]string{"status": "project_icon_cleared"})
 }
 
+func (d *Daemon) handleProjectFavorite(req Request) Response {
+	if req.Project == "" {
+		return errorResponse("project name required")
+	}
+	if _, err := d.manager.ReconcileDiscovery(true); err != nil {
+		return errorResponse(fmt.Sprintf("refreshing project registry: %v", err))
+	}
+	favorite := req.Action == "set_project_favorite"
+	if err := d.manager.SetProjectFavorite(req.Project, favorite); err != nil {
+		return errorResponse(err.Error())
+	}
+	return successResponse(map[string]bool{"favorite": favorite})
+}
+
+func (d *Daemon) handleServicePin(req Request) Response {
+	if req.Project == "" || req.Service == "" {
+		return errorResponse("project and service required")
+	}
+	pinned := req.Action == "pin_service"
+	if err := d.manager.SetServicePinned(req.Project, req.Service, pinned); err != nil {
+		return errorResponse(err.Error())
+	}
+	return successResponse(map[string]bool{"pinned": pinned})
+}
+
 func (d *Daemon) handleRestart(req Request) Response {
 	if req.Project == "" {
 		return errorResponse("project name required")
@@ -330,6 +500,7 @@ func (d *Daemon) handleRestart(req Request) Response {
 		if err != nil {
 			return errorResponse(err.Error())
 		}
+		proj.ResolveWhen(req.Env)
 
 		wasExclusive := d.manager.currentMode() == "focus"
 		d.manager.StopProject(req.Project)
@@ -347,7 +518,85 @@ func (d *Daemon) handleRestart(req Request) Response {
 }
 
 func (d *Daemon) handleStatus() Response {
-	return successResponse(d.manager.Status())
+	return successResponse(d.manager.StatusWithUsage())
+}
+
+func (d *Daemon) handleStats() Response {
+	return successResponse(DaemonStats{
+		PID:             os.Getpid(),
+		StartedAt:       d.startedAt,
+		Uptime:          time.Since(d.startedAt).Round(time.Second).String(),
+		RunningProjects: d.manager.RunningProjects(),
+		Subscribers:     d.manager.subscribers.Count(),
+		Goroutines:      runtime.NumGoroutine(),
+		LogBuffers:      d.manager.logs.BufferStats(),
+	})
+}
+
+func (d *Daemon) handleReady(req Request) Response {
+	if req.Project == "" {
+		return errorResponse("project name required")
+	}
+	running := d.manager.Status()[req.Project]
+
+	// Prefer the project config so services that never started count as not ready.
+	var names []string
+	if path, ok := d.manager.ProjectPath(req.Project); ok {
+		if proj, err := config.LoadProject(path); err == nil {
+			proj.ResolveWhen(req.Env)
+			if len(req.Only) > 0 {
+				if proj, err = config.SelectServices(proj, req.Only); err != nil {
+					return errorResponse(err.Error())
+				}
+			}
+			for name, svc := range proj.Services {
+				// Services switched off by when: are not waited for unless asked for.
+				if len(req.Only) == 0 && !svc.Enabled() {
+					continue
+				}
+				names = append(names, name)
+			}
+		}
+	}
+	if names == nil {
+		if running == nil {
+			return errorResponse(fmt.Sprintf("project %q not in registry", req.Project))
+		}
+		selected := req.Only
+		if proj := d.manager.RunningProjectConfig(req.Project); proj != nil && len(req.Only) > 0 {
+			// Resolve tag: selectors and dependencies like the main path.
+			picked, err := config.SelectServices(proj, req.Only)
+			if err != nil {
+				return errorResponse(err.Error())
+			}
+			selected = make([]string, 0, len(picked.Services))
+			for name := range picked.Services {
+				selected = append(selected, name)
+			}
+		}
+		for name, info := range running {
+			if len(req.Only) == 0 && info.Status == "disabled" {
+				continue
+			}
+			if len(req.Only) == 0 || slices.Contains(selected, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	report := ReadyReport{Project: req.Project, Ready: len(names) > 0, Services: make(map[string]ServiceReadiness, len(names))}
+	for _, name := range names {
+		info, ok := running[name]
+		detail := ServiceReadiness{Status: "stopped"}
+		if ok {
+			detail = ServiceReadiness{Running: info.Running, Ready: info.Running && info.Ready, Status: info.Status}
+		}
+		if !detail.Ready {
+			report.Ready = false
+		}
+		report.Services[name] = detail
+	}
+	return successResponse(report)
 }
 
 func (d *Daemon) handleSnapshot(force bool) Response {
@@ -378,14 +627,68 @@ func (d *Daemon) handleLogs(req Request) Response {
 	if lines <= 0 {
 		lines = 500
 	}
-	logLines := d.manager.GetLogs(req.Project, req.Service, lines)
+	since, err := parseLogTime("since", req.Since)
+	if err != nil {
+		return errorResponse(err.Error())
+	}
+	until, err := parseLogTime("until", req.Until)
+	if err != nil {
+		return errorResponse(err.Error())
+	}
+	if !since.IsZero() && !until.IsZero() && until.Before(since) {
+		return errorResponse("until must not be before since")
+	}
+	if len(req.Grep) > 0 {
+		// Filter the whole window first so the line limit counts matches, not raw lines.
+		logLines := daemonMatchTail(d.manager.GetLogsBetween(req.Project, req.Service, 0, since, until), req.Grep, lines)
+		return successResponse(logLines)
+	}
+	logLines := d.manager.GetLogsBetween(req.Project, req.Service, lines, since, until)
 	return successResponse(logLines)
 }
 
+func daemonMatchTail(lines []LogLine, specs []string, n int) []LogLine {
+	lines = MatchLines(lines, specs)
+	if n > 0 && len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+func parseLogTime(field, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	ts, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s %q: expected RFC3339 timestamp", field, value)
+	}
+	return ts, nil
+}
+
 func (d *Daemon) handlePorts() Response {
 	return successResponse(d.manager.Ports())
 }
 
+func (d *Daemon) handleImportState(req Request) Response {
+	if len(req.State) == 0 {
+		return errorResponse("state required")
+	}
+	var imported state.State
+	if err := json.Unmarshal(req.State, &imported); err != nil {
+		return errorResponse(fmt.Sprintf("parsing state: %v", err))
+	}
+	skipped, err := d.manager.ImportState(&imported)
+	if err != nil {
+		return errorResponse(err.Error())
+	}
+	return successResponse(map[string]interface{}{
+		"status":   "imported",
+		"projects": len(imported.Registry) - len(skipped),
+		"skipped":  skipped,
+	})
+}
+
 func (d *Daemon) handleFocus(req Request) Response {
 	mode := req.Mode
 	switch mode {
@@ -84,6 +84,55 @@ func TestHandleRequestStopServiceRequiresProjectAndService(t *testing.T) {
 	}
 }
 
+func TestHandleRequestStatsReportsSubscribersAndLogBuffers(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	proj := &config.Project{
+		Name:     "stats",
+		Services: map[string]*config.Service{"api": {Cmd: "echo booted; sleep 5"}},
+	}
+	if err := m.StartProject("stats", proj, t.TempDir(), false); err != nil {
+		t.Fatalf("start project: %v", err)
+	}
+	waitForServiceRunning(t, m, "stats", "api")
+	sub := m.Subscribe("stats", "")
+	defer m.Unsubscribe(sub.ID)
+
+	d := &Daemon{manager: m, startedAt: time.Now().Add(-90 * time.Second)}
+	var stats DaemonStats
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		resp := d.HandleRequest(Request{Action: "stats"})
+		if !resp.OK {
+			t.Fatalf("stats response error: %s", resp.Error)
+		}
+		if err := json.Unmarshal(resp.Data, &stats); err != nil {
+			t.Fatalf("decode stats: %v", err)
+		}
+		if stats.LogBuffers["stats"].Lines > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if stats.Subscribers != 1 || stats.Goroutines == 0 || stats.PID != os.Getpid() || stats.Uptime != "1m30s" {
+		t.Fatalf("stats = %+v, want one subscriber, goroutines, this pid, and 1m30s uptime", stats)
+	}
+	if len(stats.RunningProjects) != 1 || stats.RunningProjects[0] != "stats" {
+		t.Fatalf("running projects = %v, want [stats]", stats.RunningProjects)
+	}
+	if buf := stats.LogBuffers["stats"]; buf.Services != 1 || buf.Lines == 0 || buf.Capacity < buf.Lines {
+		t.Fatalf("log buffers = %+v, want api's buffered output", buf)
+	}
+}
+
 func TestHandleRequestPingReturnsProtocol(t *testing.T) {
 	startedAt := time.Date(2026, time.July, 11, 12, 0, 0, 0, time.UTC)
 	d := &Daemon{version: "v0.2.1", commit: "abc1234", startedAt: startedAt}
@@ -282,6 +331,123 @@ services:
 	}
 }
 
+func TestHandleReadyReportsSelectedServices(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	root := t.TempDir()
+	writeDaemonGlobalConfig(t, home, root)
+	projectDir := writeDaemonProjectRaw(t, root, "ready-check", `name: ready-check
+services:
+  web:
+    cmd: sleep 5
+  worker:
+    cmd: sleep 5
+`)
+
+	st, err := state.Load()
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	st.Register("ready-check", projectDir)
+	if err := st.Save(); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	d := &Daemon{manager: m}
+	if resp := d.HandleRequest(Request{Action: "start_service", Project: "ready-check", Service: "web", Mode: "parallel"}); !resp.OK {
+		t.Fatalf("start_service response error: %s", resp.Error)
+	}
+
+	readyReport := func(only ...string) ReadyReport {
+		t.Helper()
+		resp := d.HandleRequest(Request{Action: "ready", Project: "ready-check", Only: only})
+		if !resp.OK {
+			t.Fatalf("ready response error: %s", resp.Error)
+		}
+		var report ReadyReport
+		if err := json.Unmarshal(resp.Data, &report); err != nil {
+			t.Fatalf("decode ready report: %v", err)
+		}
+		return report
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for !readyReport("web").Ready {
+		if time.Now().After(deadline) {
+			t.Fatalf("web never reported ready: %+v", readyReport("web"))
+		}
+		time.Sleep(25 * time.Millisecond)
+	}
+
+	all := readyReport()
+	if all.Ready {
+		t.Fatalf("expected project not ready while worker is stopped: %+v", all)
+	}
+	if w := all.Services["worker"]; w.Running || w.Ready || w.Status != "stopped" {
+		t.Fatalf("worker detail = %+v", w)
+	}
+	if !all.Services["web"].Ready {
+		t.Fatalf("web detail = %+v", all.Services["web"])
+	}
+}
+
+func TestHandleReadyResolvesTagsFromRunningConfigWhenFileIsUnreadable(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	root := t.TempDir()
+	writeDaemonGlobalConfig(t, home, root)
+	projectDir := writeDaemonProjectRaw(t, root, "ready-tags", `name: ready-tags
+services:
+  web:
+    cmd: sleep 5
+    tags: [front]
+  worker:
+    cmd: sleep 5
+`)
+
+	st, err := state.Load()
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	st.Register("ready-tags", projectDir)
+	if err := st.Save(); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	d := &Daemon{manager: m}
+	if resp := d.HandleRequest(Request{Action: "start", Project: "ready-tags", Mode: "parallel"}); !resp.OK {
+		t.Fatalf("start response error: %s", resp.Error)
+	}
+	waitForServiceRunning(t, m, "ready-tags", "web")
+	if err := os.WriteFile(filepath.Join(projectDir, ".hun.yml"), []byte("services: [\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	resp := d.HandleRequest(Request{Action: "ready", Project: "ready-tags", Only: []string{"tag:front"}})
+	if !resp.OK {
+		t.Fatalf("ready response error: %s", resp.Error)
+	}
+	var report ReadyReport
+	if err := json.Unmarshal(resp.Data, &report); err != nil {
+		t.Fatalf("decode ready report: %v", err)
+	}
+	if _, ok := report.Services["web"]; !ok || len(report.Services) != 1 {
+		t.Fatalf("tag:front selected %+v, want only web", report.Services)
+	}
+}
+
 func TestHandleStartAlreadyRunningParallelUpdatesMode(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
@@ -843,3 +1009,107 @@ func waitForServiceStopped(t *testing.T, m *Manager, project, service string) {
 	}
 	t.Fatalf("service %s/%s did not stop", project, service)
 }
+
+func TestHandleSetLogsConfigMergesIntoProjectConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	root := t.TempDir()
+	writeDaemonGlobalConfig(t, home, root)
+	projectDir := writeDaemonProjectRaw(t, root, "logs-cfg", `name: logs-cfg
+logs:
+  max_files: 2
+services:
+  web:
+    cmd: sleep 30
+`)
+
+	st, err := state.Load()
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	st.Register("logs-cfg", projectDir)
+	if err := st.Save(); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+	d := &Daemon{manager: m}
+
+	bad := d.HandleRequest(Request{Action: "set_logs_config", Project: "logs-cfg", Logs: &config.LogsConfig{Retention: "soon"}})
+	if bad.OK {
+		t.Fatal("expected invalid retention to be rejected")
+	}
+
+	resp := d.HandleRequest(Request{Action: "set_logs_config", Project: "logs-cfg", Logs: &config.LogsConfig{MaxSize: "50MB", Retention: "14d"}})
+	if !resp.OK {
+		t.Fatalf("set_logs_config response error: %s", resp.Error)
+	}
+	updated, err := config.LoadProject(projectDir)
+	if err != nil {
+		t.Fatalf("load updated project: %v", err)
+	}
+	want := config.LogsConfig{MaxSize: "50MB", MaxFiles: 2, Retention: "14d"}
+	if updated.Logs != want {
+		t.Fatalf("logs = %+v, want %+v", updated.Logs, want)
+	}
+}
+
+func TestImportStateRefusesWhileRunningAndResetsRuntimeFields(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	projectDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(projectDir, ".hun.yml"), []byte("name: shop\nservices:\n  web:\n    cmd: sleep 5\n"), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+	d := &Daemon{manager: m}
+
+	imported, _ := json.Marshal(map[string]interface{}{
+		"mode":     "multitask",
+		"registry": map[string]string{"shop": projectDir, "gone": filepath.Join(projectDir, "missing")},
+		"projects": map[string]interface{}{
+			"shop": map[string]interface{}{"status": "running", "last_note": "wip", "services": map[string]interface{}{"web": map[string]interface{}{"pid": 42}}},
+		},
+	})
+
+	running := &config.Project{Name: "other", Services: map[string]*config.Service{"a": {Cmd: "sleep 5"}}}
+	if err := m.StartProject("other", running, t.TempDir(), false); err != nil {
+		t.Fatalf("start project: %v", err)
+	}
+	if resp := d.HandleRequest(Request{Action: "import_state", State: imported}); resp.OK || !strings.Contains(resp.Error, "other") {
+		t.Fatalf("import while running should be refused naming the project, got %+v", resp)
+	}
+	if err := m.StopProject("other"); err != nil {
+		t.Fatalf("stop project: %v", err)
+	}
+
+	resp := d.HandleRequest(Request{Action: "import_state", State: imported})
+	if !resp.OK {
+		t.Fatalf("import_state error: %s", resp.Error)
+	}
+	st := m.StateSnapshot()
+	if st.Mode != "multitask" || st.Registry["shop"] != projectDir {
+		t.Fatalf("imported state not adopted: %+v", st)
+	}
+	if _, ok := st.Registry["gone"]; ok {
+		t.Fatalf("registry entries without a project config should be skipped")
+	}
+	if ps := st.Projects["shop"]; ps.Status != "stopped" || len(ps.Services) != 0 || ps.LastNote != "wip" {
+		t.Fatalf("runtime fields should reset and notes survive, got %+v", ps)
+	}
+
+	exported := d.HandleRequest(Request{Action: "export_state"})
+	var roundTrip state.State
+	if err := json.Unmarshal(exported.Data, &roundTrip); err != nil || roundTrip.Registry["shop"] != projectDir {
+		t.Fatalf("export_state should return the imported registry, got %s (%v)", exported.Data, err)
+	}
+}
@@ -1,8 +1,8 @@
 package daemon
 
 import (
-	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/sourabhrathourr/hun/internal/config"
+	"github.com/sourabhrathourr/hun/internal/state"
 )
 
 // Daemon is the background process managing all services.
@@ -25,16 +26,22 @@ type Daemon struct {
 	sockPath    string
 	pidPath     string
 	lockPath    string
+	logPath     string
 	lockFile    *os.File
 	version     string
 	commit      string
 	startedAt   time.Time
 	lifecycleMu sync.Mutex
+
+	// recoveryWarning is the state file's recovery note, if loading it
+	// meant backing up a corrupt file; Run writes it to the daemon log.
+	recoveryWarning string
 }
 
 // New creates a new daemon instance.
 func New() (*Daemon, error) {
-	dir, err := config.HunDir()
+	// Fail before touching the socket, state, or log files so the error points at the real cause.
+	dir, err := config.CheckHunDirWritable()
 	if err != nil {
 		return nil, err
 	}
@@ -44,17 +51,32 @@ func New() (*Daemon, error) {
 		return nil, err
 	}
 
+	base := daemonFileBase(dir)
 	return &Daemon{
 		manager:   mgr,
-		sockPath:  filepath.Join(dir, "daemon.sock"),
-		pidPath:   filepath.Join(dir, "daemon.pid"),
-		lockPath:  filepath.Join(dir, "daemon.lock"),
+		sockPath:  filepath.Join(dir, base+".sock"),
+		pidPath:   filepath.Join(dir, base+".pid"),
+		lockPath:  filepath.Join(dir, base+".lock"),
+		logPath:   filepath.Join(dir, base+".log"),
 		version:   buildVersion,
 		commit:    buildCommit,
 		startedAt: time.Now().UTC(),
+
+		recoveryWarning: mgr.st.RecoveryWarning,
 	}, nil
 }
 
+// NewInProcess returns a daemon that serves requests against m without a
+// socket, lock, or pid file, so clients and tests can drive the API directly.
+func NewInProcess(m *Manager) *Daemon {
+	return &Daemon{
+		manager:   m,
+		version:   buildVersion,
+		commit:    buildCommit,
+		startedAt: time.Now().UTC(),
+	}
+}
+
 // Run starts the daemon and listens for connections.
 func (d *Daemon) Run() error {
 	if err := d.acquireLock(); err != nil {
@@ -73,13 +95,18 @@ func (d *Daemon) Run() error {
 	d.listener = listener
 
 	// Write the PID file before accepting requests so clients can always restart this process safely.
-	if err := os.WriteFile(d.pidPath, []byte(fmt.Sprintf("%d", os.Getpid())), 0644); err != nil {
+	// It holds the pid alone, which the macOS app parses too; ping reports the owning uid.
+	if err := os.WriteFile(d.pidPath, []byte(fmt.Sprintf("%d\n", os.Getpid())), 0644); err != nil {
 		_ = listener.Close()
 		_ = os.Remove(d.sockPath)
 		d.releaseLock()
 		return fmt.Errorf("writing daemon PID file: %w", err)
 	}
 
+	if d.recoveryWarning != "" {
+		d.logf("state: %s", d.recoveryWarning)
+	}
+
 	// Handle signals for graceful shutdown
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGTERM, syscall.SIGINT)
@@ -91,10 +118,11 @@ func (d *Daemon) Run() error {
 
 	// Recover previously running projects from persisted state in background.
 	// The daemon should start serving socket requests immediately.
+	unclean := d.manager.BeginDaemonSession()
 	go func() {
 		d.lifecycleMu.Lock()
 		defer d.lifecycleMu.Unlock()
-		d.recoverRunningProjects()
+		d.recoverRunningProjects(unclean)
 	}()
 
 	// Accept connections
@@ -167,16 +195,24 @@ func (d *Daemon) prepareSocket() error {
 
 func (d *Daemon) handleConnection(conn net.Conn) {
 	defer conn.Close()
-	scanner := bufio.NewScanner(conn)
-	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	// Requests are decoded as a stream, so no size limit applies to a line
+	// such as an import_state payload.
+	dec := json.NewDecoder(conn)
 
-	for scanner.Scan() {
-		line := scanner.Text()
+	for {
 		var req Request
-		if err := json.Unmarshal([]byte(line), &req); err != nil {
+		if err := dec.Decode(&req); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if !errors.As(err, &syntaxErr) && !errors.As(err, &typeErr) {
+				return // closed or cut off mid-request
+			}
 			resp := errorResponse(fmt.Sprintf("invalid JSON: %v", err))
 			data, _ := json.Marshal(resp)
 			conn.Write(append(data, '\n'))
+			if syntaxErr != nil {
+				return // the decoder can't find the next request after bad syntax
+			}
 			continue
 		}
 
@@ -192,17 +228,24 @@ func (d *Daemon) handleConnection(conn net.Conn) {
 	}
 }
 
+// Subscribe streams log lines for a project and service (empty means all)
+// until cancel is called, which also closes the channel.
+func (d *Daemon) Subscribe(project, service string) (lines <-chan LogLine, id int, cancel func()) {
+	sub := d.manager.Subscribe(project, service)
+	return sub.Ch, sub.ID, func() { d.manager.Unsubscribe(sub.ID) }
+}
+
 func (d *Daemon) handleSubscribe(conn net.Conn, req Request) {
-	sub := d.manager.Subscribe(req.Project, req.Service)
-	defer d.manager.Unsubscribe(sub.ID)
+	lines, id, cancel := d.Subscribe(req.Project, req.Service)
+	defer cancel()
 
 	// Send OK response
-	resp := successResponse(map[string]int{"subscriber_id": sub.ID})
+	resp := successResponse(map[string]int{"subscriber_id": id})
 	data, _ := json.Marshal(resp)
 	conn.Write(append(data, '\n'))
 
 	// Stream log lines
-	for line := range sub.Ch {
+	for line := range lines {
 		data, err := json.Marshal(line)
 		if err != nil {
 			continue
@@ -216,6 +259,7 @@ func (d *Daemon) handleSubscribe(conn net.Conn, req Request) {
 
 func (d *Daemon) shutdown() {
 	d.manager.Shutdown()
+	d.manager.EndDaemonSession()
 	if d.listener != nil {
 		d.listener.Close()
 	}
@@ -240,14 +284,11 @@ func (d *Daemon) saveGitContext(project string) {
 	d.manager.SetGitBranch(project, branch)
 }
 
-func (d *Daemon) recoverRunningProjects() {
+// recoverRunningProjects restarts projects the state file says were running.
+// After an unclean shutdown the recorded processes may have outlived the old
+// daemon, so their leftovers are reaped before anything is restarted.
+func (d *Daemon) recoverRunningProjects(unclean bool) {
 	snapshot := d.manager.StateSnapshot()
-	type projectToRecover struct {
-		name   string
-		path   string
-		offset int
-	}
-
 	var running []projectToRecover
 	for name, ps := range snapshot.Projects {
 		if ps.Status != "running" {
@@ -258,8 +299,15 @@ func (d *Daemon) recoverRunningProjects() {
 			continue
 		}
 		running = append(running, projectToRecover{name: name, path: path, offset: ps.Offset})
+		if unclean {
+			reapOrphanedServices(ps)
+		}
+	}
+	var order map[string]int
+	if g, err := config.LoadGlobal(); err == nil {
+		order = g.RecoveryOrder
 	}
-	sort.Slice(running, func(i, j int) bool { return running[i].offset < running[j].offset })
+	sortRecovery(running, order)
 
 	for idx, item := range running {
 		proj, err := config.LoadProject(item.path)
@@ -271,17 +319,79 @@ func (d *Daemon) recoverRunningProjects() {
 		if err := d.manager.StartProject(item.name, proj, item.path, exclusive); err != nil {
 			continue
 		}
+		if unclean {
+			d.manager.AnnounceToProject(item.name, "hun: restarted after the previous daemon exited uncleanly; check that this service is healthy")
+		}
 	}
 	_ = d.manager.SetFocus(snapshot.ActiveProject, snapshot.Mode)
 }
 
+type projectToRecover struct {
+	name   string
+	path   string
+	offset int
+}
+
+// sortRecovery orders projects for restart: those with a recovery_order entry
+// first, lowest value first, then the rest by port offset as before.
+func sortRecovery(running []projectToRecover, order map[string]int) {
+	sort.SliceStable(running, func(i, j int) bool {
+		oi, iok := order[running[i].name]
+		oj, jok := order[running[j].name]
+		if iok != jok {
+			return iok
+		}
+		if iok && oi != oj {
+			return oi < oj
+		}
+		if running[i].offset != running[j].offset {
+			return running[i].offset < running[j].offset
+		}
+		return running[i].name < running[j].name
+	})
+}
+
+// reapOrphanedServices terminates service process groups recorded by a daemon
+// that died without stopping them. A recorded pid only counts as an orphan when
+// it still leads its own process group and its port is still taken, which
+// keeps a recycled pid from being mistaken for one of ours.
+func reapOrphanedServices(ps state.ProjectState) {
+	for _, svc := range ps.Services {
+		if svc.PID <= 0 || svc.Port <= 0 {
+			continue
+		}
+		if pgid, err := syscall.Getpgid(svc.PID); err != nil || pgid != svc.PID {
+			continue
+		}
+		if ensureTCPPortAvailable(svc.Port) == nil {
+			continue
+		}
+		_ = syscall.Kill(-svc.PID, syscall.SIGTERM)
+		deadline := time.Now().Add(3 * time.Second)
+		for time.Now().Before(deadline) && syscall.Kill(svc.PID, 0) == nil {
+			time.Sleep(50 * time.Millisecond)
+		}
+		_ = syscall.Kill(-svc.PID, syscall.SIGKILL)
+	}
+}
+
 // SocketPath returns the daemon socket path.
 func SocketPath() (string, error) {
 	dir, err := config.HunDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(dir, "daemon.sock"), nil
+	return filepath.Join(dir, daemonFileBase(dir)+".sock"), nil
+}
+
+// UserSocketPath returns the uid-scoped socket path used when another user's
+// daemon already owns the shared socket.
+func UserSocketPath() (string, error) {
+	dir, err := config.HunDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, userDaemonFileBase()+".sock"), nil
 }
 
 // PIDPath returns the daemon pid-file path.
@@ -290,5 +400,46 @@ func PIDPath() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(dir, "daemon.pid"), nil
+	return filepath.Join(dir, daemonFileBase(dir)+".pid"), nil
+}
+
+// daemonFileBase picks the file name stem for the socket, pid, and lock files.
+// A shared hun home keeps the plain "daemon" names for whoever got there first;
+// every other user falls back to a uid-scoped stem so daemons never cross users.
+func daemonFileBase(dir string) string {
+	scoped := userDaemonFileBase()
+	if _, err := os.Lstat(filepath.Join(dir, scoped+".sock")); err == nil {
+		return scoped
+	}
+	if uid, ok := fileOwnerUID(filepath.Join(dir, "daemon.sock")); ok && uid != os.Getuid() {
+		return scoped
+	}
+	return "daemon"
+}
+
+func userDaemonFileBase() string {
+	return fmt.Sprintf("daemon-%d", os.Getuid())
+}
+
+func fileOwnerUID(path string) (int, bool) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return 0, false
+	}
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	return int(st.Uid), true
+}
+
+// logf appends a timestamped line to the daemon log. The daemon runs detached
+// without a stderr, so this is where it reports problems no client asked about.
+func (d *Daemon) logf(format string, args ...any) {
+	f, err := os.OpenFile(d.logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintf(f, "%s %s\n", time.Now().Format(time.RFC3339), fmt.Sprintf(format, args...))
 }
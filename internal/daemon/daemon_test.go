@@ -83,3 +83,68 @@ func shortTestSocketPath(t *testing.T) string {
 	t.Cleanup(func() { _ = os.Remove(path) })
 	return path
 }
+
+func TestDaemonFileBasePrefersExistingUserScopedSocket(t *testing.T) {
+	dir := t.TempDir()
+	if got := daemonFileBase(dir); got != "daemon" {
+		t.Fatalf("base = %q, want daemon for an empty hun dir", got)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "daemon.sock"), nil, 0o600); err != nil {
+		t.Fatalf("write socket: %v", err)
+	}
+	if got := daemonFileBase(dir); got != "daemon" {
+		t.Fatalf("base = %q, want daemon when the shared socket is ours", got)
+	}
+	scoped := userDaemonFileBase()
+	if err := os.WriteFile(filepath.Join(dir, scoped+".sock"), nil, 0o600); err != nil {
+		t.Fatalf("write scoped socket: %v", err)
+	}
+	if got := daemonFileBase(dir); got != scoped {
+		t.Fatalf("base = %q, want %q once a user-scoped socket exists", got, scoped)
+	}
+}
+
+func TestSortRecoveryHonorsConfiguredOrderBeforeOffset(t *testing.T) {
+	running := []projectToRecover{
+		{name: "web", offset: 0},
+		{name: "docs", offset: 1},
+		{name: "api", offset: 2},
+		{name: "infra", offset: 3},
+	}
+	sortRecovery(running, map[string]int{"infra": 1, "api": 2})
+
+	var got []string
+	for _, p := range running {
+		got = append(got, p.name)
+	}
+	if strings.Join(got, ",") != "infra,api,web,docs" {
+		t.Fatalf("recovery order = %v, want infra,api,web,docs", got)
+	}
+}
+
+func TestNewDaemonLogsStateRecovery(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("HUN_HOME", "")
+	hunDir := filepath.Join(home, ".hun")
+	if err := os.MkdirAll(hunDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(hunDir, "state.json"), []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := New()
+	if err != nil {
+		t.Fatalf("new daemon: %v", err)
+	}
+	defer d.manager.Shutdown()
+	if !strings.Contains(d.recoveryWarning, "was corrupt") {
+		t.Fatalf("recoveryWarning = %q, want the state recovery", d.recoveryWarning)
+	}
+	d.logf("state: %s", d.recoveryWarning)
+	data, err := os.ReadFile(filepath.Join(hunDir, "daemon.log"))
+	if err != nil || !strings.Contains(string(data), "state: ") || !strings.Contains(string(data), "was corrupt") {
+		t.Fatalf("daemon.log = %q, %v", data, err)
+	}
+}
@@ -0,0 +1,40 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// ServiceEnvironment describes what this process launches services with: the
+// shell that runs their commands and the PATH they see.
+type ServiceEnvironment struct {
+	Shell      string `json:"shell"`
+	ShellError string `json:"shell_error,omitempty"` // why Shell can't run commands; empty when it can
+	Path       string `json:"path"`
+}
+
+// CurrentServiceEnvironment reports the shell and PATH services would get if
+// started from this process right now.
+func CurrentServiceEnvironment() ServiceEnvironment {
+	shell := serviceShell()
+	env := ServiceEnvironment{
+		Shell: shell,
+		Path:  envValue(buildServiceEnvironment(nil, "", 0), "PATH"),
+	}
+	resolved := shell
+	if !filepath.IsAbs(shell) {
+		if found, err := exec.LookPath(shell); err == nil {
+			resolved = found
+		}
+	}
+	info, err := os.Stat(resolved)
+	switch {
+	case err != nil:
+		env.ShellError = fmt.Sprintf("%s does not exist", shell)
+	case info.IsDir() || info.Mode()&0o111 == 0:
+		env.ShellError = fmt.Sprintf("%s is not executable", shell)
+	}
+	return env
+}
@@ -0,0 +1,99 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/sourabhrathourr/hun/internal/config"
+)
+
+// hookLogService is the log stream a project's hooks write to, next to its
+// services, so `hun logs <project> hooks` shows why one failed.
+const hookLogService = config.HookLogService
+
+// runHook runs one of a project's lifecycle hooks in dir, with the daemon's
+// environment plus hooks.env, HUN_HOOK=1, and HUN_PROJECT. Its output lands in
+// the project's hooks log, each line tagged with the hook's name. A hook still
+// running after hooks.timeout, or once ctx is done, is killed along with its
+// process group.
+func (m *Manager) runHook(ctx context.Context, projectName string, hooks config.Hooks, name, cmd, dir string) error {
+	if strings.TrimSpace(cmd) == "" {
+		return nil
+	}
+	timeout, err := hooks.TimeoutDuration()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	c := exec.CommandContext(ctx, serviceShell(), "-c", cmd)
+	c.Dir = dir
+	c.Env = buildServiceEnvironment(hooks.Env, "", 0)
+	c.Env = setEnv(c.Env, "HUN_HOOK", "1")
+	c.Env = setEnv(c.Env, "HUN_PROJECT", projectName)
+	c.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	c.Cancel = func() error {
+		return syscall.Kill(-c.Process.Pid, syscall.SIGKILL)
+	}
+	c.WaitDelay = time.Second
+
+	stdout := &hookLogWriter{m: m, project: projectName, hook: name}
+	stderr := &hookLogWriter{m: m, project: projectName, hook: name, isErr: true}
+	c.Stdout, c.Stderr = stdout, stderr
+	err = c.Run()
+	stdout.flush()
+	stderr.flush()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s and was killed", timeout)
+	}
+	return err
+}
+
+// hookLogWriter turns one of a hook's output streams into log lines.
+type hookLogWriter struct {
+	m       *Manager
+	project string
+	hook    string
+	isErr   bool
+	partial []byte
+}
+
+func (w *hookLogWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		w.emit(string(w.partial[:i]))
+		w.partial = w.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// flush logs output the hook left without a trailing newline.
+func (w *hookLogWriter) flush() {
+	if len(w.partial) > 0 {
+		w.emit(string(w.partial))
+		w.partial = nil
+	}
+}
+
+func (w *hookLogWriter) emit(text string) {
+	entry := w.m.logs.WriteLog(LogLine{
+		Timestamp: time.Now(),
+		Project:   w.project,
+		Service:   hookLogService,
+		Text:      "[" + w.hook + "] " + strings.TrimSuffix(text, "\r"),
+		IsErr:     w.isErr,
+		IsMeta:    true,
+	})
+	w.m.subscribers.Broadcast(entry)
+}
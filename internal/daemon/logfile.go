@@ -0,0 +1,203 @@
+package daemon
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// storedLogTimeLayout is how log files stamp each line. It carries no zone:
+// lines are written, and read back, in local time.
+const storedLogTimeLayout = "2006-01-02 15:04:05"
+
+// storedLogPrefixLen is the length of "[2006-01-02 15:04:05] [out] ".
+const storedLogPrefixLen = len("[") + len(storedLogTimeLayout) + len("] [out] ")
+
+// ParseStoredLogLine splits one line of a service log file back into a
+// LogLine. It reports false when raw doesn't start with the daemon's
+// "[time] [stream] " prefix. Only the prefix is consumed, so text that itself
+// contains bracketed timestamps or stream tags is returned untouched.
+func ParseStoredLogLine(raw string) (LogLine, bool) {
+	if len(raw) < storedLogPrefixLen-1 || raw[0] != '[' {
+		return LogLine{}, false
+	}
+	stamp := raw[1 : 1+len(storedLogTimeLayout)]
+	rest := raw[1+len(storedLogTimeLayout):]
+	var isErr bool
+	switch {
+	case strings.HasPrefix(rest, "] [out]"):
+	case strings.HasPrefix(rest, "] [err]"):
+		isErr = true
+	default:
+		return LogLine{}, false
+	}
+	rest = rest[len("] [out]"):]
+	if rest != "" && rest[0] != ' ' {
+		return LogLine{}, false
+	}
+	ts, err := time.ParseInLocation(storedLogTimeLayout, stamp, time.Local)
+	if err != nil {
+		return LogLine{}, false
+	}
+	return LogLine{Timestamp: ts, IsErr: isErr, Text: strings.TrimPrefix(rest, " ")}, true
+}
+
+// ParseStoredLogs reads a service log file written by the daemon. A line
+// without the "[time] [stream] " prefix continues the entry before it, which
+// is how text that contained newlines ends up on disk; lines before the first
+// prefixed one (a file cut mid-entry) become an entry with a zero timestamp.
+func ParseStoredLogs(r io.Reader, project, service string) ([]LogLine, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var lines []LogLine
+	for scanner.Scan() {
+		raw := strings.TrimSuffix(scanner.Text(), "\r")
+		line, ok := ParseStoredLogLine(raw)
+		if !ok {
+			if n := len(lines); n > 0 {
+				lines[n-1].Text += "\n" + raw
+				continue
+			}
+			line = LogLine{Text: raw}
+		}
+		line.Project = project
+		line.Service = service
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// ReadStoredLogs returns a service's on-disk log history, oldest first,
+// across rotated backups and the current file, restricted to [since, until].
+// Files only keep whole seconds, so since is rounded down to match.
+func ReadStoredLogs(project, service string, since, until time.Time) ([]LogLine, error) {
+	path, err := LogFilePath(project, service)
+	if err != nil {
+		return nil, err
+	}
+	files := append(rotatedLogFiles(path, service), path)
+
+	var lines []LogLine
+	for _, file := range files {
+		f, err := os.Open(file)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := ParseStoredLogs(f, project, service)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, parsed...)
+	}
+
+	if since.IsZero() && until.IsZero() {
+		return lines, nil
+	}
+	since = since.Truncate(time.Second)
+	kept := lines[:0]
+	for _, line := range lines {
+		if !since.IsZero() && line.Timestamp.Before(since) {
+			continue
+		}
+		if !until.IsZero() && line.Timestamp.After(until) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return kept, nil
+}
+
+// StoredLogServices lists the services of project that have a log file on
+// disk, including ones that are no longer running or configured.
+func StoredLogServices(project string) ([]string, error) {
+	dir, err := ProjectLogDir(project)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var services []string
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".log")
+		if e.IsDir() || !ok || rotatedLogSuffix.MatchString(e.Name()) {
+			continue
+		}
+		services = append(services, name)
+	}
+	sort.Strings(services)
+	return services, nil
+}
+
+// MergeStoredLogs combines one service's on-disk history with its buffered
+// lines, both oldest first. The buffer is authoritative from the second its
+// first line was stamped in; disk lines from that same second are kept only
+// when the buffer doesn't also hold them, since the file has no finer time.
+func MergeStoredLogs(stored, buffered []LogLine) []LogLine {
+	if len(buffered) == 0 {
+		return stored
+	}
+	type lineKey struct {
+		text  string
+		isErr bool
+	}
+	start := buffered[0].Timestamp.Truncate(time.Second)
+	inBuffer := make(map[lineKey]int)
+	for _, line := range buffered {
+		if !line.Timestamp.Truncate(time.Second).Equal(start) {
+			break
+		}
+		inBuffer[lineKey{line.Text, line.IsErr}]++
+	}
+
+	merged := make([]LogLine, 0, len(stored)+len(buffered))
+	for _, line := range stored {
+		switch {
+		case line.Timestamp.Before(start):
+			merged = append(merged, line)
+		case line.Timestamp.Equal(start):
+			key := lineKey{line.Text, line.IsErr}
+			if inBuffer[key] > 0 {
+				inBuffer[key]--
+				continue
+			}
+			merged = append(merged, line)
+		}
+	}
+	return append(merged, buffered...)
+}
+
+// rotatedLogSuffix matches the timestamp the log rotator appends to backups.
+var rotatedLogSuffix = regexp.MustCompile(`-\d{4}-\d{2}-\d{2}T\d{2}-\d{2}-\d{2}\.\d{3}\.log$`)
+
+// rotatedLogFiles lists the backups the log rotator left next to path,
+// named <service>-<2006-01-02T15-04-05.000>.log, oldest first. The timestamp
+// check keeps api-worker.log from being read as a backup of api.log.
+func rotatedLogFiles(path, service string) []string {
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, e := range entries {
+		name := e.Name()
+		if !e.IsDir() && rotatedLogSuffix.MatchString(name) && strings.TrimSuffix(name, rotatedLogSuffix.FindString(name)) == service {
+			files = append(files, filepath.Join(filepath.Dir(path), e.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files
+}
@@ -0,0 +1,77 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseStoredLogsRestoresStreamAndContinuations(t *testing.T) {
+	raw := "partial tail of a rotated entry\n" +
+		"[2024-03-01 10:00:00] [out] [INFO] [2023-01-01 00:00:00] [err] quoted\n" +
+		"[2024-03-01 10:00:01] [err] panic: boom\n" +
+		"goroutine 1 [running]:\n" +
+		"[2024-03-01 10:00:02] [out] \n"
+
+	lines, err := ParseStoredLogs(strings.NewReader(raw), "shop", "api")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 entries, got %d: %+v", len(lines), lines)
+	}
+	if !lines[0].Timestamp.IsZero() || lines[0].Text != "partial tail of a rotated entry" {
+		t.Fatalf("leading fragment = %+v", lines[0])
+	}
+	if lines[1].IsErr || lines[1].Text != "[INFO] [2023-01-01 00:00:00] [err] quoted" {
+		t.Fatalf("bracketed text should survive untouched, got %+v", lines[1])
+	}
+	want := time.Date(2024, 3, 1, 10, 0, 1, 0, time.Local)
+	if !lines[2].IsErr || !lines[2].Timestamp.Equal(want) || lines[2].Text != "panic: boom\ngoroutine 1 [running]:" {
+		t.Fatalf("stderr entry with continuation = %+v", lines[2])
+	}
+	if lines[3].Text != "" || lines[3].Project != "shop" || lines[3].Service != "api" {
+		t.Fatalf("empty entry = %+v", lines[3])
+	}
+}
+
+func TestReadStoredLogsSpansBackupsAndHonorsWindow(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	path, err := LogFilePath("shop", "api")
+	if err != nil {
+		t.Fatalf("LogFilePath: %v", err)
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(name, data string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("api-2024-03-01T09-00-00.000.log", "[2024-03-01 08:59:00] [out] oldest\n")
+	write("api-worker.log", "[2024-03-01 09:30:00] [out] sibling service\n")
+	write("api.log", "[2024-03-01 09:30:00] [out] middle\n[2024-03-01 10:00:00] [out] newest\n")
+
+	all, err := ReadStoredLogs("shop", "api", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if len(all) != 3 || all[0].Text != "oldest" || all[2].Text != "newest" {
+		t.Fatalf("expected backup then current file, got %+v", all)
+	}
+
+	since := time.Date(2024, 3, 1, 9, 30, 0, 500*int(time.Millisecond), time.Local)
+	until := time.Date(2024, 3, 1, 9, 45, 0, 0, time.Local)
+	window, err := ReadStoredLogs("shop", "api", since, until)
+	if err != nil {
+		t.Fatalf("read window: %v", err)
+	}
+	if len(window) != 1 || window[0].Text != "middle" {
+		t.Fatalf("window should keep the line stamped in since's second, got %+v", window)
+	}
+}
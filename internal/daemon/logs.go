@@ -22,6 +22,88 @@ type LogLine struct {
 	Project   string    `json:"project"`
 	Text      string    `json:"text"`
 	IsErr     bool      `json:"is_err"`
+	Seq       uint64    `json:"seq,omitempty"`     // daemon-wide write order; 0 for lines from older daemons
+	IsMeta    bool      `json:"is_meta,omitempty"` // written by hun itself, not the service
+}
+
+// LogMatch is a parsed log filter spec. A spec is one or more case-insensitive
+// substrings separated by "," (any must match) or "&" (all must match); a
+// leading "!" inverts the result. A spec without separators is a plain
+// substring match.
+type LogMatch struct {
+	Patterns []string
+	All      bool
+	Invert   bool
+}
+
+// ParseLogMatch parses a filter spec. An empty spec matches everything.
+func ParseLogMatch(spec string) LogMatch {
+	var m LogMatch
+	if rest, ok := strings.CutPrefix(spec, "!"); ok {
+		m.Invert = true
+		spec = rest
+	}
+	sep := ","
+	if strings.Contains(spec, "&") {
+		sep = "&"
+		m.All = true
+	}
+	parts := strings.Split(spec, sep)
+	for _, part := range parts {
+		if len(parts) > 1 {
+			part = strings.TrimSpace(part)
+		}
+		if part != "" {
+			m.Patterns = append(m.Patterns, strings.ToLower(part))
+		}
+	}
+	return m
+}
+
+// Matches reports whether text satisfies the spec.
+func (m LogMatch) Matches(text string) bool {
+	if len(m.Patterns) == 0 {
+		return true
+	}
+	lower := strings.ToLower(text)
+	hit := m.All
+	for _, p := range m.Patterns {
+		found := strings.Contains(lower, p)
+		if m.All && !found {
+			hit = false
+			break
+		}
+		if !m.All && found {
+			hit = true
+			break
+		}
+	}
+	return hit != m.Invert
+}
+
+// MatchLines keeps the lines whose text satisfies every spec.
+func MatchLines(lines []LogLine, specs []string) []LogLine {
+	if len(specs) == 0 {
+		return lines
+	}
+	matchers := make([]LogMatch, 0, len(specs))
+	for _, spec := range specs {
+		matchers = append(matchers, ParseLogMatch(spec))
+	}
+	out := make([]LogLine, 0, len(lines))
+	for _, line := range lines {
+		keep := true
+		for _, m := range matchers {
+			if !m.Matches(line.Text) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			out = append(out, line)
+		}
+	}
+	return out
 }
 
 // RingBuffer is a circular buffer for log lines.
@@ -69,6 +151,45 @@ func (rb *RingBuffer) Lines(n int) []LogLine {
 	return result
 }
 
+// Resize changes the buffer capacity, keeping the newest lines that fit.
+func (rb *RingBuffer) Resize(size int) {
+	if size <= 0 {
+		return
+	}
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	if size == rb.size {
+		return
+	}
+	n := rb.count
+	if n > size {
+		n = size
+	}
+	lines := make([]LogLine, size)
+	start := (rb.head - n + rb.size) % rb.size
+	for i := 0; i < n; i++ {
+		lines[i] = rb.lines[(start+i)%rb.size]
+	}
+	rb.lines = lines
+	rb.size = size
+	rb.count = n
+	rb.head = n % size
+}
+
+// Len returns how many lines the buffer holds.
+func (rb *RingBuffer) Len() int {
+	rb.mu.RLock()
+	defer rb.mu.RUnlock()
+	return rb.count
+}
+
+// Cap returns the buffer capacity.
+func (rb *RingBuffer) Cap() int {
+	rb.mu.RLock()
+	defer rb.mu.RUnlock()
+	return rb.size
+}
+
 // Reset clears all buffered lines.
 func (rb *RingBuffer) Reset() {
 	rb.mu.Lock()
@@ -122,6 +243,9 @@ func (w *serviceLogWriter) close() {
 	<-w.done
 }
 
+// logBufferLines is the in-memory scrollback kept per service.
+const logBufferLines = 10000
+
 // LogManager handles log buffering and disk writing for all services.
 type LogManager struct {
 	buffers    map[string]*RingBuffer // "project:service" → buffer
@@ -129,6 +253,35 @@ type LogManager struct {
 	projectCfg map[string]rotationConfig
 	mu         sync.RWMutex
 	logDir     string
+
+	idleLines int    // buffer size for projects other than focused; 0 disables shrinking
+	focused   string // project that keeps the full buffer
+
+	seq atomic.Uint64 // last Seq handed out by WriteLog
+}
+
+// LogFilePath returns where the daemon writes a service's log file,
+// ~/.hun/logs/<project>/<service>.log. Rotated files sit next to it.
+func LogFilePath(project, service string) (string, error) {
+	dir, err := config.HunDir()
+	if err != nil {
+		return "", err
+	}
+	return serviceLogFile(filepath.Join(dir, "logs"), project, service), nil
+}
+
+// ProjectLogDir returns the directory holding a project's service log
+// files, ~/.hun/logs/<project>.
+func ProjectLogDir(project string) (string, error) {
+	dir, err := config.HunDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "logs", project), nil
+}
+
+func serviceLogFile(logDir, project, service string) string {
+	return filepath.Join(logDir, project, service+".log")
 }
 
 // NewLogManager creates a new log manager.
@@ -139,7 +292,7 @@ func NewLogManager() (*LogManager, error) {
 	}
 	logDir := filepath.Join(dir, "logs")
 	if err := os.MkdirAll(logDir, 0o755); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("creating log directory %s: %w (set HUN_HOME to a writable directory)", logDir, err)
 	}
 	return &LogManager{
 		buffers:    make(map[string]*RingBuffer),
@@ -149,6 +302,22 @@ func NewLogManager() (*LogManager, error) {
 	}, nil
 }
 
+// BufferStats totals buffered lines and capacity per project.
+func (lm *LogManager) BufferStats() map[string]LogBufferStats {
+	lm.mu.RLock()
+	defer lm.mu.RUnlock()
+	stats := make(map[string]LogBufferStats)
+	for key, rb := range lm.buffers {
+		project, _, _ := strings.Cut(key, ":")
+		s := stats[project]
+		s.Services++
+		s.Lines += rb.Len()
+		s.Capacity += rb.Cap()
+		stats[project] = s
+	}
+	return stats
+}
+
 func (lm *LogManager) bufferKey(project, service string) string {
 	return project + ":" + service
 }
@@ -183,16 +352,59 @@ func (lm *LogManager) GetBuffer(project, service string) *RingBuffer {
 	if rb, ok := lm.buffers[key]; ok {
 		return rb
 	}
-	rb := NewRingBuffer(10000)
+	rb := NewRingBuffer(lm.bufferSizeLocked(project))
 	lm.buffers[key] = rb
 	return rb
 }
 
-// WriteLog writes a log line to both ring buffer and asynchronous disk writer.
-func (lm *LogManager) WriteLog(line LogLine) {
+// SetIdleBufferLines sets how many lines services of unfocused projects keep
+// in memory. Older lines stay in the on-disk log files. Zero keeps full buffers.
+func (lm *LogManager) SetIdleBufferLines(n int) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	if n < 0 || n >= logBufferLines {
+		n = 0
+	}
+	lm.idleLines = n
+	lm.resizeBuffersLocked()
+}
+
+// SetFocusedProject grows the focused project's buffers back to full size and
+// shrinks every other project's buffers when idle shrinking is enabled.
+func (lm *LogManager) SetFocusedProject(project string) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	if lm.focused == project {
+		return
+	}
+	lm.focused = project
+	lm.resizeBuffersLocked()
+}
+
+func (lm *LogManager) bufferSizeLocked(project string) int {
+	if lm.idleLines > 0 && project != lm.focused {
+		return lm.idleLines
+	}
+	return logBufferLines
+}
+
+func (lm *LogManager) resizeBuffersLocked() {
+	for key, rb := range lm.buffers {
+		project, _, _ := strings.Cut(key, ":")
+		rb.Resize(lm.bufferSizeLocked(project))
+	}
+}
+
+// WriteLog stamps a log line with the next sequence number and writes it to
+// both ring buffer and asynchronous disk writer. It returns the stamped line.
+func (lm *LogManager) WriteLog(line LogLine) LogLine {
+	if line.Seq == 0 {
+		line.Seq = lm.seq.Add(1)
+	}
 	rb := lm.GetBuffer(line.Project, line.Service)
 	rb.Write(line)
 	lm.writeAsync(line)
+	return line
 }
 
 func (lm *LogManager) writeAsync(line LogLine) {
@@ -218,12 +430,11 @@ func (lm *LogManager) getOrCreateWriter(project, service string) *serviceLogWrit
 	logDir := lm.logDir
 	lm.mu.RUnlock()
 
-	projDir := filepath.Join(logDir, project)
-	if err := os.MkdirAll(projDir, 0o755); err != nil {
+	path := serviceLogFile(logDir, project, service)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return nil
 	}
 
-	path := filepath.Join(projDir, service+".log")
 	rotator := &lumberjack.Logger{
 		Filename:   path,
 		MaxSize:    cfg.maxSizeMB,
@@ -239,7 +450,7 @@ func (lm *LogManager) getOrCreateWriter(project, service string) *serviceLogWrit
 	go func() {
 		defer close(writer.done)
 		for line := range writer.ch {
-			ts := line.Timestamp.Format("2006-01-02 15:04:05")
+			ts := line.Timestamp.Format(storedLogTimeLayout)
 			stream := "out"
 			if line.IsErr {
 				stream = "err"
@@ -291,13 +502,38 @@ func (lm *LogManager) GetLines(project, service string, n int) []LogLine {
 	return rb.Lines(n)
 }
 
+// GetLinesBetween is GetLines restricted to lines stamped within [since, until].
+// The window is applied before the n-line limit so the newest matching lines win.
+func (lm *LogManager) GetLinesBetween(project, service string, n int, since, until time.Time) []LogLine {
+	if since.IsZero() && until.IsZero() {
+		return lm.GetLines(project, service, n)
+	}
+	all := lm.GetLines(project, service, 0)
+	lines := make([]LogLine, 0, len(all))
+	for _, line := range all {
+		if !since.IsZero() && line.Timestamp.Before(since) {
+			continue
+		}
+		if !until.IsZero() && line.Timestamp.After(until) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if n > 0 && len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+// getProjectLines merges every service's lines for project, or for all
+// projects when project is empty.
 func (lm *LogManager) getProjectLines(project string, n int) []LogLine {
 	prefix := project + ":"
 
 	lm.mu.RLock()
 	buffers := make([]*RingBuffer, 0)
 	for key, rb := range lm.buffers {
-		if strings.HasPrefix(key, prefix) {
+		if project == "" || strings.HasPrefix(key, prefix) {
 			buffers = append(buffers, rb)
 		}
 	}
@@ -1,6 +1,9 @@
 package daemon
 
 import (
+	"fmt"
+	"os"
+	"strings"
 	"testing"
 	"time"
 )
@@ -37,3 +40,143 @@ func TestLogManagerResetServiceKeepsSiblingBuffers(t *testing.T) {
 		t.Fatalf("expected service b logs untouched, got %d lines", len(got))
 	}
 }
+
+func TestLogManagerGetLinesBetweenFiltersWindowBeforeLimit(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	lm, err := NewLogManager()
+	if err != nil {
+		t.Fatalf("new log manager: %v", err)
+	}
+	defer lm.Close()
+
+	base := time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC)
+	for i := 0; i < 6; i++ {
+		lm.WriteLog(LogLine{
+			Project:   "proj",
+			Service:   "svc",
+			Text:      "line",
+			Timestamp: base.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	got := lm.GetLinesBetween("proj", "svc", 2, base.Add(time.Minute), base.Add(3*time.Minute))
+	if len(got) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(got))
+	}
+	if !got[0].Timestamp.Equal(base.Add(2*time.Minute)) || !got[1].Timestamp.Equal(base.Add(3*time.Minute)) {
+		t.Fatalf("expected newest lines inside window, got %v and %v", got[0].Timestamp, got[1].Timestamp)
+	}
+
+	if got := lm.GetLinesBetween("proj", "", 0, base.Add(4*time.Minute), time.Time{}); len(got) != 2 {
+		t.Fatalf("expected open-ended since to return 2 project lines, got %d", len(got))
+	}
+}
+
+func TestLogMatchSpecs(t *testing.T) {
+	tests := []struct {
+		spec string
+		text string
+		want bool
+	}{
+		{spec: "Error", text: "fatal error: boom", want: true},
+		{spec: "error ", text: "error: boom", want: false},
+		{spec: "!healthz", text: "GET /healthz 200", want: false},
+		{spec: "!healthz", text: "GET /users 200", want: true},
+		{spec: "healthz, metrics", text: "GET /metrics 200", want: true},
+		{spec: "!healthz,metrics", text: "GET /metrics 200", want: false},
+		{spec: "GET&500", text: "GET /users 500", want: true},
+		{spec: "GET&500", text: "GET /users 200", want: false},
+		{spec: "", text: "anything", want: true},
+	}
+	for _, tc := range tests {
+		if got := ParseLogMatch(tc.spec).Matches(tc.text); got != tc.want {
+			t.Fatalf("ParseLogMatch(%q).Matches(%q) = %v, want %v", tc.spec, tc.text, got, tc.want)
+		}
+	}
+
+	lines := []LogLine{{Text: "GET /healthz 200"}, {Text: "GET /users 500"}, {Text: "POST /users 201"}}
+	if got := MatchLines(lines, []string{"users", "!post"}); len(got) != 1 || got[0].Text != "GET /users 500" {
+		t.Fatalf("MatchLines = %+v, want only the GET /users line", got)
+	}
+}
+
+func TestLogManagerShrinksIdleProjectBuffersAndRegrowsOnFocus(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	lm, err := NewLogManager()
+	if err != nil {
+		t.Fatalf("new log manager: %v", err)
+	}
+	defer lm.Close()
+
+	lm.SetFocusedProject("front")
+	for i := 0; i < 50; i++ {
+		for _, project := range []string{"front", "back"} {
+			lm.WriteLog(LogLine{Project: project, Service: "api", Text: fmt.Sprintf("line-%d", i), Timestamp: time.Now()})
+		}
+	}
+
+	lm.SetIdleBufferLines(10)
+	if got := lm.GetLines("front", "api", 0); len(got) != 50 {
+		t.Fatalf("focused project kept %d lines, want 50", len(got))
+	}
+	back := lm.GetLines("back", "api", 0)
+	if len(back) != 10 || back[0].Text != "line-40" || back[9].Text != "line-49" {
+		t.Fatalf("idle project buffer = %d lines starting %q, want newest 10", len(back), back[0].Text)
+	}
+
+	lm.SetFocusedProject("back")
+	if got := lm.GetBuffer("back", "api").Cap(); got != logBufferLines {
+		t.Fatalf("refocused buffer cap = %d, want %d", got, logBufferLines)
+	}
+	if got := lm.GetLines("front", "api", 0); len(got) != 10 {
+		t.Fatalf("newly idle project kept %d lines, want 10", len(got))
+	}
+	lm.WriteLog(LogLine{Project: "back", Service: "api", Text: "after-focus", Timestamp: time.Now()})
+	if got := lm.GetLines("back", "api", 0); len(got) != 11 || got[10].Text != "after-focus" {
+		t.Fatalf("refocused buffer = %d lines, want 11 ending with after-focus", len(got))
+	}
+}
+
+func TestLogFilePathMatchesWhereLogsAreWritten(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	lm, err := NewLogManager()
+	if err != nil {
+		t.Fatalf("new log manager: %v", err)
+	}
+	lm.WriteLog(LogLine{Project: "shop", Service: "api", Text: "hello", Timestamp: time.Now()})
+	lm.Close()
+
+	path, err := LogFilePath("shop", "api")
+	if err != nil {
+		t.Fatalf("LogFilePath: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), "hello") {
+		t.Fatalf("expected the log line in %s, got %q (%v)", path, data, err)
+	}
+}
+
+func TestLogManagerStampsSequenceAcrossServices(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	lm, err := NewLogManager()
+	if err != nil {
+		t.Fatalf("new log manager: %v", err)
+	}
+	defer lm.Close()
+
+	first := lm.WriteLog(LogLine{Project: "proj", Service: "a", Text: "one", Timestamp: time.Now()})
+	second := lm.WriteLog(LogLine{Project: "other", Service: "b", Text: "two", Timestamp: time.Now()})
+	if first.Seq == 0 || second.Seq <= first.Seq {
+		t.Fatalf("seq should increase across projects and services: %d then %d", first.Seq, second.Seq)
+	}
+	if got := lm.GetLines("other", "b", 1); len(got) != 1 || got[0].Seq != second.Seq {
+		t.Fatalf("buffered line should keep its seq, got %+v", got)
+	}
+}
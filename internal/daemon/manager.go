@@ -1,16 +1,17 @@
 package daemon
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sourabhrathourr/hun/internal/config"
@@ -29,6 +30,10 @@ type Manager struct {
 	discoveryScanDirs []string
 	discoveryWarnings []string
 	iconCache         map[string]projectIconCacheEntry
+	postStart         map[string]context.CancelFunc // cancels each project's background post_start hook
+	resources         resourceSampler
+
+	statusRev atomic.Uint64 // bumped by statusChanged whenever what Status reports changes
 
 	mu      sync.RWMutex
 	stateMu sync.Mutex
@@ -55,7 +60,11 @@ func NewManager() (*Manager, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Manager{
+	if g, err := config.LoadGlobal(); err == nil {
+		logMgr.SetIdleBufferLines(g.Logs.IdleBufferLines)
+	}
+	logMgr.SetFocusedProject(st.ActiveProject)
+	m := &Manager{
 		processes:   make(map[string]map[string]*Process),
 		projectCfgs: make(map[string]*config.Project),
 		logs:        logMgr,
@@ -64,7 +73,11 @@ func NewManager() (*Manager, error) {
 		portSignals: make(map[string]runtimePortSignal),
 		iconCache:   make(map[string]projectIconCacheEntry),
 		st:          st,
-	}, nil
+	}
+	// Start from the clock so a revision from a previous daemon never
+	// matches this one's.
+	m.statusRev.Store(uint64(time.Now().UnixNano()))
+	return m, nil
 }
 
 // RefreshRegistry merges on-disk registry changes into in-memory state.
@@ -88,6 +101,14 @@ func (m *Manager) ProjectPath(name string) (string, bool) {
 	return path, ok
 }
 
+// RunningProjectConfig returns the config project was started with, or nil
+// when it isn't running.
+func (m *Manager) RunningProjectConfig(project string) *config.Project {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.projectCfgs[project]
+}
+
 func (m *Manager) RegisterProjectPath(path string) (*config.Project, string, error) {
 	abs, err := filepath.Abs(strings.TrimSpace(path))
 	if err != nil {
@@ -153,13 +174,77 @@ func (m *Manager) StateSnapshot() *state.State {
 			services[sn] = sv
 		}
 		v.Services = services
+		v.Pinned = append([]string(nil), v.Pinned...)
 		clone.Projects[k] = v
 	}
 	return clone
 }
 
+// ImportState replaces the registry and per-project state with imported. It
+// refuses while any project is running, since live processes would no longer
+// match the state describing them. Registry entries whose directory has no
+// .hun.yml on this machine are dropped and returned as skipped.
+func (m *Manager) ImportState(imported *state.State) (skipped []string, err error) {
+	if err := imported.Validate(); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	running := make([]string, 0, len(m.processes))
+	for name := range m.processes {
+		running = append(running, name)
+	}
+	m.mu.RUnlock()
+	if len(running) > 0 {
+		sort.Strings(running)
+		return nil, fmt.Errorf("stop running projects before importing state: %s", strings.Join(running, ", "))
+	}
+
+	registry := make(map[string]string, len(imported.Registry))
+	for name, path := range imported.Registry {
+		if !config.ProjectExists(path) {
+			skipped = append(skipped, name)
+			continue
+		}
+		registry[name] = filepath.Clean(path)
+	}
+	projects := make(map[string]state.ProjectState, len(imported.Projects))
+	for name, ps := range imported.Projects {
+		if _, ok := registry[name]; !ok {
+			continue
+		}
+		// Nothing is running after an import, whatever the source machine had.
+		ps.Status = "stopped"
+		ps.Services = nil
+		ps.StartedAt = ""
+		projects[name] = ps
+	}
+	sort.Strings(skipped)
+
+	err = m.mutateState(func(st *state.State) {
+		st.Mode = imported.Mode
+		st.ActiveProject = ""
+		st.Registry = registry
+		st.Projects = projects
+		m.lastDiscoveryScan = time.Time{}
+	})
+	return skipped, err
+}
+
 // StartProject starts all services for a project.
 func (m *Manager) StartProject(projectName string, projConfig *config.Project, projectPath string, exclusive bool) error {
+	return m.startProject(projectName, projConfig, projectPath, exclusive, false)
+}
+
+// StartProjectSafe is a debugging start: services launch one at a time with
+// auto-restart disabled, each must become ready (or give up waiting) before
+// the next starts, and the sequence halts at the first crash. Services that
+// already started keep running so the crash can be inspected; the returned
+// error carries the failing service's last lines.
+func (m *Manager) StartProjectSafe(projectName string, projConfig *config.Project, projectPath string, exclusive bool) error {
+	return m.startProject(projectName, projConfig, projectPath, exclusive, true)
+}
+
+func (m *Manager) startProject(projectName string, projConfig *config.Project, projectPath string, exclusive, safe bool) error {
 	m.mu.Lock()
 	if _, exists := m.processes[projectName]; exists {
 		m.mu.Unlock()
@@ -169,13 +254,15 @@ func (m *Manager) StartProject(projectName string, projConfig *config.Project, p
 	m.processes[projectName] = make(map[string]*Process)
 	m.projectCfgs[projectName] = projConfig
 	m.mu.Unlock()
+	m.statusChanged()
 
 	if projConfig.Hooks.PreStart != "" {
-		if err := runHook(projConfig.Hooks.PreStart, projectPath); err != nil {
+		if err := m.runHook(context.Background(), projectName, projConfig.Hooks, "pre_start", projConfig.Hooks.PreStart, projectPath); err != nil {
 			m.mu.Lock()
 			delete(m.processes, projectName)
 			delete(m.projectCfgs, projectName)
 			m.mu.Unlock()
+			m.statusChanged()
 			return fmt.Errorf("pre_start hook failed: %w", err)
 		}
 	}
@@ -190,6 +277,7 @@ func (m *Manager) StartProject(projectName string, projConfig *config.Project, p
 		delete(m.processes, projectName)
 		delete(m.projectCfgs, projectName)
 		m.mu.Unlock()
+		m.statusChanged()
 		return err
 	}
 
@@ -201,9 +289,7 @@ func (m *Manager) StartProject(projectName string, projConfig *config.Project, p
 		}
 	}
 	rollback := func(startErr error) error {
-		for _, proc := range started {
-			_ = proc.Stop()
-		}
+		_ = stopProcesses(started)
 		m.ports.ReleaseOffset(projectName)
 		m.logs.CleanProject(projectName)
 		m.clearRuntimePortSignals(projectName)
@@ -211,23 +297,166 @@ func (m *Manager) StartProject(projectName string, projConfig *config.Project, p
 		delete(m.processes, projectName)
 		delete(m.projectCfgs, projectName)
 		m.mu.Unlock()
+		m.statusChanged()
 		m.setProjectStopped(projectName)
 		return startErr
 	}
 
 	for _, svcName := range order {
 		svcConfig := projConfig.Services[svcName]
-		proc, err := m.startConfiguredService(projectName, svcName, svcConfig, projectPath, !exclusive, 0, dependentCount[svcName] > 0)
+		if !svcConfig.Enabled() {
+			continue // reported as "disabled" by Status
+		}
+		if safe {
+			noRestart := *svcConfig
+			noRestart.Restart = ""
+			svcConfig = &noRestart
+		}
+		proc, err := m.startConfiguredService(projectName, svcName, svcConfig, projectPath, !exclusive, 0, !safe && dependentCount[svcName] > 0)
 		if err != nil {
 			return rollback(fmt.Errorf("starting service %s: %w", svcName, err))
 		}
 		started[svcName] = proc
+		if safe && !waitReadyOrExit(proc, safeStartReadyWait) && proc.ExitCode() != 0 {
+			m.setProjectRunning(projectName, projectPath, m.refreshProjectOffset(projectName), exclusive)
+			return m.safeStartCrash(projectName, svcName, proc)
+		}
 	}
 
 	m.setProjectRunning(projectName, projectPath, m.refreshProjectOffset(projectName), exclusive)
+	if projConfig.Hooks.PostStart != "" && len(started) > 0 {
+		go m.runPostStartHook(m.postStartContext(projectName), projectName, projConfig.Hooks, projectPath, started)
+	}
 	return nil
 }
 
+// postStartReadyWait bounds how long the post_start hook waits for a
+// project's services to become ready before running anyway.
+const postStartReadyWait = 30 * time.Second
+
+// runPostStartHook runs the post_start hook once every started service is
+// ready. It runs in the background so start returns as soon as the services
+// are launched. The project is already up, so a failure (or a service
+// crashing first) is only reported, on the hooks log. Stopping the project
+// cancels ctx, which ends the wait or kills a hook already running.
+func (m *Manager) runPostStartHook(ctx context.Context, projectName string, hooks config.Hooks, projectPath string, started map[string]*Process) {
+	deadline := time.Now().Add(postStartReadyWait)
+	for svcName, proc := range started {
+		ready := waitReadyOrExit(proc, time.Until(deadline))
+		if ctx.Err() != nil {
+			return
+		}
+		if !ready && proc.ExitCode() != 0 {
+			m.emitInternalServiceLine(projectName, hookLogService, fmt.Sprintf("[hun] post_start hook skipped: %s exited before it was ready", svcName), true)
+			return
+		}
+	}
+	if !m.IsRunning(projectName) {
+		return
+	}
+	if err := m.runHook(ctx, projectName, hooks, "post_start", hooks.PostStart, projectPath); err != nil && ctx.Err() == nil {
+		m.emitInternalServiceLine(projectName, hookLogService, fmt.Sprintf("[hun] post_start hook failed: %v", err), true)
+	}
+}
+
+// postStartContext returns a context for project's post_start hook that
+// stopping the project cancels, replacing any earlier one.
+func (m *Manager) postStartContext(project string) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.postStart == nil {
+		m.postStart = make(map[string]context.CancelFunc)
+	}
+	if prev := m.postStart[project]; prev != nil {
+		prev()
+	}
+	m.postStart[project] = cancel
+	return ctx
+}
+
+// cancelPostStart stops project's pending or running post_start hook.
+func (m *Manager) cancelPostStart(project string) {
+	m.mu.Lock()
+	cancel := m.postStart[project]
+	delete(m.postStart, project)
+	m.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// secretCommand returns the secret_command for a project's ${secret:NAME}
+// env values: the project's own, else the global one.
+func (m *Manager) secretCommand(projectName string) string {
+	m.mu.RLock()
+	proj := m.projectCfgs[projectName]
+	m.mu.RUnlock()
+	if proj != nil && proj.SecretCommand != "" {
+		return proj.SecretCommand
+	}
+	if g, err := config.LoadGlobal(); err == nil {
+		return g.SecretCommand
+	}
+	return ""
+}
+
+// safeStartReadyWait bounds how long a safe start waits on one service before
+// moving on while it is still running but not yet ready.
+const safeStartReadyWait = 30 * time.Second
+
+// safeStartTailLines is how much of a crashed service's output a safe start
+// reports.
+const safeStartTailLines = 20
+
+// safeStartTailWait bounds how long a safe start waits for a crashed
+// service's last output to reach its log buffer.
+const safeStartTailWait = time.Second
+
+// waitReadyOrExit blocks until proc is ready, exits, or timeout passes. It
+// returns false only when the process exited first.
+func waitReadyOrExit(proc *Process, timeout time.Duration) bool {
+	exited := proc.Done()
+	deadline := time.After(timeout)
+	tick := time.NewTicker(50 * time.Millisecond)
+	defer tick.Stop()
+	for {
+		if proc.IsReady() {
+			return true
+		}
+		select {
+		case <-exited:
+			return false
+		case <-deadline:
+			return true
+		case <-tick.C:
+		}
+	}
+}
+
+func (m *Manager) safeStartCrash(projectName, serviceName string, proc *Process) error {
+	var b strings.Builder
+	code := proc.ExitCode()
+	if code < 0 {
+		fmt.Fprintf(&b, "safe start halted: %s was killed by a signal", serviceName)
+	} else {
+		fmt.Fprintf(&b, "safe start halted: %s exited with code %d", serviceName, code)
+	}
+	// The output scanners may still be draining the pipes right after exit.
+	var tail []LogLine
+	for deadline := time.Now().Add(safeStartTailWait); ; {
+		tail = m.logs.GetLines(projectName, serviceName, safeStartTailLines)
+		if len(tail) > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	for _, line := range tail {
+		b.WriteString("\n  " + line.Text)
+	}
+	return errors.New(b.String())
+}
+
 // StartService starts one service and any services it depends on.
 func (m *Manager) StartService(projectName, serviceName string, projConfig *config.Project, projectPath string, exclusive bool) error {
 	return m.startService(projectName, serviceName, projConfig, projectPath, exclusive, nil)
@@ -251,13 +480,15 @@ func (m *Manager) startService(projectName, serviceName string, projConfig *conf
 	}
 	m.projectCfgs[projectName] = projConfig
 	m.mu.Unlock()
+	m.statusChanged()
 
 	if newProject && projConfig.Hooks.PreStart != "" {
-		if err := runHook(projConfig.Hooks.PreStart, projectPath); err != nil {
+		if err := m.runHook(context.Background(), projectName, projConfig.Hooks, "pre_start", projConfig.Hooks.PreStart, projectPath); err != nil {
 			m.mu.Lock()
 			delete(m.processes, projectName)
 			delete(m.projectCfgs, projectName)
 			m.mu.Unlock()
+			m.statusChanged()
 			return fmt.Errorf("pre_start hook failed: %w", err)
 		}
 	}
@@ -266,9 +497,7 @@ func (m *Manager) startService(projectName, serviceName string, projConfig *conf
 	m.ports.EnsureProject(projectName)
 	started := make(map[string]*Process)
 	rollback := func(startErr error) error {
-		for _, proc := range started {
-			_ = proc.Stop()
-		}
+		_ = stopProcesses(started)
 		m.mu.Lock()
 		if newProject {
 			delete(m.processes, projectName)
@@ -279,6 +508,7 @@ func (m *Manager) startService(projectName, serviceName string, projConfig *conf
 			}
 		}
 		m.mu.Unlock()
+		m.statusChanged()
 		if newProject {
 			m.ports.ReleaseOffset(projectName)
 			m.setProjectStopped(projectName)
@@ -304,10 +534,112 @@ func (m *Manager) startService(projectName, serviceName string, projConfig *conf
 	return nil
 }
 
+var hunVarRe = regexp.MustCompile(`\$(\{HUN_[A-Z_]+\}|HUN_[A-Z_]+)`)
+
+// hunVars returns the variables hun expands in a service's cmd and cwd.
+func hunVars(project, service string, port, offset int) map[string]string {
+	vars := map[string]string{
+		"HUN_PROJECT": project,
+		"HUN_SERVICE": service,
+		"HUN_OFFSET":  strconv.Itoa(offset),
+		"HUN_PORT":    "",
+	}
+	if port > 0 {
+		vars["HUN_PORT"] = strconv.Itoa(port)
+	}
+	return vars
+}
+
+// expandHunVars substitutes $HUN_* and ${HUN_*} references known to vars.
+// Every other reference, including unknown HUN_ names, is left untouched for
+// the shell to expand.
+func expandHunVars(s string, vars map[string]string) string {
+	if !strings.Contains(s, "HUN_") {
+		return s
+	}
+	return hunVarRe.ReplaceAllStringFunc(s, func(ref string) string {
+		return os.Expand(ref, func(name string) string {
+			if v, ok := vars[name]; ok {
+				return v
+			}
+			return ref
+		})
+	})
+}
+
+// ExpandHunNames substitutes $HUN_PROJECT and $HUN_SERVICE in a service's
+// cmd or cwd, the references known before it starts. It reports whether s
+// also uses $HUN_PORT or $HUN_OFFSET, which depend on the port picked at start.
+func ExpandHunNames(s, project, service string) (expanded string, needsPort bool) {
+	vars := hunVars(project, service, 0, 0)
+	delete(vars, "HUN_PORT")
+	delete(vars, "HUN_OFFSET")
+	expanded = expandHunVars(s, vars)
+	for _, ref := range hunVarRe.FindAllString(expanded, -1) {
+		switch strings.Trim(ref, "${}") {
+		case "HUN_PORT", "HUN_OFFSET":
+			needsPort = true
+		}
+	}
+	return expanded, needsPort
+}
+
+// restartBackoffResetAfter is how long a service must stay up before its
+// next crash restarts from the initial delay again.
+const restartBackoffResetAfter = time.Minute
+
+// restartBackoff tracks the growing delay between on_failure restarts of one
+// service. Without a restart_backoff config it keeps the historical fixed 1s.
+type restartBackoff struct {
+	initial    time.Duration
+	max        time.Duration
+	multiplier float64
+	delay      time.Duration
+	attempts   int // restarts since the last run that outlived restartBackoffResetAfter
+}
+
+func newRestartBackoff(cfg *config.RestartBackoff) *restartBackoff {
+	if cfg == nil {
+		return &restartBackoff{initial: time.Second, max: time.Second, multiplier: 1}
+	}
+	initial, max, multiplier, err := cfg.Curve()
+	if err != nil {
+		initial, max, multiplier, _ = (*config.RestartBackoff)(nil).Curve()
+	}
+	return &restartBackoff{initial: initial, max: max, multiplier: multiplier}
+}
+
+// exhausted reports whether max restarts (0 means unlimited) already happened
+// in a row. A run that lasted uptime past the reset window starts a new count.
+func (b *restartBackoff) exhausted(uptime time.Duration, max int) bool {
+	return max > 0 && uptime < restartBackoffResetAfter && b.attempts >= max
+}
+
+// next returns the delay before the upcoming restart given how long the
+// crashed run lasted, and advances the curve.
+func (b *restartBackoff) next(uptime time.Duration) time.Duration {
+	if b.delay == 0 || uptime >= restartBackoffResetAfter {
+		b.delay = b.initial
+		b.attempts = 0
+	}
+	b.attempts++
+	delay := b.delay
+	grown := time.Duration(float64(b.delay) * b.multiplier)
+	if grown > b.max {
+		grown = b.max
+	}
+	b.delay = grown
+	return delay
+}
+
 func (m *Manager) startConfiguredService(projectName, serviceName string, svcConfig *config.Service, projectPath string, allowPortFallback bool, preferredPort int, waitForReady bool) (*Process, error) {
+	env, err := resolveServiceEnv(svcConfig.Env, m.secretCommand(projectName), projectPath)
+	if err != nil {
+		return nil, err
+	}
+
 	var actualPort int
 	var lease *portLease
-	var err error
 	if preferredPort > 0 {
 		actualPort, lease, err = m.ports.ReserveExactPort(preferredPort)
 	} else {
@@ -320,25 +652,48 @@ func (m *Manager) startConfiguredService(projectName, serviceName string, svcCon
 		m.ports.RecordOffset(projectName, actualPort-svcConfig.Port)
 	}
 
-	dir := projectPath
-	if svcConfig.Cwd != "" {
-		dir = filepath.Join(projectPath, svcConfig.Cwd)
+	// The cwd may reference the selected port, so it is only resolved now.
+	// Start expands both again whenever a restart launches on another port.
+	launchVars := func(port int) map[string]string {
+		return hunVars(projectName, serviceName, port, m.ports.GetOffset(projectName))
+	}
+	vars := launchVars(actualPort)
+	cmd := expandHunVars(svcConfig.Cmd, vars)
+	rawDir := svcConfig.WorkDir(projectPath)
+	dir := expandHunVars(rawDir, vars)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		lease.release()
+		return nil, fmt.Errorf("cwd %s for %s is not a directory", dir, serviceName)
 	}
 
 	restartPolicy := svcConfig.Restart
+	restartMax := svcConfig.RestartMax
+	backoff := newRestartBackoff(svcConfig.RestartBackoff)
 	proc := &Process{
 		Name:             serviceName,
-		Cmd:              svcConfig.Cmd,
+		Cmd:              cmd,
 		Dir:              dir,
-		Env:              svcConfig.Env,
+		rawCmd:           svcConfig.Cmd,
+		rawDir:           rawDir,
+		hunVars:          launchVars,
+		Env:              env,
 		PortEnv:          svcConfig.PortEnv,
 		ReadyPattern:     svcConfig.Ready,
+		ReadyCmd:         svcConfig.ReadyCmd,
+		ReadyTCP:         svcConfig.ReadyTCP,
 		basePort:         svcConfig.Port,
 		observedPort:     actualPort,
 		launchPort:       actualPort,
 		allowRuntimePort: allowPortFallback,
+		onChange:         m.statusChanged,
 	}
+	proc.ReadyInterval, proc.ReadyTimeout, _ = svcConfig.ReadyCmdTiming()
+	proc.StopTimeout, _ = svcConfig.StopTimeoutDuration()
 	proc.SetPortLease(lease)
+	if proc.ReadyHTTP, err = svcConfig.ReadyHTTPURL(actualPort); err != nil {
+		proc.ReleasePortLease()
+		return nil, err
+	}
 
 	// Every start boundary represents a fresh in-memory log session.
 	m.logs.ResetService(projectName, serviceName)
@@ -351,12 +706,12 @@ func (m *Manager) startConfiguredService(projectName, serviceName string, svcCon
 			Text:      line,
 			IsErr:     isErr,
 		}
-		m.logs.WriteLog(logLine)
+		logLine = m.logs.WriteLog(logLine)
 		m.subscribers.Broadcast(logLine)
 		m.observeRuntimePort(projectName, serviceName, line)
 	}
 
-	if err := ensureDockerReadyForCommand(svcConfig.Cmd, func(line string) {
+	if err := ensureDockerReadyForCommand(cmd, func(line string) {
 		emitServiceLine(line, false)
 	}); err != nil {
 		return nil, err
@@ -367,12 +722,29 @@ func (m *Manager) startConfiguredService(projectName, serviceName string, svcCon
 	proc.onExit = func(err error, intentional bool) {
 		status := "stopped"
 		restarted := false
-		if !intentional && err != nil {
+		crashed := !intentional && err != nil
+		autoRestart := !intentional && (restartPolicy == "always" || crashed && restartPolicy == "on_failure")
+		uptime := time.Since(proc.StartedAt())
+		givingUp := autoRestart && backoff.exhausted(uptime, restartMax)
+		if givingUp {
+			autoRestart = false
+		}
+		if crashed {
 			status = "crashed"
 		}
+		if crashed || autoRestart {
+			proc.noteRestart()
+		}
 		m.updateServiceState(projectName, serviceName, 0, proc.ObservedPort(), status)
-		if status == "crashed" && restartPolicy == "on_failure" {
-			time.Sleep(time.Second)
+		if givingUp {
+			m.emitInternalServiceLine(projectName, serviceName, fmt.Sprintf("[hun] giving up after %d restarts", restartMax), true)
+		}
+		if autoRestart {
+			time.Sleep(backoff.next(uptime))
+			// A stop during the backoff, or the project going away, cancels it.
+			autoRestart = !proc.stopRequested() && m.isCurrentProcess(projectName, serviceName, proc)
+		}
+		if autoRestart {
 			m.logs.ResetService(projectName, serviceName)
 			launchPort := proc.ResetObservedPort()
 			if portErr := ensureTCPPortAvailable(launchPort); portErr != nil {
@@ -391,6 +763,9 @@ func (m *Manager) startConfiguredService(projectName, serviceName string, svcCon
 
 	readyCh := make(chan struct{}, 1)
 	proc.onReady = func() {
+		if via := proc.ReadyVia(); via != "" {
+			m.emitInternalServiceLine(projectName, serviceName, "[hun] ready via "+via, false)
+		}
 		select {
 		case readyCh <- struct{}{}:
 		default:
@@ -402,6 +777,7 @@ func (m *Manager) startConfiguredService(projectName, serviceName string, svcCon
 	m.mu.Lock()
 	m.processes[projectName][serviceName] = proc
 	m.mu.Unlock()
+	m.statusChanged()
 
 	if err := proc.Start(); err != nil {
 		proc.ReleasePortLease()
@@ -410,12 +786,13 @@ func (m *Manager) startConfiguredService(projectName, serviceName string, svcCon
 			delete(m.processes[projectName], serviceName)
 		}
 		m.mu.Unlock()
+		m.statusChanged()
 		return nil, err
 	}
 	m.updateServiceState(projectName, serviceName, proc.PID(), actualPort, "running")
 	go m.monitorRuntimePort(projectName, serviceName, proc, proc.PID(), proc.StartedAt())
 
-	if waitForReady && svcConfig.Ready != "" {
+	if waitForReady && (svcConfig.Ready != "" || svcConfig.ReadyHTTP != "" || svcConfig.ReadyTCP || svcConfig.ReadyCmd != "") {
 		select {
 		case <-readyCh:
 		case <-time.After(30 * time.Second):
@@ -424,24 +801,20 @@ func (m *Manager) startConfiguredService(projectName, serviceName string, svcCon
 	return proc, nil
 }
 
-// StopProject stops all services for a project.
-func (m *Manager) StopProject(projectName string) error {
+// isCurrentProcess reports whether proc is still the registered process for
+// the service, i.e. its project hasn't been stopped or the service replaced.
+func (m *Manager) isCurrentProcess(projectName, serviceName string, proc *Process) bool {
 	m.mu.RLock()
-	procs, exists := m.processes[projectName]
-	projCfg := m.projectCfgs[projectName]
-	m.mu.RUnlock()
-	if !exists {
-		return fmt.Errorf("project %s not running", projectName)
-	}
-
-	procList := make([]*Process, 0, len(procs))
-	for _, proc := range procs {
-		procList = append(procList, proc)
-	}
+	defer m.mu.RUnlock()
+	return m.processes[projectName][serviceName] == proc
+}
 
+// stopProcesses stops every process at once, so each gets its own stop
+// timeout rather than waiting behind the others, and returns the first error.
+func stopProcesses(procs map[string]*Process) error {
 	var wg sync.WaitGroup
-	errCh := make(chan error, len(procList))
-	for _, proc := range procList {
+	errCh := make(chan error, len(procs))
+	for _, proc := range procs {
 		wg.Add(1)
 		go func(p *Process) {
 			defer wg.Done()
@@ -452,15 +825,32 @@ func (m *Manager) StopProject(projectName string) error {
 	}
 	wg.Wait()
 	close(errCh)
+	return <-errCh
+}
 
-	var stopErr error
-	for err := range errCh {
-		if stopErr == nil {
-			stopErr = err
+// StopProject stops all services for a project.
+func (m *Manager) StopProject(projectName string) error {
+	m.mu.RLock()
+	procs, exists := m.processes[projectName]
+	projCfg := m.projectCfgs[projectName]
+	m.mu.RUnlock()
+	if !exists {
+		return fmt.Errorf("project %s not running", projectName)
+	}
+	m.cancelPostStart(projectName)
+
+	if projCfg != nil && projCfg.Hooks.PreStop != "" {
+		if path, ok := m.ProjectPath(projectName); ok && path != "" {
+			// The stop goes ahead regardless; the failure is kept in the
+			// hooks log, which outlives the project's buffers on disk.
+			if err := m.runHook(context.Background(), projectName, projCfg.Hooks, "pre_stop", projCfg.Hooks.PreStop, path); err != nil {
+				m.emitInternalServiceLine(projectName, hookLogService, fmt.Sprintf("[hun] pre_stop hook failed: %v", err), true)
+			}
 		}
 	}
-	if stopErr != nil {
-		return stopErr
+
+	if err := stopProcesses(procs); err != nil {
+		return err
 	}
 
 	path := ""
@@ -468,7 +858,7 @@ func (m *Manager) StopProject(projectName string) error {
 		path = p
 	}
 	if projCfg != nil && projCfg.Hooks.PostStop != "" && path != "" {
-		_ = runHook(projCfg.Hooks.PostStop, path)
+		_ = m.runHook(context.Background(), projectName, projCfg.Hooks, "post_stop", projCfg.Hooks.PostStop, path)
 	}
 
 	m.ports.ReleaseOffset(projectName)
@@ -518,8 +908,55 @@ func (m *Manager) StopService(projectName, serviceName string) error {
 	return nil
 }
 
-// StopAll stops all running projects.
+// PauseService freezes a running service without stopping it.
+func (m *Manager) PauseService(projectName, serviceName string) error {
+	proc, err := m.runningProcess(projectName, serviceName)
+	if err != nil {
+		return err
+	}
+	if err := proc.Pause(); err != nil {
+		return err
+	}
+	m.updateServiceState(projectName, serviceName, proc.PID(), proc.ObservedPort(), "paused")
+	return nil
+}
+
+// ResumeService continues a service paused by PauseService.
+func (m *Manager) ResumeService(projectName, serviceName string) error {
+	proc, err := m.runningProcess(projectName, serviceName)
+	if err != nil {
+		return err
+	}
+	if err := proc.Resume(); err != nil {
+		return err
+	}
+	m.updateServiceState(projectName, serviceName, proc.PID(), proc.ObservedPort(), "running")
+	return nil
+}
+
+func (m *Manager) runningProcess(projectName, serviceName string) (*Process, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	procs, exists := m.processes[projectName]
+	if !exists {
+		return nil, fmt.Errorf("project %s not running", projectName)
+	}
+	proc, exists := procs[serviceName]
+	if !exists {
+		return nil, fmt.Errorf("service %s not found in project %s", serviceName, projectName)
+	}
+	return proc, nil
+}
+
+// StopAll stops all running projects concurrently.
 func (m *Manager) StopAll() error {
+	return m.StopAllWithin(0)
+}
+
+// StopAllWithin stops all running projects concurrently. With a positive
+// timeout, process groups still alive at the deadline are SIGKILLed so a
+// hurried shutdown doesn't leave orphans behind.
+func (m *Manager) StopAllWithin(timeout time.Duration) error {
 	m.mu.RLock()
 	names := make([]string, 0, len(m.processes))
 	for name := range m.processes {
@@ -527,10 +964,46 @@ func (m *Manager) StopAll() error {
 	}
 	m.mu.RUnlock()
 
+	done := make(chan struct{})
+	var wg sync.WaitGroup
 	for _, name := range names {
-		_ = m.StopProject(name)
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			_ = m.StopProject(name)
+		}(name)
 	}
-	return nil
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	if timeout <= 0 {
+		<-done
+		return nil
+	}
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+	}
+
+	m.mu.RLock()
+	var stuck []string
+	for project, procs := range m.processes {
+		for name, proc := range procs {
+			if proc.IsRunning() {
+				proc.Kill()
+				stuck = append(stuck, project+":"+name)
+			}
+		}
+	}
+	m.mu.RUnlock()
+	sort.Strings(stuck)
+	if len(stuck) == 0 {
+		return nil
+	}
+	return fmt.Errorf("killed services that did not stop within %s: %s", timeout, strings.Join(stuck, ", "))
 }
 
 // RestartService restarts a single service within a project.
@@ -551,6 +1024,7 @@ func (m *Manager) RestartService(projectName, serviceName string) error {
 	if err := proc.Stop(); err != nil {
 		return err
 	}
+	proc.noteRestart()
 	m.clearRuntimePortSignal(projectName, serviceName)
 	m.logs.ResetService(projectName, serviceName)
 	launchPort, lease, err := m.ports.ReserveAvailablePort(proc.BasePort(), proc.AllowsRuntimePort())
@@ -570,8 +1044,75 @@ func (m *Manager) RestartService(projectName, serviceName string) error {
 	return nil
 }
 
-// Status returns current status of all running projects and services.
+// Status returns current status of all running projects and services,
+// without resource usage. It is cheap enough for internal callers.
 func (m *Manager) Status() map[string]map[string]ServiceInfo {
+	return m.statusSnapshot()
+}
+
+// StatusWithUsage is Status with CPU% and memory filled in, for clients
+// that display them.
+func (m *Manager) StatusWithUsage() map[string]map[string]ServiceInfo {
+	result := m.statusSnapshot()
+	m.addResourceUsage(result)
+	return result
+}
+
+// StatusDelta answers status_since. Unchanged means the client's revision is
+// current and Status is omitted.
+type StatusDelta struct {
+	Revision  uint64                            `json:"revision"`
+	Unchanged bool                              `json:"unchanged,omitempty"`
+	Status    map[string]map[string]ServiceInfo `json:"status,omitempty"`
+}
+
+// StatusSince returns the full status only when it changed since revision,
+// so an idle poll costs one counter read. Resource usage doesn't count as a
+// change, since it moves on every sample; it is filled in whenever a full
+// status is sent.
+func (m *Manager) StatusSince(revision uint64) StatusDelta {
+	// Read the revision before the snapshot: a change racing with it bumps
+	// the counter again, so the next poll picks it up.
+	rev := m.statusRev.Load()
+	if revision == rev {
+		return StatusDelta{Revision: rev, Unchanged: true}
+	}
+	status := m.statusSnapshot()
+	m.addResourceUsage(status)
+	return StatusDelta{Revision: rev, Status: status}
+}
+
+// statusChanged bumps the status revision after anything Status reports
+// changes: the running processes, their state, or what is persisted for
+// them. It only touches an atomic, so it is safe under any lock.
+func (m *Manager) statusChanged() {
+	m.statusRev.Add(1)
+}
+
+// addResourceUsage samples CPU% and memory for the running services in
+// status. It runs outside m.mu since reading every process can be slow.
+func (m *Manager) addResourceUsage(status map[string]map[string]ServiceInfo) {
+	started := make(map[int]time.Time)
+	for _, services := range status {
+		for _, info := range services {
+			if info.Running && info.PID > 0 {
+				started[info.PID] = info.StartedAt
+			}
+		}
+	}
+	usage := m.resources.sample(started)
+	for _, services := range status {
+		for name, info := range services {
+			if u, ok := usage[info.PID]; ok && info.Running {
+				info.CPU = u.cpu
+				info.MemBytes = u.memBytes
+				services[name] = info
+			}
+		}
+	}
+}
+
+func (m *Manager) statusSnapshot() map[string]map[string]ServiceInfo {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	stateStatuses := m.serviceStatusSnapshot()
@@ -589,9 +1130,17 @@ func (m *Manager) Status() map[string]map[string]ServiceInfo {
 					status = "stopped"
 				}
 			}
+			paused := running && proc.IsPaused()
 			if running {
 				status = "running"
 			}
+			if paused {
+				status = "paused"
+			}
+			exitCode := 0
+			if status == "crashed" {
+				exitCode = proc.ExitCode()
+			}
 			result[proj][name] = ServiceInfo{
 				PID:       proc.PID(),
 				Port:      proc.ObservedPort(),
@@ -599,6 +1148,16 @@ func (m *Manager) Status() map[string]map[string]ServiceInfo {
 				Running:   running,
 				Ready:     proc.IsReady(),
 				StartedAt: proc.StartedAt(),
+				Restarts:  proc.Restarts(),
+				Paused:    paused,
+				ExitCode:  exitCode,
+			}
+		}
+		if cfg := m.projectCfgs[proj]; cfg != nil {
+			for name, svc := range cfg.Services {
+				if _, started := procs[name]; !started && !svc.Enabled() {
+					result[proj][name] = ServiceInfo{Status: "disabled"}
+				}
 			}
 		}
 	}
@@ -648,6 +1207,23 @@ func (m *Manager) GetLogs(project, service string, lines int) []LogLine {
 	return m.logs.GetLines(project, service, lines)
 }
 
+// GetLogsBetween returns up to lines buffered log lines stamped within
+// [since, until]. A zero since or until leaves that side of the window open.
+func (m *Manager) GetLogsBetween(project, service string, lines int, since, until time.Time) []LogLine {
+	return m.logs.GetLinesBetween(project, service, lines, since, until)
+}
+
+// ApplyLogsConfig updates log rotation for project. Running projects also get
+// the new settings recorded on their in-memory config.
+func (m *Manager) ApplyLogsConfig(project string, logs config.LogsConfig) {
+	m.logs.SetProjectConfig(project, logs)
+	m.mu.Lock()
+	if cfg := m.projectCfgs[project]; cfg != nil {
+		cfg.Logs = logs
+	}
+	m.mu.Unlock()
+}
+
 // Subscribe creates a new log subscriber.
 func (m *Manager) Subscribe(project, service string) *Subscriber {
 	return m.subscribers.Subscribe(project, service)
@@ -674,6 +1250,44 @@ func (m *Manager) IsServiceRunning(project, service string) bool {
 	return proc != nil && proc.IsRunning()
 }
 
+// SetProjectFavorite marks or unmarks a registered project as a favorite.
+func (m *Manager) SetProjectFavorite(projectName string, favorite bool) error {
+	if _, ok := m.ProjectPath(projectName); !ok {
+		return fmt.Errorf("project %q not in registry", projectName)
+	}
+	return m.mutateState(func(st *state.State) {
+		ps := st.Projects[projectName]
+		ps.Favorite = favorite
+		st.Projects[projectName] = ps
+	})
+}
+
+// SetServicePinned pins or unpins a service of a registered project. Pinned
+// names are kept sorted; the service need not be running or configured.
+func (m *Manager) SetServicePinned(projectName, service string, pinned bool) error {
+	if _, ok := m.ProjectPath(projectName); !ok {
+		return fmt.Errorf("project %q not in registry", projectName)
+	}
+	return m.mutateState(func(st *state.State) {
+		ps := st.Projects[projectName]
+		names := make([]string, 0, len(ps.Pinned)+1)
+		for _, name := range ps.Pinned {
+			if name != service {
+				names = append(names, name)
+			}
+		}
+		if pinned {
+			names = append(names, service)
+			sort.Strings(names)
+		}
+		if len(names) == 0 {
+			names = nil
+		}
+		ps.Pinned = names
+		st.Projects[projectName] = ps
+	})
+}
+
 // ForgetService removes one service from in-memory process and persisted state.
 func (m *Manager) ForgetService(project, service string, projConfig *config.Project) {
 	m.clearRuntimePortSignal(project, service)
@@ -691,6 +1305,7 @@ func (m *Manager) ForgetService(project, service string, projConfig *config.Proj
 		}
 	}
 	m.mu.Unlock()
+	m.statusChanged()
 
 	if releaseOffset {
 		m.ports.ReleaseOffset(project)
@@ -832,10 +1447,45 @@ func (m *Manager) SetGitBranch(project, branch string) {
 
 // Shutdown performs graceful shutdown of all processes.
 func (m *Manager) Shutdown() {
-	_ = m.StopAll()
+	_ = m.StopAllWithin(shutdownDrainTimeout)
 	m.logs.Close()
 }
 
+// shutdownDrainTimeout bounds the whole daemon shutdown, not each service.
+const shutdownDrainTimeout = 8 * time.Second
+
+// AnnounceToProject writes a daemon notice into every running service log of project.
+func (m *Manager) AnnounceToProject(project, text string) {
+	m.mu.RLock()
+	names := make([]string, 0, len(m.processes[project]))
+	for name := range m.processes[project] {
+		names = append(names, name)
+	}
+	m.mu.RUnlock()
+	for _, name := range names {
+		line := LogLine{Timestamp: time.Now(), Project: project, Service: name, Text: text, IsErr: true, IsMeta: true}
+		line = m.logs.WriteLog(line)
+		m.subscribers.Broadcast(line)
+	}
+}
+
+// BeginDaemonSession marks the state as owned by a live daemon and reports
+// whether the previous daemon exited without a clean shutdown.
+func (m *Manager) BeginDaemonSession() (unclean bool) {
+	_ = m.mutateState(func(st *state.State) {
+		unclean = st.DaemonDirty
+		st.DaemonDirty = true
+	})
+	return unclean
+}
+
+// EndDaemonSession records a clean shutdown once every project has stopped.
+func (m *Manager) EndDaemonSession() {
+	_ = m.mutateState(func(st *state.State) {
+		st.DaemonDirty = false
+	})
+}
+
 // ServiceInfo holds info about a running service.
 type ServiceInfo struct {
 	PID       int       `json:"pid"`
@@ -844,6 +1494,11 @@ type ServiceInfo struct {
 	Running   bool      `json:"running"`
 	Ready     bool      `json:"ready"`
 	StartedAt time.Time `json:"started_at,omitempty"`
+	Restarts  int       `json:"restarts,omitempty"`  // crashes + restarts since the project started
+	Paused    bool      `json:"paused,omitempty"`    // held with SIGSTOP; still counts as running
+	ExitCode  int       `json:"exit_code,omitempty"` // last exit status when crashed; -1 if killed by a signal
+	CPU       float64   `json:"cpu,omitempty"`       // CPU% of the service's process group since the last status
+	MemBytes  int64     `json:"mem_bytes,omitempty"` // resident memory of the service's process group
 }
 
 var runtimePortPatterns = []*regexp.Regexp{
@@ -1110,8 +1765,9 @@ func (m *Manager) emitInternalServiceLine(project, service, line string, isErr b
 		Project:   project,
 		Text:      line,
 		IsErr:     isErr,
+		IsMeta:    true,
 	}
-	m.logs.WriteLog(entry)
+	entry = m.logs.WriteLog(entry)
 	m.subscribers.Broadcast(entry)
 }
 
@@ -1210,6 +1866,10 @@ func (m *Manager) mutateState(fn func(st *state.State)) error {
 		m.st = st
 	}
 	fn(m.st)
+	m.statusChanged()
+	if m.logs != nil {
+		m.logs.SetFocusedProject(m.st.ActiveProject)
+	}
 	return m.st.Save()
 }
 
@@ -1286,18 +1946,6 @@ func serviceStartOrder(proj *config.Project, target string) ([]string, error) {
 	return order, nil
 }
 
-func runHook(cmd, dir string) error {
-	if strings.TrimSpace(cmd) == "" {
-		return nil
-	}
-	shell := getenvDefault("SHELL", "/bin/sh")
-	c := exec.Command(shell, "-c", cmd)
-	c.Dir = dir
-	c.Env = buildServiceEnvironment(nil, "", 0)
-	c.Env = setEnv(c.Env, "HUN_HOOK", "1")
-	return c.Run()
-}
-
 func getenvDefault(key, fallback string) string {
 	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
 		return v
@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"syscall"
 	"testing"
@@ -46,6 +47,42 @@ func TestFocusSurvivorPriority(t *testing.T) {
 	}
 }
 
+func TestStartProjectWaitsForReadyCmdDependency(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	projectPath := t.TempDir()
+	proj := &config.Project{
+		Name: "gated",
+		Services: map[string]*config.Service{
+			"db": {
+				Cmd:      "sleep 0.3; touch db.ready; sleep 5",
+				ReadyCmd: "test -f db.ready",
+			},
+			"api": {
+				Cmd:       "test -f db.ready && touch api.saw-db; sleep 5",
+				DependsOn: []string{"db"},
+			},
+		},
+	}
+	if err := m.StartProject("gated", proj, projectPath, false); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	marker := filepath.Join(projectPath, "api.saw-db")
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		if _, err := os.Stat(marker); err == nil {
+			return
+		}
+	}
+	t.Fatal("api started before its ready_cmd-gated dependency was ready")
+}
+
 func TestStartProjectRollsBackOnServiceStartFailure(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
@@ -97,6 +134,339 @@ func TestStartProjectRollsBackOnServiceStartFailure(t *testing.T) {
 	}
 }
 
+func TestStartProjectKeepsRunningWhenPostStartHookFails(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	projectPath := t.TempDir()
+	proj := &config.Project{
+		Name:  "hooked",
+		Hooks: config.Hooks{PostStart: "exit 3"},
+		Services: map[string]*config.Service{
+			"svc1": {Cmd: "sleep 5"},
+		},
+	}
+
+	if err := m.StartProject("hooked", proj, projectPath, false); err != nil {
+		t.Fatalf("start should succeed despite post_start failure: %v", err)
+	}
+	if !m.IsRunning("hooked") {
+		t.Fatal("project should keep running after a failed post_start hook")
+	}
+	warned := false
+	for deadline := time.Now().Add(2 * time.Second); !warned && time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		for _, line := range m.logs.GetLines("hooked", hookLogService, 0) {
+			if strings.Contains(line.Text, "post_start hook failed") && line.IsErr {
+				warned = true
+			}
+		}
+	}
+	if !warned {
+		t.Fatal("failed post_start hook was not logged to the hooks log")
+	}
+}
+
+func TestStartProjectRunsPostStartHookOnceReady(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	projectPath := t.TempDir()
+	proj := &config.Project{
+		Name:  "hooked",
+		Hooks: config.Hooks{PostStart: "test -f ready && touch post-start"},
+		Services: map[string]*config.Service{
+			"svc1": {Cmd: "touch ready; echo up; sleep 5", Ready: "up"},
+		},
+	}
+
+	if err := m.StartProject("hooked", proj, projectPath, false); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	marker := filepath.Join(projectPath, "post-start")
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		if _, err := os.Stat(marker); err == nil {
+			return
+		}
+	}
+	t.Fatal("post_start hook did not run after the service was ready")
+}
+
+func TestStopProjectCancelsPendingPostStartHook(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	projectPath := t.TempDir()
+	proj := &config.Project{
+		Name:  "hooked",
+		Hooks: config.Hooks{PostStart: "touch post-start"},
+		Services: map[string]*config.Service{
+			"svc1": {Cmd: "sleep 5", Ready: "never printed"},
+		},
+	}
+	if err := m.StartProject("hooked", proj, projectPath, false); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	sub := m.Subscribe("hooked", hookLogService)
+	defer m.Unsubscribe(sub.ID)
+	if err := m.StopProject("hooked"); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	time.Sleep(300 * time.Millisecond)
+	if _, err := os.Stat(filepath.Join(projectPath, "post-start")); err == nil {
+		t.Fatal("post_start hook ran after its project was stopped")
+	}
+	for len(sub.Ch) > 0 {
+		if line := <-sub.Ch; strings.Contains(line.Text, "post_start") {
+			t.Fatalf("a stopped project's post_start should end quietly, got %q", line.Text)
+		}
+	}
+	m.mu.RLock()
+	pending := len(m.postStart)
+	m.mu.RUnlock()
+	if pending != 0 {
+		t.Fatalf("stop should drop the project's post_start cancel, %d left", pending)
+	}
+}
+
+func TestStopProjectRunsPreStopHookWhileServicesRun(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	projectPath := t.TempDir()
+	proj := &config.Project{
+		Name:  "hooked",
+		Hooks: config.Hooks{PreStop: "kill -0 $(cat pid) && touch pre-stop"},
+		Services: map[string]*config.Service{
+			"svc1": {Cmd: "echo $$ > pid; exec sleep 5"},
+		},
+	}
+
+	if err := m.StartProject("hooked", proj, projectPath, false); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	m.stateMu.Lock()
+	m.st.Registry["hooked"] = projectPath
+	m.stateMu.Unlock()
+	pidFile := filepath.Join(projectPath, "pid")
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		if data, _ := os.ReadFile(pidFile); len(data) > 0 {
+			break
+		}
+	}
+	if err := m.StopProject("hooked"); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "pre-stop")); err != nil {
+		t.Fatalf("pre_stop hook did not run before the service stopped: %v", err)
+	}
+}
+
+func TestStopProjectLogsFailedPreStopHookAndStillStops(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	projectPath := t.TempDir()
+	proj := &config.Project{
+		Name:  "hooked",
+		Hooks: config.Hooks{PreStop: "exit 4"},
+		Services: map[string]*config.Service{
+			"svc1": {Cmd: "sleep 5"},
+		},
+	}
+	if err := m.StartProject("hooked", proj, projectPath, false); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	m.stateMu.Lock()
+	m.st.Registry["hooked"] = projectPath
+	m.stateMu.Unlock()
+
+	sub := m.Subscribe("hooked", hookLogService)
+	defer m.Unsubscribe(sub.ID)
+	if err := m.StopProject("hooked"); err != nil {
+		t.Fatalf("stop should go ahead despite the pre_stop failure: %v", err)
+	}
+	if m.IsRunning("hooked") {
+		t.Fatal("project should be stopped")
+	}
+	for {
+		select {
+		case line := <-sub.Ch:
+			if strings.Contains(line.Text, "pre_stop hook failed") && line.IsErr {
+				return
+			}
+		default:
+			t.Fatal("failed pre_stop hook was not logged to the hooks log")
+		}
+	}
+}
+
+func TestStartProjectRollsBackWhenPreStartHookTimesOut(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	projectPath := t.TempDir()
+	proj := &config.Project{
+		Name: "hooked",
+		Hooks: config.Hooks{
+			PreStart: "sleep 30 & echo $! > child; wait",
+			Timeout:  "300ms",
+		},
+		Services: map[string]*config.Service{
+			"svc1": {Cmd: "sleep 5"},
+		},
+	}
+
+	start := time.Now()
+	err = m.StartProject("hooked", proj, projectPath, false)
+	if err == nil || !strings.Contains(err.Error(), "timed out after 300ms") {
+		t.Fatalf("start error = %v, want a pre_start timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("hung hook held up the start for %s", elapsed)
+	}
+	if m.IsRunning("hooked") {
+		t.Fatal("project should not be running after its pre_start hook timed out")
+	}
+
+	data, err := os.ReadFile(filepath.Join(projectPath, "child"))
+	if err != nil {
+		t.Fatalf("reading hook child pid: %v", err)
+	}
+	var pid int
+	fmt.Sscan(string(data), &pid)
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		if syscall.Kill(pid, 0) != nil {
+			return
+		}
+	}
+	t.Fatalf("hook child %d survived the timeout", pid)
+}
+
+func TestHookOutputAndEnvironmentReachProjectLogs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	projectPath := t.TempDir()
+	proj := &config.Project{
+		Name: "hooked",
+		Hooks: config.Hooks{
+			PreStart: `echo "$HUN_PROJECT $GREETING"; echo "no database" >&2; exit 1`,
+			Env:      map[string]string{"GREETING": "hello"},
+		},
+		Services: map[string]*config.Service{
+			"svc1": {Cmd: "sleep 5"},
+		},
+	}
+
+	if err := m.StartProject("hooked", proj, projectPath, false); err == nil {
+		t.Fatal("expected the failing pre_start hook to stop the start")
+	}
+	var got []string
+	for _, line := range m.logs.GetLines("hooked", hookLogService, 0) {
+		if !line.IsMeta {
+			t.Fatalf("hook line %q should be marked as hun's own", line.Text)
+		}
+		got = append(got, fmt.Sprintf("%s err=%v", line.Text, line.IsErr))
+	}
+	// stdout and stderr are read concurrently, so their relative order varies.
+	sort.Strings(got)
+	want := []string{"[pre_start] hooked hello err=false", "[pre_start] no database err=true"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("hook log = %q, want %q", got, want)
+	}
+}
+
+func TestStartProjectExpandsHunVarsInCmdAndCwd(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	projectPath := t.TempDir()
+	if err := os.Mkdir(filepath.Join(projectPath, "web"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	proj := &config.Project{
+		Name: "vars",
+		Services: map[string]*config.Service{
+			"web": {
+				Cmd: `echo "${HUN_PROJECT}/$HUN_SERVICE in $(basename "$PWD") ${HOME:+home}"; sleep 5`,
+				Cwd: "./${HUN_SERVICE}",
+			},
+		},
+	}
+	if err := m.StartProject("vars", proj, projectPath, false); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	waitForLogLine(t, m, "vars", "web", "vars/web in web home", 3*time.Second)
+}
+
+func TestExpandHunVarsLeavesOtherReferencesToTheShell(t *testing.T) {
+	vars := hunVars("app", "api", 3001, 1)
+	got := expandHunVars(`serve --port $HUN_PORT --base ${HUN_OFFSET} $HOME ${HUN_OTHER} | awk '{print $1}'`, vars)
+	want := `serve --port 3001 --base 1 $HOME ${HUN_OTHER} | awk '{print $1}'`
+	if got != want {
+		t.Fatalf("expandHunVars = %q, want %q", got, want)
+	}
+}
+
+func TestExpandHunNamesLeavesPortReferences(t *testing.T) {
+	got, needsPort := ExpandHunNames("./${HUN_SERVICE}/$HOME", "app", "api")
+	if got != "./api/$HOME" || needsPort {
+		t.Fatalf("ExpandHunNames = %q, %v", got, needsPort)
+	}
+	got, needsPort = ExpandHunNames("./$HUN_PROJECT-${HUN_PORT}", "app", "api")
+	if got != "./app-${HUN_PORT}" || !needsPort {
+		t.Fatalf("ExpandHunNames = %q, %v, want the port left for start", got, needsPort)
+	}
+}
+
 func TestStatusShowsProjectDuringStartup(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
@@ -145,6 +515,56 @@ func TestStatusShowsProjectDuringStartup(t *testing.T) {
 	}
 }
 
+func TestStatusSinceOnlySendsStatusWhenItChanged(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	first := m.StatusSince(0)
+	if first.Unchanged || first.Revision == 0 {
+		t.Fatalf("a client without a revision needs the full status, got %+v", first)
+	}
+	if again := m.StatusSince(first.Revision); !again.Unchanged || again.Revision != first.Revision || again.Status != nil {
+		t.Fatalf("nothing changed, got %+v", again)
+	}
+
+	proj := &config.Project{
+		Name:     "shop",
+		Services: map[string]*config.Service{"api": {Cmd: "sleep 30"}},
+	}
+	if err := m.StartProject("shop", proj, t.TempDir(), false); err != nil {
+		t.Fatalf("start project: %v", err)
+	}
+	defer m.StopProject("shop")
+
+	changed := m.StatusSince(first.Revision)
+	if changed.Unchanged || changed.Revision == first.Revision {
+		t.Fatalf("starting a service should move the revision, got %+v", changed)
+	}
+	if !changed.Status["shop"]["api"].Running {
+		t.Fatalf("expected the running service in the new status, got %+v", changed.Status)
+	}
+
+	// Readiness is set by the process itself, not the manager; it must move
+	// the revision too.
+	rev := changed.Revision
+	for deadline := time.Now().Add(3 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		delta := m.StatusSince(rev)
+		if delta.Unchanged {
+			continue
+		}
+		rev = delta.Revision
+		if delta.Status["shop"]["api"].Ready {
+			return
+		}
+	}
+	t.Fatal("the service becoming ready never moved the status revision")
+}
+
 func TestRuntimePortDetectionUpdatesLiveStatusWithoutPersistingOverride(t *testing.T) {
 	requireRuntimePortInspection(t)
 	home := t.TempDir()
@@ -954,6 +1374,44 @@ func pidAlive(pid int) bool {
 	return syscall.Kill(pid, 0) == nil
 }
 
+func TestRestartServiceReexpandsHunPortWhenPortMoves(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	port := freeTCPPort(t)
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	proj := &config.Project{
+		Name: "moved-port",
+		Services: map[string]*config.Service{
+			"web": {Cmd: "echo listening on $HUN_PORT && sleep 30", Port: port},
+		},
+	}
+	if err := m.StartProject(proj.Name, proj, t.TempDir(), false); err != nil {
+		t.Fatalf("start project: %v", err)
+	}
+	waitForLogLine(t, m, proj.Name, "web", fmt.Sprintf("listening on %d", port), 3*time.Second)
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		t.Fatalf("occupy base port: %v", err)
+	}
+	defer listener.Close()
+
+	if err := m.RestartService(proj.Name, "web"); err != nil {
+		t.Fatalf("restart service: %v", err)
+	}
+	moved := m.Status()[proj.Name]["web"].Port
+	if moved == port || moved == 0 {
+		t.Fatalf("restart port = %d, want a fallback from the taken %d", moved, port)
+	}
+	waitForLogLine(t, m, proj.Name, "web", fmt.Sprintf("listening on %d", moved), 3*time.Second)
+}
+
 func TestRestartServiceResetsServiceLogsAndStartedAt(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
@@ -1001,6 +1459,9 @@ func TestRestartServiceResetsServiceLogsAndStartedAt(t *testing.T) {
 	if !updated.After(before) {
 		t.Fatalf("expected started_at to advance after restart, before=%s after=%s", before, updated)
 	}
+	if got := m.Status()["fresh-restart"]["svc"].Restarts; got != 1 {
+		t.Fatalf("restarts = %d, want 1 after one explicit restart", got)
+	}
 
 	waitForLogLine(t, m, "fresh-restart", "svc", "started", 3*time.Second)
 	lines := m.GetLogs("fresh-restart", "svc", 200)
@@ -1055,6 +1516,87 @@ func TestOnFailureRestartKeepsOnlyFreshServiceLogs(t *testing.T) {
 	}
 }
 
+func TestRestartAlwaysRelaunchesCleanExitsUntilStopped(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	proj := &config.Project{
+		Name: "looper",
+		Services: map[string]*config.Service{
+			"job": {Cmd: "echo pass-done && exit 0", Restart: "always"},
+		},
+	}
+	if err := m.StartProject("looper", proj, t.TempDir(), true); err != nil {
+		t.Fatalf("start project: %v", err)
+	}
+
+	deadline := time.Now().Add(4 * time.Second)
+	for m.Status()["looper"]["job"].Restarts < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("clean exits were not restarted: %+v", m.Status()["looper"]["job"])
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// The job is almost always waiting out its backoff here; a stop must
+	// cancel the pending restart rather than race it.
+	if err := m.StopService("looper", "job"); err != nil {
+		t.Fatalf("stop service: %v", err)
+	}
+	restarts := m.Status()["looper"]["job"].Restarts
+	time.Sleep(1500 * time.Millisecond)
+	info := m.Status()["looper"]["job"]
+	if info.Running || info.Restarts != restarts {
+		t.Fatalf("stopped service came back: %+v (restarts before stop %d)", info, restarts)
+	}
+}
+
+func TestStartProjectSafeHaltsAtFirstCrashWithoutRestart(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	proj := &config.Project{
+		Name: "safe-start",
+		Services: map[string]*config.Service{
+			"db":  {Cmd: "sleep 5"},
+			"api": {Cmd: "echo boom-first-error && exit 3", Restart: "on_failure", DependsOn: []string{"db"}},
+			"web": {Cmd: "sleep 5", DependsOn: []string{"api"}},
+		},
+	}
+
+	err = m.StartProjectSafe("safe-start", proj, t.TempDir(), false)
+	if err == nil {
+		t.Fatal("expected safe start to report the crash")
+	}
+	if msg := err.Error(); !strings.Contains(msg, "api exited with code 3") || !strings.Contains(msg, "boom-first-error") {
+		t.Fatalf("error should name the crash and carry its tail, got %q", msg)
+	}
+
+	status := m.Status()["safe-start"]
+	if !status["db"].Running {
+		t.Fatalf("services started before the crash should keep running: %+v", status)
+	}
+	if _, ok := status["web"]; ok {
+		t.Fatalf("services after the crash should not start: %+v", status)
+	}
+	time.Sleep(1500 * time.Millisecond)
+	if info := m.Status()["safe-start"]["api"]; info.Running || info.Restarts > 1 {
+		t.Fatalf("safe start should not auto-restart the crashed service: %+v", info)
+	}
+}
+
 func waitForLogLine(t *testing.T, m *Manager, project, service, contains string, timeout time.Duration) {
 	t.Helper()
 	deadline := time.Now().Add(timeout)
@@ -1069,3 +1611,206 @@ func waitForLogLine(t *testing.T, m *Manager, project, service, contains string,
 	}
 	t.Fatalf("timed out waiting for log containing %q for %s:%s", contains, project, service)
 }
+
+func TestRestartBackoffGrowsCapsAndResetsAfterHealthyRun(t *testing.T) {
+	fixed := newRestartBackoff(nil)
+	for i := 0; i < 3; i++ {
+		if got := fixed.next(0); got != time.Second {
+			t.Fatalf("default restart delay %d = %s, want 1s", i, got)
+		}
+	}
+
+	b := newRestartBackoff(&config.RestartBackoff{Initial: "1s", Max: "5s", Multiplier: 2})
+	var got []time.Duration
+	for i := 0; i < 5; i++ {
+		got = append(got, b.next(100*time.Millisecond))
+	}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("backoff delays = %v, want %v", got, want)
+	}
+	if d := b.next(restartBackoffResetAfter); d != time.Second {
+		t.Fatalf("delay after healthy run = %s, want reset to 1s", d)
+	}
+}
+
+func TestRestartBackoffExhaustsAfterMaxRestartsInARow(t *testing.T) {
+	b := newRestartBackoff(nil)
+	for i := 0; i < 3; i++ {
+		if b.exhausted(0, 3) {
+			t.Fatalf("exhausted after %d restarts, want 3 allowed", i)
+		}
+		b.next(0)
+	}
+	if !b.exhausted(0, 3) {
+		t.Fatal("expected the fourth crash in a row to give up")
+	}
+	if b.exhausted(0, 0) {
+		t.Fatal("restart_max 0 should never give up")
+	}
+	if b.exhausted(restartBackoffResetAfter, 3) {
+		t.Fatal("a crash after a healthy run should start a new count")
+	}
+}
+
+func TestRestartMaxGivesUpAndLogsIt(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	proj := &config.Project{
+		Name: "flaky",
+		Services: map[string]*config.Service{
+			"svc": {Cmd: "exit 1", Restart: "on_failure", RestartMax: 1},
+		},
+	}
+	if err := m.StartProject("flaky", proj, t.TempDir(), true); err != nil {
+		t.Fatalf("start project: %v", err)
+	}
+	waitForLogLine(t, m, "flaky", "svc", "[hun] giving up after 1 restarts", 4*time.Second)
+	if info := m.Status()["flaky"]["svc"]; info.Running || info.Status != "crashed" || info.Restarts != 2 {
+		t.Fatalf("service after giving up = %+v, want crashed with 2 restarts noted", info)
+	}
+}
+
+func TestStopAllWithinKillsServicesPastDeadline(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available; skipping shutdown drain test")
+	}
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	if m.BeginDaemonSession() {
+		t.Fatal("fresh state must not report an unclean previous shutdown")
+	}
+	if !m.BeginDaemonSession() {
+		t.Fatal("a session that never ended must be reported as unclean")
+	}
+	m.EndDaemonSession()
+	if m.BeginDaemonSession() {
+		t.Fatal("EndDaemonSession must record a clean shutdown")
+	}
+
+	ignoreTerm := `python3 -c "import signal,time; signal.signal(signal.SIGTERM, signal.SIG_IGN);` +
+		`exec('while True:\\n  time.sleep(1)')"`
+	for _, name := range []string{"one", "two"} {
+		proj := &config.Project{Name: name, Services: map[string]*config.Service{"svc": {Cmd: ignoreTerm}}}
+		if err := m.StartProject(name, proj, t.TempDir(), false); err != nil {
+			t.Fatalf("start %s: %v", name, err)
+		}
+		waitForServiceRunning(t, m, name, "svc")
+	}
+	time.Sleep(250 * time.Millisecond) // let python install its SIGTERM handler
+	pids := []int{m.Status()["one"]["svc"].PID, m.Status()["two"]["svc"].PID}
+
+	started := time.Now()
+	err = m.StopAllWithin(500 * time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "one:svc") || !strings.Contains(err.Error(), "two:svc") {
+		t.Fatalf("expected both stuck services reported, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 3*time.Second {
+		t.Fatalf("drain took %v, expected the deadline to bound it", elapsed)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for _, pid := range pids {
+		for pidAlive(pid) && time.Now().Before(deadline) {
+			time.Sleep(25 * time.Millisecond)
+		}
+		if pidAlive(pid) {
+			t.Fatalf("pid %d survived the drain deadline", pid)
+		}
+	}
+}
+
+func TestSetProjectFavoritePersistsAndRejectsUnknownProjects(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	projectRoot := t.TempDir()
+	writeTestFile(t, filepath.Join(projectRoot, ".hun.yml"))
+
+	st, err := state.Load()
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	st.Register("app", projectRoot)
+	if err := st.Save(); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	if err := m.SetProjectFavorite("app", true); err != nil {
+		t.Fatalf("set favorite: %v", err)
+	}
+	reloaded, err := state.Load()
+	if err != nil {
+		t.Fatalf("reload state: %v", err)
+	}
+	if !reloaded.Projects["app"].Favorite {
+		t.Fatal("favorite should be saved to state.json")
+	}
+	if err := m.SetProjectFavorite("app", false); err != nil || m.StateSnapshot().Projects["app"].Favorite {
+		t.Fatalf("clearing favorite: err=%v", err)
+	}
+	if err := m.SetProjectFavorite("missing", true); err == nil {
+		t.Fatal("expected an error for a project not in the registry")
+	}
+}
+
+func TestSetServicePinnedKeepsSortedNamesInState(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	projectRoot := t.TempDir()
+	writeTestFile(t, filepath.Join(projectRoot, ".hun.yml"))
+
+	st, err := state.Load()
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	st.Register("app", projectRoot)
+	if err := st.Save(); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer m.Shutdown()
+
+	for _, service := range []string{"web", "api", "web"} {
+		if err := m.SetServicePinned("app", service, true); err != nil {
+			t.Fatalf("pin %s: %v", service, err)
+		}
+	}
+	reloaded, err := state.Load()
+	if err != nil {
+		t.Fatalf("reload state: %v", err)
+	}
+	if got := strings.Join(reloaded.Projects["app"].Pinned, ","); got != "api,web" {
+		t.Fatalf("pinned = %s, want api,web saved to state.json", got)
+	}
+	if err := m.SetServicePinned("app", "api", false); err != nil {
+		t.Fatalf("unpin api: %v", err)
+	}
+	if got := m.StateSnapshot().Projects["app"].Pinned; len(got) != 1 || got[0] != "web" {
+		t.Fatalf("pinned after unpinning api = %v, want [web]", got)
+	}
+	if err := m.SetServicePinned("missing", "web", true); err == nil {
+		t.Fatal("expected an error for a project not in the registry")
+	}
+}
@@ -2,17 +2,23 @@ package daemon
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"net"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
+
+	"github.com/sourabhrathourr/hun/internal/config"
 )
 
 // Process represents a single running service process.
@@ -28,13 +34,30 @@ type Process struct {
 	launchPort       int  // port selected and requested at process launch
 	allowRuntimePort bool // multitask services may adopt another verified owned listener
 
+	ReadyCmd      string        // probe command; a zero exit marks the process ready
+	ReadyInterval time.Duration // between ReadyCmd attempts
+	ReadyTimeout  time.Duration // per ReadyCmd attempt
+	ReadyHTTP     string        // URL polled until it answers 2xx
+	ReadyTCP      bool          // dial the service's port until it accepts
+	StopTimeout   time.Duration // SIGTERM grace before SIGKILL; 0 means config.DefaultStopTimeout
+
+	// With hunVars set, Start re-expands rawCmd and rawDir into Cmd and Dir
+	// for its launch port, so a restart on another port sees the new one.
+	rawCmd  string
+	rawDir  string
+	hunVars func(port int) map[string]string
+
 	cmd       *exec.Cmd
 	stdin     io.Closer
 	pid       int
 	running   bool
 	ready     bool
+	readyVia  string // how a probe marked the process ready, for the log note
 	stopping  bool
+	paused    bool // process group is held with SIGSTOP
 	startedAt time.Time
+	restarts  int // crashes plus explicit restarts since the project started
+	exitCode  int // exit status of the last run; -1 when killed by a signal
 	exited    chan struct{}
 	portLease *portLease
 	mu        sync.Mutex
@@ -42,6 +65,14 @@ type Process struct {
 	onOutput func(line string, isErr bool)
 	onExit   func(err error, intentional bool)
 	onReady  func()
+	onChange func() // any state Status reports changed; must not block or lock p
+}
+
+// changed reports a status change to onChange, if set.
+func (p *Process) changed() {
+	if p.onChange != nil {
+		p.onChange()
+	}
 }
 
 // Start launches the process in its own process group.
@@ -53,41 +84,61 @@ func (p *Process) Start() error {
 		return fmt.Errorf("process %s already running", p.Name)
 	}
 
-	shell := os.Getenv("SHELL")
-	if shell == "" {
-		shell = "/bin/sh"
+	launchPort := p.launchPort
+	if launchPort <= 0 {
+		launchPort = p.observedPort
 	}
-	p.cmd = exec.Command(shell, "-c", p.Cmd)
+	if p.hunVars != nil {
+		vars := p.hunVars(launchPort)
+		p.Cmd = expandHunVars(p.rawCmd, vars)
+		p.Dir = expandHunVars(p.rawDir, vars)
+	}
+
+	p.cmd = exec.Command(serviceShell(), "-c", p.Cmd)
 
 	if p.Dir != "" {
 		p.cmd.Dir = p.Dir
 	}
 
 	// Build environment
-	launchPort := p.launchPort
-	if launchPort <= 0 {
-		launchPort = p.observedPort
-	}
 	p.cmd.Env = buildServiceEnvironment(p.Env, p.PortEnv, launchPort)
 
 	// Start in own process group for clean kill
 	p.cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 
-	stdout, err := p.cmd.StdoutPipe()
+	// Output goes through pipes hun owns rather than StdoutPipe: cmd.Wait
+	// closes those as soon as the process exits, which can drop the last
+	// lines a crashing service wrote. These are read to EOF instead.
+	stdout, stdoutW, err := os.Pipe()
 	if err != nil {
 		return fmt.Errorf("stdout pipe: %w", err)
 	}
-	stderr, err := p.cmd.StderrPipe()
+	stderr, stderrW, err := os.Pipe()
 	if err != nil {
+		stdout.Close()
+		stdoutW.Close()
 		return fmt.Errorf("stderr pipe: %w", err)
 	}
+	p.cmd.Stdout = stdoutW
+	p.cmd.Stderr = stderrW
+	closeOutput := func() {
+		stdout.Close()
+		stderr.Close()
+	}
 	stdin, err := p.cmd.StdinPipe()
 	if err != nil {
+		stdoutW.Close()
+		stderrW.Close()
+		closeOutput()
 		return fmt.Errorf("stdin pipe: %w", err)
 	}
 
-	if err := p.cmd.Start(); err != nil {
+	err = p.cmd.Start()
+	stdoutW.Close()
+	stderrW.Close()
+	if err != nil {
 		_ = stdin.Close()
+		closeOutput()
 		return fmt.Errorf("starting %s: %w", p.Name, err)
 	}
 
@@ -95,21 +146,51 @@ func (p *Process) Start() error {
 	p.pid = p.cmd.Process.Pid
 	p.running = true
 	p.ready = false
+	p.readyVia = ""
 	p.stopping = false
+	p.paused = false
 	p.startedAt = time.Now().UTC()
 	p.exited = make(chan struct{})
-
-	go p.scanOutput(stdout, false)
-	go p.scanOutput(stderr, true)
-	go p.waitForExit(p.cmd, p.exited)
-
-	if p.ReadyPattern == "" {
+	p.changed()
+
+	var scanners sync.WaitGroup
+	scanners.Add(2)
+	go func() {
+		defer scanners.Done()
+		p.scanOutput(stdout, false)
+	}()
+	go func() {
+		defer scanners.Done()
+		p.scanOutput(stderr, true)
+	}()
+	drained := make(chan struct{})
+	go func() {
+		scanners.Wait()
+		close(drained)
+	}()
+	go p.waitForExit(p.cmd, p.exited, drained, closeOutput)
+
+	if p.ReadyCmd != "" {
+		go p.probeReadyCmd(p.cmd.Env, p.exited)
+	}
+	if p.ReadyHTTP != "" {
+		go p.probeReady(p.exited, p.checkReadyHTTP)
+	}
+	if p.ReadyTCP {
+		go p.probeReady(p.exited, p.checkReadyTCP)
+	}
+	if p.ReadyCmd == "" && p.ReadyHTTP == "" && !p.ReadyTCP && p.ReadyPattern == "" {
 		go p.markReadyAfterGracePeriod()
 	}
 
 	return nil
 }
 
+// serviceShell is the shell service, hook, and ready commands run under.
+func serviceShell() string {
+	return getenvDefault("SHELL", "/bin/sh")
+}
+
 func buildServiceEnvironment(overrides map[string]string, portEnv string, port int) []string {
 	env := withDeveloperEnvironment(os.Environ())
 	for k, v := range overrides {
@@ -271,11 +352,14 @@ func dirExists(path string) bool {
 func (p *Process) Stop() error {
 	p.mu.Lock()
 	if !p.running {
+		// Between runs this cancels a pending automatic restart.
+		p.stopping = true
 		p.mu.Unlock()
 		return nil
 	}
 	pid := p.pid
 	exited := p.exited
+	paused := p.paused
 	p.stopping = true
 	p.mu.Unlock()
 
@@ -283,8 +367,16 @@ func (p *Process) Stop() error {
 	if err := syscall.Kill(-pid, syscall.SIGTERM); err != nil && !errors.Is(err, syscall.ESRCH) {
 		return fmt.Errorf("sending SIGTERM to %s: %w", p.Name, err)
 	}
+	if paused {
+		// A stopped group only sees the SIGTERM once it is allowed to run again.
+		_ = syscall.Kill(-pid, syscall.SIGCONT)
+	}
 
-	if waitForProcessExit(exited, 5*time.Second) {
+	grace := p.StopTimeout
+	if grace <= 0 {
+		grace = config.DefaultStopTimeout
+	}
+	if waitForProcessExit(exited, grace) {
 		return nil
 	}
 
@@ -298,6 +390,83 @@ func (p *Process) Stop() error {
 	return fmt.Errorf("process %s did not exit after SIGKILL", p.Name)
 }
 
+// stopRequested reports whether Stop was called since the last run exited.
+func (p *Process) stopRequested() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stopping
+}
+
+// Kill sends SIGKILL to the process group without waiting for it to exit.
+func (p *Process) Kill() {
+	p.mu.Lock()
+	pid := p.pid
+	running := p.running
+	p.stopping = true
+	p.mu.Unlock()
+	if running && pid > 0 {
+		_ = syscall.Kill(-pid, syscall.SIGKILL)
+	}
+}
+
+// Pause freezes the process group with SIGSTOP, keeping it alive.
+func (p *Process) Pause() error {
+	return p.setPaused(true)
+}
+
+// Resume continues a paused process group with SIGCONT.
+func (p *Process) Resume() error {
+	return p.setPaused(false)
+}
+
+func (p *Process) setPaused(paused bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.running {
+		return fmt.Errorf("process %s is not running", p.Name)
+	}
+	if p.paused == paused {
+		return nil
+	}
+	sig := syscall.SIGCONT
+	if paused {
+		sig = syscall.SIGSTOP
+	}
+	if err := syscall.Kill(-p.pid, sig); err != nil {
+		return fmt.Errorf("sending %s to %s: %w", sig, p.Name, err)
+	}
+	p.paused = paused
+	p.changed()
+	return nil
+}
+
+// ExitCode returns the exit status of the last completed run.
+func (p *Process) ExitCode() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.exitCode
+}
+
+// Done returns a channel closed when the current run exits. A process that
+// was never started returns a closed channel.
+func (p *Process) Done() <-chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.exited == nil {
+		done := make(chan struct{})
+		close(done)
+		return done
+	}
+	return p.exited
+}
+
+// IsPaused returns whether the process group is currently stopped by Pause.
+func (p *Process) IsPaused() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.paused
+}
+
 // IsRunning returns whether the process is currently running.
 func (p *Process) IsRunning() bool {
 	p.mu.Lock()
@@ -305,6 +474,14 @@ func (p *Process) IsRunning() bool {
 	return p.running
 }
 
+// ReadyVia reports how a readiness probe marked the process ready, or ""
+// when readiness has nothing worth noting in the log.
+func (p *Process) ReadyVia() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.readyVia
+}
+
 // IsReady returns whether the process has matched its ready pattern.
 func (p *Process) IsReady() bool {
 	p.mu.Lock()
@@ -326,6 +503,20 @@ func (p *Process) StartedAt() time.Time {
 	return p.startedAt
 }
 
+// Restarts returns how many times this service crashed or was restarted.
+func (p *Process) Restarts() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.restarts
+}
+
+func (p *Process) noteRestart() {
+	p.mu.Lock()
+	p.restarts++
+	p.mu.Unlock()
+	p.changed()
+}
+
 // ObservedPort returns the port currently reported to status consumers.
 func (p *Process) ObservedPort() int {
 	p.mu.Lock()
@@ -345,6 +536,7 @@ func (p *Process) SetObservedPort(port int) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	p.observedPort = port
+	p.changed()
 }
 
 // ResetObservedPort restores live status to the authoritative launch port.
@@ -352,6 +544,7 @@ func (p *Process) ResetObservedPort() int {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	p.observedPort = p.launchPort
+	p.changed()
 	return p.observedPort
 }
 
@@ -378,6 +571,7 @@ func (p *Process) PreparePort(port int, lease *portLease) {
 	p.launchPort = port
 	p.observedPort = port
 	p.mu.Unlock()
+	p.changed()
 	oldLease.release()
 }
 
@@ -388,6 +582,7 @@ func (p *Process) AdoptRuntimePort(port int) error {
 		p.launchPort = port
 		p.observedPort = port
 		p.mu.Unlock()
+		p.changed()
 		return nil
 	}
 	p.mu.Unlock()
@@ -414,7 +609,8 @@ func (p *Process) ReleasePortLease() {
 	lease.release()
 }
 
-func (p *Process) scanOutput(r io.Reader, isErr bool) {
+func (p *Process) scanOutput(r io.ReadCloser, isErr bool) {
+	defer r.Close()
 	scanner := bufio.NewScanner(r)
 	scanner.Buffer(make([]byte, 64*1024), 64*1024)
 	for scanner.Scan() {
@@ -422,30 +618,41 @@ func (p *Process) scanOutput(r io.Reader, isErr bool) {
 		if p.onOutput != nil {
 			p.onOutput(line, isErr)
 		}
-		if !p.IsReady() && p.ReadyPattern != "" {
-			if strings.Contains(line, p.ReadyPattern) {
-				p.mu.Lock()
-				p.ready = true
-				p.mu.Unlock()
-				if p.onReady != nil {
-					p.onReady()
-				}
-			}
+		if !p.IsReady() && p.ReadyPattern != "" && strings.Contains(line, p.ReadyPattern) {
+			p.markReady()
 		}
 	}
 }
 
-func (p *Process) waitForExit(cmd *exec.Cmd, exited chan struct{}) {
+// outputDrainTimeout bounds how long an exited service's output is still
+// read. A background child that inherited the pipes keeps them open; what it
+// writes after this is dropped rather than landing in the next run's log.
+var outputDrainTimeout = 2 * time.Second
+
+// waitForExit reaps cmd, then reads its output to EOF, closing the pipes once
+// outputDrainTimeout passes, before reporting the exit.
+func (p *Process) waitForExit(cmd *exec.Cmd, exited, drained chan struct{}, closeOutput func()) {
 	err := cmd.Wait()
+	select {
+	case <-drained:
+	case <-time.After(outputDrainTimeout):
+		closeOutput()
+		<-drained
+	}
 	p.mu.Lock()
+	if cmd.ProcessState != nil {
+		p.exitCode = cmd.ProcessState.ExitCode()
+	}
 	p.running = false
 	p.ready = false
+	p.paused = false
 	p.pid = 0
 	stdin := p.stdin
 	p.stdin = nil
 	intentional := p.stopping
 	p.stopping = false
 	p.mu.Unlock()
+	p.changed()
 	if stdin != nil {
 		_ = stdin.Close()
 	}
@@ -457,18 +664,124 @@ func (p *Process) waitForExit(cmd *exec.Cmd, exited chan struct{}) {
 
 func (p *Process) markReadyAfterGracePeriod() {
 	time.Sleep(time.Second)
+	p.markReady()
+}
+
+// markReady flags a running process ready once and fires onReady.
+func (p *Process) markReady() {
+	p.markReadyVia("")
+}
+
+// markReadyVia is markReady for a probe, recording how readiness was seen.
+func (p *Process) markReadyVia(via string) {
 	p.mu.Lock()
 	if !p.running || p.ready {
 		p.mu.Unlock()
 		return
 	}
 	p.ready = true
+	p.readyVia = via
 	p.mu.Unlock()
+	p.changed()
 	if p.onReady != nil {
 		p.onReady()
 	}
 }
 
+// probeReadyCmd runs ReadyCmd with the service's environment every
+// ReadyInterval until it succeeds, the ready pattern matches first, or the
+// run the probe belongs to exits.
+func (p *Process) probeReadyCmd(env []string, exited <-chan struct{}) {
+	interval, timeout := p.ReadyInterval, p.ReadyTimeout
+	if interval <= 0 {
+		interval = time.Second
+	}
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	for {
+		select {
+		case <-exited:
+			return
+		case <-time.After(interval):
+		}
+		if p.IsReady() {
+			return
+		}
+		if p.runReadyCmd(env, timeout) == nil {
+			p.markReady()
+			return
+		}
+	}
+}
+
+// readyProbeInterval is how often ReadyHTTP and ReadyTCP are polled.
+const readyProbeInterval = 250 * time.Millisecond
+
+// probeReady runs check every readyProbeInterval until it passes, another
+// readiness signal fires first, or the run the probe belongs to exits. check
+// returns how readiness was seen, for the log note.
+func (p *Process) probeReady(exited <-chan struct{}, check func() (string, bool)) {
+	for {
+		select {
+		case <-exited:
+			return
+		case <-time.After(readyProbeInterval):
+		}
+		if p.IsReady() {
+			return
+		}
+		if via, ok := check(); ok {
+			p.markReadyVia(via)
+			return
+		}
+	}
+}
+
+// checkReadyHTTP reports whether ReadyHTTP answers 2xx.
+func (p *Process) checkReadyHTTP() (string, bool) {
+	client := &http.Client{Timeout: 2 * time.Second}
+	resp, err := client.Get(p.ReadyHTTP)
+	if err != nil {
+		return "", false
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", false
+	}
+	return fmt.Sprintf("http (%s answered %d)", p.ReadyHTTP, resp.StatusCode), true
+}
+
+// checkReadyTCP reports whether the service's current port accepts a
+// connection. The observed port is dialed so a runtime port change is seen.
+func (p *Process) checkReadyTCP() (string, bool) {
+	port := p.ObservedPort()
+	if port <= 0 {
+		return "", false
+	}
+	addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+	conn, err := net.DialTimeout("tcp", addr, time.Second)
+	if err != nil {
+		return "", false
+	}
+	conn.Close()
+	return "tcp (" + addr + " accepted a connection)", true
+}
+
+func (p *Process) runReadyCmd(env []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, serviceShell(), "-c", p.ReadyCmd)
+	cmd.Dir = p.Dir
+	cmd.Env = env
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = time.Second
+	return cmd.Run()
+}
+
 func signalExited(exited chan struct{}) {
 	close(exited)
 }
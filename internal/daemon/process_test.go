@@ -1,9 +1,16 @@
 package daemon
 
 import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -118,6 +125,120 @@ func TestProcessKeepsStdinOpenForInteractiveDevServers(t *testing.T) {
 	}
 }
 
+func TestProcessStopsReadingOutputHeldOpenByBackgroundChild(t *testing.T) {
+	defer func(d time.Duration) { outputDrainTimeout = d }(outputDrainTimeout)
+	outputDrainTimeout = 200 * time.Millisecond
+
+	var mu sync.Mutex
+	var lines []string
+	exited := make(chan struct{})
+	proc := &Process{
+		Name: "forks",
+		Cmd:  "(sleep 1; echo late) & echo early",
+		Dir:  t.TempDir(),
+	}
+	proc.onOutput = func(line string, isErr bool) {
+		mu.Lock()
+		lines = append(lines, line)
+		mu.Unlock()
+	}
+	proc.onExit = func(error, bool) { close(exited) }
+	if err := proc.Start(); err != nil {
+		t.Fatalf("start process: %v", err)
+	}
+	pgid := proc.PID()
+	defer syscall.Kill(-pgid, syscall.SIGKILL)
+
+	select {
+	case <-exited:
+	case <-time.After(3 * time.Second):
+		t.Fatal("exit was not reported while a background child held the output pipes")
+	}
+	time.Sleep(1500 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(lines, ",") != "early" {
+		t.Fatalf("output = %q, want only the line written before the drain timeout", lines)
+	}
+}
+
+func TestProcessPauseResumeAndStopWhilePaused(t *testing.T) {
+	proc := &Process{
+		Name: "pausable",
+		Cmd:  "sleep 30",
+		Dir:  t.TempDir(),
+	}
+
+	if err := proc.Start(); err != nil {
+		t.Fatalf("start process: %v", err)
+	}
+	defer proc.Stop()
+
+	if err := proc.Pause(); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	if !proc.IsPaused() || !proc.IsRunning() {
+		t.Fatalf("expected paused running process, paused=%v running=%v", proc.IsPaused(), proc.IsRunning())
+	}
+	if err := proc.Resume(); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if proc.IsPaused() {
+		t.Fatal("expected process to be resumed")
+	}
+
+	if err := proc.Pause(); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	if err := proc.Stop(); err != nil {
+		t.Fatalf("stop paused process: %v", err)
+	}
+	if proc.IsRunning() || proc.IsPaused() {
+		t.Fatalf("expected stopped process, paused=%v running=%v", proc.IsPaused(), proc.IsRunning())
+	}
+}
+
+func TestProcessStopWaitsForStopTimeoutThenKills(t *testing.T) {
+	dir := t.TempDir()
+	flusher := &Process{
+		Name:        "flusher",
+		Cmd:         "trap 'sleep 0.7; touch flushed; exit 0' TERM; while true; do sleep 0.1; done",
+		Dir:         dir,
+		StopTimeout: 3 * time.Second,
+	}
+	if err := flusher.Start(); err != nil {
+		t.Fatalf("start flusher: %v", err)
+	}
+	time.Sleep(150 * time.Millisecond)
+	if err := flusher.Stop(); err != nil {
+		t.Fatalf("stop flusher: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "flushed")); err != nil {
+		t.Fatalf("flusher was killed before its SIGTERM handler finished: %v", err)
+	}
+
+	stubborn := &Process{
+		Name:        "stubborn",
+		Cmd:         "trap '' TERM; sleep 30",
+		Dir:         dir,
+		StopTimeout: 300 * time.Millisecond,
+	}
+	if err := stubborn.Start(); err != nil {
+		t.Fatalf("start stubborn: %v", err)
+	}
+	time.Sleep(150 * time.Millisecond)
+	started := time.Now()
+	if err := stubborn.Stop(); err != nil {
+		t.Fatalf("stop stubborn: %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 2*time.Second {
+		t.Fatalf("stop took %s, want SIGKILL soon after the 300ms stop timeout", elapsed)
+	}
+	if stubborn.IsRunning() {
+		t.Fatal("process ignoring SIGTERM should be killed after its stop timeout")
+	}
+}
+
 func pathContains(path, dir string) bool {
 	for _, part := range strings.Split(path, string(os.PathListSeparator)) {
 		if part == dir {
@@ -126,3 +247,130 @@ func pathContains(path, dir string) bool {
 	}
 	return false
 }
+
+func TestProcessReadyCmdMarksReadyOnZeroExit(t *testing.T) {
+	dir := t.TempDir()
+	proc := &Process{
+		Name:          "probed",
+		Cmd:           "sleep 30",
+		Dir:           dir,
+		ReadyCmd:      "test -f ready.flag",
+		ReadyInterval: 20 * time.Millisecond,
+		ReadyTimeout:  time.Second,
+	}
+	readyCh := make(chan struct{}, 1)
+	proc.onReady = func() { readyCh <- struct{}{} }
+
+	if err := proc.Start(); err != nil {
+		t.Fatalf("start process: %v", err)
+	}
+	defer proc.Stop()
+
+	time.Sleep(1200 * time.Millisecond)
+	if proc.IsReady() {
+		t.Fatal("ready_cmd should gate readiness instead of the no-pattern grace period")
+	}
+	if err := os.WriteFile(filepath.Join(dir, "ready.flag"), nil, 0o644); err != nil {
+		t.Fatalf("write flag: %v", err)
+	}
+	select {
+	case <-readyCh:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for ready_cmd to mark the process ready")
+	}
+}
+
+func TestProcessReadyHTTPMarksReadyOn2xx(t *testing.T) {
+	var healthy atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	proc := &Process{
+		Name:         "api",
+		Cmd:          "sleep 30",
+		Dir:          t.TempDir(),
+		ReadyPattern: "never printed",
+		ReadyHTTP:    srv.URL + "/healthz",
+	}
+	readyCh := make(chan struct{}, 1)
+	proc.onReady = func() { readyCh <- struct{}{} }
+	if err := proc.Start(); err != nil {
+		t.Fatalf("start process: %v", err)
+	}
+	defer proc.Stop()
+
+	time.Sleep(600 * time.Millisecond)
+	if proc.IsReady() {
+		t.Fatal("a 503 should not mark the service ready")
+	}
+	healthy.Store(true)
+	select {
+	case <-readyCh:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for ready_http to mark the process ready")
+	}
+	if via := proc.ReadyVia(); !strings.HasPrefix(via, "http (") || !strings.Contains(via, "204") {
+		t.Fatalf("ReadyVia() = %q", via)
+	}
+}
+
+func TestProcessReadyTCPMarksReadyOnceThePortAccepts(t *testing.T) {
+	// Reserve a free port, then close it so the probe sees it refused at first.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	proc := &Process{
+		Name:         "db",
+		Cmd:          "sleep 30",
+		Dir:          t.TempDir(),
+		ReadyTCP:     true,
+		observedPort: port,
+	}
+	readyCh := make(chan struct{}, 1)
+	proc.onReady = func() { readyCh <- struct{}{} }
+	if err := proc.Start(); err != nil {
+		t.Fatalf("start process: %v", err)
+	}
+	defer proc.Stop()
+
+	time.Sleep(1200 * time.Millisecond)
+	if proc.IsReady() {
+		t.Fatal("ready_tcp should gate readiness instead of the no-pattern grace period")
+	}
+	ln, err = net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		t.Skipf("port %d was taken in between: %v", port, err)
+	}
+	defer ln.Close()
+	select {
+	case <-readyCh:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for ready_tcp to mark the process ready")
+	}
+	if via := proc.ReadyVia(); !strings.HasPrefix(via, "tcp (127.0.0.1:") {
+		t.Fatalf("ReadyVia() = %q", via)
+	}
+}
+
+func TestCurrentServiceEnvironmentFlagsMissingShell(t *testing.T) {
+	t.Setenv("SHELL", filepath.Join(t.TempDir(), "zsh"))
+	if env := CurrentServiceEnvironment(); !strings.Contains(env.ShellError, "does not exist") {
+		t.Fatalf("expected a missing-shell error, got %+v", env)
+	}
+
+	t.Setenv("SHELL", "/bin/sh")
+	env := CurrentServiceEnvironment()
+	if env.ShellError != "" || env.Path == "" {
+		t.Fatalf("expected /bin/sh to be usable with a PATH, got %+v", env)
+	}
+}
@@ -0,0 +1,243 @@
+package daemon
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// procClockTicks is USER_HZ, the unit /proc/<pid>/stat reports CPU time in.
+// It is 100 on every Linux architecture hun runs on.
+const procClockTicks = 100
+
+// groupUsage is what one process group has consumed so far: CPU time across
+// its live members and their combined resident memory.
+type groupUsage struct {
+	cpu time.Duration
+	rss int64
+}
+
+// cpuReading is the CPU time a process group had used at a point in time.
+type cpuReading struct {
+	cpu time.Duration
+	at  time.Time
+}
+
+// resourceSampleInterval is the shortest time between process scans. Status
+// requests inside it share the previous sample, so however many clients
+// poll, CPU% always spans at least this long and the scan runs at most once
+// per interval.
+var resourceSampleInterval = time.Second
+
+// resourceSampler turns cumulative CPU time into CPU% by remembering each
+// process group's previous reading. Services run in their own process group
+// (the service pid), so a shell and everything it spawned count together.
+type resourceSampler struct {
+	mu    sync.Mutex
+	last  map[int]cpuReading
+	at    time.Time
+	usage map[int]resourceUsage
+}
+
+// resourceUsage is a process group's CPU% and resident memory at a sample.
+type resourceUsage struct {
+	cpu      float64
+	memBytes int64
+}
+
+// sample returns CPU% and RSS for each process group in started, keyed by
+// pgid. CPU% covers the time since the previous scan, or since the group
+// started on its first scan, and can exceed 100 on several cores. Calls
+// within resourceSampleInterval of the last scan reuse its results; a group
+// that started since then shows up on the next scan.
+func (s *resourceSampler) sample(started map[int]time.Time) map[int]resourceUsage {
+	if len(started) == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.usage == nil || time.Since(s.at) >= resourceSampleInterval {
+		if !s.scan(started) {
+			return nil
+		}
+	}
+	result := make(map[int]resourceUsage, len(started))
+	for pgid := range started {
+		if u, ok := s.usage[pgid]; ok {
+			result[pgid] = u
+		}
+	}
+	return result
+}
+
+// scan reads every process and replaces the cached usage and the CPU
+// baselines for the groups in started. It reports whether the read worked.
+func (s *resourceSampler) scan(started map[int]time.Time) bool {
+	groups, err := readProcessGroups()
+	if err != nil {
+		return false
+	}
+	now := time.Now()
+	prev := s.last
+	s.last = make(map[int]cpuReading, len(started))
+	s.usage = make(map[int]resourceUsage, len(started))
+	s.at = now
+	for pgid, startedAt := range started {
+		g, ok := groups[pgid]
+		if !ok {
+			continue
+		}
+		s.last[pgid] = cpuReading{cpu: g.cpu, at: now}
+		base := cpuReading{at: startedAt}
+		if p, ok := prev[pgid]; ok {
+			base = p
+		}
+		s.usage[pgid] = resourceUsage{cpu: cpuPercent(g.cpu-base.cpu, now.Sub(base.at)), memBytes: g.rss}
+	}
+	return true
+}
+
+// cpuPercent expresses used CPU time as a percentage of elapsed wall time.
+// A group that lost members since the last sample can report less CPU time
+// than before; that reads as idle rather than negative.
+func cpuPercent(used, elapsed time.Duration) float64 {
+	if used <= 0 || elapsed <= 0 {
+		return 0
+	}
+	return float64(used) / float64(elapsed) * 100
+}
+
+// readProcessGroups totals CPU time and RSS per process group for every
+// process the daemon can see.
+func readProcessGroups() (map[int]groupUsage, error) {
+	if runtime.GOOS == "linux" {
+		return readProcGroups("/proc")
+	}
+	out, err := exec.Command("ps", "-A", "-o", "pgid=,time=,rss=").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parsePSGroups(out), nil
+}
+
+// readProcGroups reads /proc/<pid>/stat for every process under root.
+func readProcGroups(root string) (map[int]groupUsage, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	pageSize := int64(os.Getpagesize())
+	groups := make(map[int]groupUsage)
+	for _, e := range entries {
+		if _, err := strconv.Atoi(e.Name()); err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(root, e.Name(), "stat"))
+		if err != nil {
+			continue // exited since ReadDir
+		}
+		pgid, ticks, pages, ok := parseProcStat(string(data))
+		if !ok {
+			continue
+		}
+		g := groups[pgid]
+		g.cpu += time.Duration(ticks) * time.Second / procClockTicks
+		g.rss += pages * pageSize
+		groups[pgid] = g
+	}
+	return groups, nil
+}
+
+// parseProcStat pulls the process group, utime+stime, and RSS pages out of
+// a /proc/<pid>/stat line. The command name is skipped by its closing paren
+// since it may itself contain spaces or parens.
+func parseProcStat(stat string) (pgid int, ticks, rssPages int64, ok bool) {
+	end := strings.LastIndexByte(stat, ')')
+	if end < 0 {
+		return 0, 0, 0, false
+	}
+	// Fields after the name start at "state" (field 3 of proc(5)).
+	fields := strings.Fields(stat[end+1:])
+	if len(fields) < 22 {
+		return 0, 0, 0, false
+	}
+	pgid, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	utime, err1 := strconv.ParseInt(fields[11], 10, 64)
+	stime, err2 := strconv.ParseInt(fields[12], 10, 64)
+	rss, err3 := strconv.ParseInt(fields[21], 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return 0, 0, 0, false
+	}
+	return pgid, utime + stime, rss, true
+}
+
+// parsePSGroups totals `ps -A -o pgid=,time=,rss=` output per process group.
+// RSS is in KiB; time is [[dd-]hh:]mm:ss[.cc].
+func parsePSGroups(out []byte) map[int]groupUsage {
+	groups := make(map[int]groupUsage)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 {
+			continue
+		}
+		pgid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		cpu, ok := parsePSTime(fields[1])
+		if !ok {
+			continue
+		}
+		rss, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		g := groups[pgid]
+		g.cpu += cpu
+		g.rss += rss * 1024
+		groups[pgid] = g
+	}
+	return groups
+}
+
+// parsePSTime parses ps's cumulative CPU time column.
+func parsePSTime(value string) (time.Duration, bool) {
+	var days int64
+	if d, rest, ok := strings.Cut(value, "-"); ok {
+		n, err := strconv.ParseInt(d, 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		days, value = n, rest
+	}
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, false
+	}
+	seconds, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil {
+		return 0, false
+	}
+	total := time.Duration(seconds * float64(time.Second))
+	unit := time.Minute
+	for i := len(parts) - 2; i >= 0; i-- {
+		n, err := strconv.ParseInt(parts[i], 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		total += time.Duration(n) * unit
+		unit = time.Hour
+	}
+	return total + time.Duration(days)*24*time.Hour, true
+}
@@ -0,0 +1,72 @@
+package daemon
+
+import (
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestParseProcStatSkipsCommandNamesWithSpacesAndParens(t *testing.T) {
+	stat := "4242 (node (dev) x) S 1 4240 4240 0 -1 4194560 900 0 0 0 250 50 0 0 20 0 11 0 123456 1000000 2048 18446744073709551615\n"
+	pgid, ticks, pages, ok := parseProcStat(stat)
+	if !ok || pgid != 4240 || ticks != 300 || pages != 2048 {
+		t.Fatalf("parseProcStat = %d %d %d %v", pgid, ticks, pages, ok)
+	}
+	if _, _, _, ok := parseProcStat("4242 (truncated) S 1"); ok {
+		t.Fatal("short stat line should not parse")
+	}
+}
+
+func TestParsePSGroupsTotalsEachProcessGroup(t *testing.T) {
+	out := []byte("  100   0:01.50  2048\n  100   1:00.00  1024\n  200 1-02:03:04   512\nbogus\n")
+	groups := parsePSGroups(out)
+	if got := groups[100]; got.cpu != 61500*time.Millisecond || got.rss != 3072*1024 {
+		t.Fatalf("group 100 = %+v", got)
+	}
+	want := 26*time.Hour + 3*time.Minute + 4*time.Second
+	if got := groups[200]; got.cpu != want || got.rss != 512*1024 {
+		t.Fatalf("group 200 = %+v, want cpu %s", got, want)
+	}
+}
+
+func TestResourceSamplerReportsCPUSinceLastSample(t *testing.T) {
+	defer func(d time.Duration) { resourceSampleInterval = d }(resourceSampleInterval)
+	resourceSampleInterval = 100 * time.Millisecond
+	pgid := syscall.Getpgrp()
+	var s resourceSampler
+	started := map[int]time.Time{pgid: time.Now().Add(-time.Minute)}
+	first := s.sample(started)
+	if first[pgid].memBytes <= 0 {
+		t.Fatalf("expected this test's process group to use memory, got %+v", first[pgid])
+	}
+
+	for deadline := time.Now().Add(200 * time.Millisecond); time.Now().Before(deadline); {
+	}
+	second := s.sample(started)
+	if got := second[pgid].cpu; got <= 0 {
+		t.Fatalf("busy loop between samples should read as CPU use, got %.1f%%", got)
+	}
+	if _, ok := s.sample(map[int]time.Time{})[pgid]; ok {
+		t.Fatal("no services means nothing sampled")
+	}
+}
+
+func TestResourceSamplerReusesScanWithinInterval(t *testing.T) {
+	defer func(d time.Duration) { resourceSampleInterval = d }(resourceSampleInterval)
+	resourceSampleInterval = time.Hour
+	pgid := syscall.Getpgrp()
+	var s resourceSampler
+	started := map[int]time.Time{pgid: time.Now().Add(-time.Minute)}
+	first := s.sample(started)
+	baseline := s.last[pgid]
+
+	for deadline := time.Now().Add(50 * time.Millisecond); time.Now().Before(deadline); {
+	}
+	second := s.sample(started)
+	if second[pgid] != first[pgid] {
+		t.Fatalf("sample within the interval = %+v, want the cached %+v", second[pgid], first[pgid])
+	}
+	if s.last[pgid] != baseline {
+		t.Fatal("a cached sample must not move the CPU baseline")
+	}
+}
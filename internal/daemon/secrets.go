@@ -0,0 +1,72 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/sourabhrathourr/hun/internal/config"
+)
+
+// secretCommandTimeout leaves room for a password manager's unlock prompt.
+const secretCommandTimeout = 30 * time.Second
+
+// resolveServiceEnv returns env with every ${secret:NAME} replaced by its
+// value. command is the configured secret_command; without one, macOS falls
+// back to the login keychain (a generic password whose service is NAME).
+// env itself is left untouched so secrets never reach the stored config.
+func resolveServiceEnv(env map[string]string, command, dir string) (map[string]string, error) {
+	resolved := make(map[string]string, len(env))
+	cache := make(map[string]string)
+	lookup := func(name string) (string, error) {
+		if value, ok := cache[name]; ok {
+			return value, nil
+		}
+		value, err := readSecret(name, command, dir)
+		if err != nil {
+			return "", fmt.Errorf("resolving ${secret:%s}: %w", name, err)
+		}
+		cache[name] = value
+		return value, nil
+	}
+	for key, value := range env {
+		expanded, err := config.ExpandSecrets(value, lookup)
+		if err != nil {
+			return nil, fmt.Errorf("env %s: %w", key, err)
+		}
+		resolved[key] = expanded
+	}
+	return resolved, nil
+}
+
+func readSecret(name, command, dir string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), secretCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	switch {
+	case command != "":
+		cmd = exec.CommandContext(ctx, serviceShell(), "-c", command)
+		cmd.Dir = dir
+		cmd.Env = setEnv(buildServiceEnvironment(nil, "", 0), "HUN_SECRET", name)
+	case runtime.GOOS == "darwin":
+		cmd = exec.CommandContext(ctx, "security", "find-generic-password", "-s", name, "-w")
+	default:
+		return "", fmt.Errorf("no secret_command configured in .hun.yml or ~/.hun/config.yml")
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
@@ -29,7 +29,8 @@ func NewSubscriberManager() *SubscriberManager {
 	}
 }
 
-// Subscribe creates a new subscriber for the given project/service.
+// Subscribe creates a new subscriber for the given project/service. An empty
+// project follows every project and an empty service every service.
 func (sm *SubscriberManager) Subscribe(project, service string) *Subscriber {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
@@ -44,6 +45,13 @@ func (sm *SubscriberManager) Subscribe(project, service string) *Subscriber {
 	return sub
 }
 
+// Count returns how many subscribers are attached.
+func (sm *SubscriberManager) Count() int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return len(sm.subscribers)
+}
+
 // Unsubscribe removes a subscriber.
 func (sm *SubscriberManager) Unsubscribe(id int) {
 	sm.mu.Lock()
@@ -59,7 +67,7 @@ func (sm *SubscriberManager) Broadcast(line LogLine) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 	for _, sub := range sm.subscribers {
-		if sub.Project != line.Project {
+		if sub.Project != "" && sub.Project != line.Project {
 			continue
 		}
 		if sub.Service != "" && sub.Service != line.Service {
@@ -72,6 +80,7 @@ func (sm *SubscriberManager) Broadcast(line LogLine) {
 				Project:   line.Project,
 				Service:   line.Service,
 				IsErr:     true,
+				IsMeta:    true,
 				Text:      fmt.Sprintf("hun: dropped %d log lines due to slow subscriber", sub.dropped),
 			}
 			select {
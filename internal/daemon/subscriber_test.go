@@ -36,3 +36,21 @@ func TestSubscriberManagerEmitsDroppedWarning(t *testing.T) {
 	}
 	t.Fatal("timed out waiting for dropped warning")
 }
+
+func TestSubscriberWithoutProjectReceivesEveryProject(t *testing.T) {
+	sm := NewSubscriberManager()
+	all := sm.Subscribe("", "")
+	defer sm.Unsubscribe(all.ID)
+	shop := sm.Subscribe("shop", "")
+	defer sm.Unsubscribe(shop.ID)
+
+	sm.Broadcast(LogLine{Project: "shop", Service: "api", Text: "a"})
+	sm.Broadcast(LogLine{Project: "blog", Service: "web", Text: "b"})
+
+	if got := len(all.Ch); got != 2 {
+		t.Fatalf("all-projects subscriber got %d lines, want 2", got)
+	}
+	if got := len(shop.Ch); got != 1 {
+		t.Fatalf("shop subscriber got %d lines, want 1", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -18,9 +19,12 @@ type composeFile struct {
 }
 
 type composeService struct {
-	Image     string      `yaml:"image"`
-	Ports     interface{} `yaml:"ports"`
-	DependsOn interface{} `yaml:"depends_on"`
+	Image       string      `yaml:"image"`
+	Ports       interface{} `yaml:"ports"`
+	DependsOn   interface{} `yaml:"depends_on"`
+	Environment interface{} `yaml:"environment"`
+	EnvFile     interface{} `yaml:"env_file"`
+	Profiles    []string    `yaml:"profiles"`
 }
 
 func (d *ComposeDetector) Detect(dir string) []DetectedService {
@@ -29,13 +33,8 @@ func (d *ComposeDetector) Detect(dir string) []DetectedService {
 		return nil
 	}
 
-	data, err := os.ReadFile(composePath)
-	if err != nil {
-		return nil
-	}
-
-	var cf composeFile
-	if err := yaml.Unmarshal(data, &cf); err != nil {
+	cf, ok := loadCompose(composePath)
+	if !ok {
 		return nil
 	}
 
@@ -49,6 +48,11 @@ func (d *ComposeDetector) Detect(dir string) []DetectedService {
 	for _, name := range names {
 		svc := cf.Services[name]
 		port := parseComposePort(svc.Ports)
+		portEnv := ""
+		portConfidence := composePortConfidence(port)
+		if port == 0 {
+			port, portEnv, portConfidence = composeEnvPort(filepath.Dir(composePath), svc)
+		}
 		ready := guessReadyPattern(svc.Image)
 		dependsOn := parseComposeDependsOn(svc.DependsOn)
 		class := "app"
@@ -60,7 +64,7 @@ func (d *ComposeDetector) Detect(dir string) []DetectedService {
 			LogicalName:    name,
 			Cmd:            "docker compose up " + name,
 			Port:           port,
-			PortEnv:        "",
+			PortEnv:        portEnv,
 			Ready:          ready,
 			DependsOn:      dependsOn,
 			Runtime:        "compose",
@@ -68,7 +72,8 @@ func (d *ComposeDetector) Detect(dir string) []DetectedService {
 			Class:          class,
 			Source:         filepath.ToSlash(composePath),
 			Confidence:     0.9,
-			PortConfidence: composePortConfidence(port),
+			PortConfidence: portConfidence,
+			Profiles:       svc.Profiles,
 		})
 	}
 
@@ -85,6 +90,136 @@ func findComposeFile(dir string) string {
 	return ""
 }
 
+// findComposeOverride returns the override file docker compose loads
+// alongside base, e.g. docker-compose.override.yml next to
+// docker-compose.yml, or "" when there is none.
+func findComposeOverride(base string) string {
+	stem := strings.TrimSuffix(filepath.Base(base), filepath.Ext(base))
+	for _, ext := range []string{".yml", ".yaml"} {
+		p := filepath.Join(filepath.Dir(base), stem+".override"+ext)
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
+// loadCompose reads the compose file at path with its override file, if
+// any, merged in.
+func loadCompose(path string) (composeFile, bool) {
+	cf, ok := readComposeFile(path)
+	if !ok {
+		return composeFile{}, false
+	}
+	if overridePath := findComposeOverride(path); overridePath != "" {
+		if override, ok := readComposeFile(overridePath); ok {
+			cf.Services = mergeComposeServices(cf.Services, override.Services)
+		}
+	}
+	return cf, true
+}
+
+// ComposeEnvFiles returns the env_file paths named by the compose file in
+// dir, relative to dir and sorted, so a caller mirroring the project knows
+// which env files port inference reads.
+func ComposeEnvFiles(dir string) []string {
+	composePath := findComposeFile(dir)
+	if composePath == "" {
+		return nil
+	}
+	cf, ok := loadCompose(composePath)
+	if !ok {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var files []string
+	for _, svc := range cf.Services {
+		for _, file := range composeEnvFiles(svc.EnvFile) {
+			file = filepath.ToSlash(filepath.Clean(file))
+			if !seen[file] {
+				seen[file] = true
+				files = append(files, file)
+			}
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+func readComposeFile(path string) (composeFile, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return composeFile{}, false
+	}
+	var cf composeFile
+	if err := yaml.Unmarshal(data, &cf); err != nil {
+		return composeFile{}, false
+	}
+	return cf, true
+}
+
+// mergeComposeServices layers override onto base: each field an override
+// service sets replaces the base one, except environment, which is merged
+// key by key as compose does. Services only in override are added. Neither
+// input is modified.
+func mergeComposeServices(base, override map[string]composeService) map[string]composeService {
+	merged := make(map[string]composeService, len(base)+len(override))
+	for name, svc := range base {
+		merged[name] = svc
+	}
+	for name, o := range override {
+		svc := merged[name]
+		if o.Image != "" {
+			svc.Image = o.Image
+		}
+		if o.Ports != nil {
+			svc.Ports = o.Ports
+		}
+		if o.DependsOn != nil {
+			svc.DependsOn = o.DependsOn
+		}
+		if o.Environment != nil {
+			svc.Environment = mergeComposeEnvironment(svc.Environment, o.Environment)
+		}
+		if o.EnvFile != nil {
+			svc.EnvFile = o.EnvFile
+		}
+		if o.Profiles != nil {
+			svc.Profiles = o.Profiles
+		}
+		merged[name] = svc
+	}
+	return merged
+}
+
+// mergeComposeEnvironment combines two environment sections, either of which
+// may be a KEY=value list or a map, into a map where override's keys win.
+func mergeComposeEnvironment(base, override interface{}) interface{} {
+	merged := make(map[string]interface{})
+	for _, env := range []interface{}{base, override} {
+		switch env := env.(type) {
+		case []interface{}:
+			for _, item := range env {
+				s, ok := item.(string)
+				if !ok {
+					continue
+				}
+				key, value, hasValue := strings.Cut(s, "=")
+				if hasValue {
+					merged[strings.TrimSpace(key)] = value
+				} else {
+					merged[strings.TrimSpace(key)] = nil // passed through from the host
+				}
+			}
+		case map[string]interface{}:
+			for key, value := range env {
+				merged[key] = value
+			}
+		}
+	}
+	return merged
+}
+
 func composePortConfidence(port int) float64 {
 	if port <= 0 {
 		return 0
@@ -92,6 +227,109 @@ func composePortConfidence(port int) float64 {
 	return 0.95
 }
 
+// composeEnvPortConfidence is lower than a published port: the variable
+// names the port inside the container, which may not be published as is.
+const composeEnvPortConfidence = 0.7
+
+// composeEnvPort infers a service's port from a PORT-like variable in its
+// env_file entries or inline environment, the latter taking precedence as in
+// compose itself. When the value is an interpolation such as ${PORT:-3000},
+// the interpolated variable is returned as the port env so hun can set it.
+func composeEnvPort(dir string, svc composeService) (port int, portEnv string, confidence float64) {
+	var vars portVars
+	interpolated := make(map[string]string)
+	set := func(key, value string) {
+		p, from := parseComposeEnvValue(value)
+		if p == 0 {
+			return
+		}
+		vars.add(key, p)
+		if from != "" {
+			interpolated[strings.ToUpper(key)] = from
+		} else {
+			delete(interpolated, strings.ToUpper(key))
+		}
+	}
+
+	for _, file := range composeEnvFiles(svc.EnvFile) {
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if m := dotEnvAssignRe.FindStringSubmatch(strings.TrimSpace(line)); len(m) == 3 {
+				set(m[1], m[2])
+			}
+		}
+	}
+	switch env := svc.Environment.(type) {
+	case []interface{}:
+		for _, item := range env {
+			s, ok := item.(string)
+			if !ok {
+				continue
+			}
+			if key, value, ok := strings.Cut(s, "="); ok {
+				set(strings.TrimSpace(key), value)
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(env))
+		for key := range env {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if env[key] != nil {
+				set(key, fmt.Sprintf("%v", env[key]))
+			}
+		}
+	}
+
+	p, key, ok := vars.listenPort()
+	if !ok {
+		return 0, "", 0
+	}
+	return p, interpolated[key], composeEnvPortConfidence
+}
+
+// composeEnvFiles lists the paths of an env_file entry, which compose accepts
+// as a string, a list of strings, or a list of {path: ...} maps.
+func composeEnvFiles(raw interface{}) []string {
+	switch v := raw.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var files []string
+		for _, item := range v {
+			switch f := item.(type) {
+			case string:
+				files = append(files, f)
+			case map[string]interface{}:
+				if path, ok := f["path"].(string); ok {
+					files = append(files, path)
+				}
+			}
+		}
+		return files
+	default:
+		return nil
+	}
+}
+
+// parseComposeEnvValue reads a port from an environment value, either a
+// literal or an interpolation with a default like ${PORT:-3000}, in which
+// case from is the interpolated variable.
+func parseComposeEnvValue(value string) (port int, from string) {
+	value = strings.Trim(strings.TrimSpace(value), `"'`)
+	if m := composeInterpolationRe.FindStringSubmatch(value); m != nil {
+		return parsePort(m[2]), m[1]
+	}
+	return parsePort(value), ""
+}
+
+var composeInterpolationRe = regexp.MustCompile(`^\$\{([A-Za-z_][A-Za-z0-9_]*):?-([^}]*)\}$`)
+
 func parseComposeDependsOn(raw interface{}) []string {
 	switch v := raw.(type) {
 	case []interface{}:
@@ -0,0 +1,169 @@
+package detect
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DenoDetector detects Deno apps via the tasks in deno.json or deno.jsonc.
+type DenoDetector struct{}
+
+// denoDefaultPort is where Deno.serve listens when no port is given.
+const denoDefaultPort = 8000
+
+func (d *DenoDetector) Detect(dir string) []DetectedService {
+	path := findDenoConfig(dir)
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var cfg struct {
+		Name  string                     `json:"name"`
+		Tasks map[string]json.RawMessage `json:"tasks"`
+	}
+	if err := json.Unmarshal(stripJSONC(data), &cfg); err != nil {
+		return nil
+	}
+	tasks := denoTaskCommands(cfg.Tasks)
+	task, body, ok := selectPrimaryScript(tasks)
+	if !ok {
+		return nil
+	}
+
+	name := filepath.Base(dir)
+	if cfg.Name != "" {
+		// JSR names are scoped, e.g. @acme/api.
+		name = cfg.Name[strings.LastIndex(cfg.Name, "/")+1:]
+	}
+	name = normalizeServiceName(name)
+	if name == "" {
+		return nil
+	}
+
+	port, portEnv := inferExplicitPort(body)
+	portConfidence := 0.9
+	if port == 0 {
+		port, portConfidence = denoDefaultPort, 0.3
+	}
+	ready := "Listening on"
+	if lower := strings.ToLower(body); strings.Contains(lower, "vite") || strings.Contains(lower, "next dev") {
+		ready = readyPatternForScript(body, name)
+	}
+
+	return []DetectedService{
+		{
+			Name:           name,
+			LogicalName:    name,
+			Cmd:            "deno task " + task,
+			Port:           port,
+			PortEnv:        portEnv,
+			Ready:          ready,
+			Runtime:        "deno",
+			Strategy:       "local",
+			Class:          "app",
+			Source:         filepath.ToSlash(path),
+			Confidence:     0.8,
+			PortConfidence: portConfidence,
+		},
+	}
+}
+
+func findDenoConfig(dir string) string {
+	for _, name := range []string{"deno.json", "deno.jsonc"} {
+		path := filepath.Join(dir, name)
+		if fileExists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// denoTaskCommands flattens deno.json tasks, which are either a command
+// string or an object with a "command" field.
+func denoTaskCommands(raw map[string]json.RawMessage) map[string]string {
+	tasks := make(map[string]string, len(raw))
+	for name, value := range raw {
+		var cmd string
+		if err := json.Unmarshal(value, &cmd); err != nil {
+			var task struct {
+				Command string `json:"command"`
+			}
+			if json.Unmarshal(value, &task) != nil {
+				continue
+			}
+			cmd = task.Command
+		}
+		tasks[name] = cmd
+	}
+	return tasks
+}
+
+// stripJSONC turns JSONC into JSON by dropping // and /* */ comments and
+// trailing commas outside strings.
+func stripJSONC(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				out = append(out, '\n')
+			}
+		case c == ',' && closesNext(data[i+1:]):
+			// trailing comma: dropped
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := strings.Index(string(data[i+2:]), "*/")
+			if end < 0 {
+				return out
+			}
+			i += end + 3
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// closesNext reports whether the next byte that isn't whitespace or part of a
+// comment closes an object or array.
+func closesNext(rest []byte) bool {
+	for i := 0; i < len(rest); i++ {
+		switch c := rest[i]; {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+		case c == '/' && i+1 < len(rest) && rest[i+1] == '/':
+			for i < len(rest) && rest[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(rest) && rest[i+1] == '*':
+			end := bytes.Index(rest[i+2:], []byte("*/"))
+			if end < 0 {
+				return false
+			}
+			i += end + 3
+		default:
+			return c == '}' || c == ']'
+		}
+	}
+	return false
+}
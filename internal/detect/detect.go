@@ -1,6 +1,7 @@
 package detect
 
 import (
+	"slices"
 	"sort"
 	"strings"
 )
@@ -14,13 +15,14 @@ type DetectedService struct {
 	PortEnv        string
 	Ready          string
 	DependsOn      []string
-	Runtime        string  // node, python, go, compose
-	Source         string  // source file/path used for detection
-	LogicalName    string  // canonical name used for profile conflict resolution
-	Strategy       string  // local, compose
-	Class          string  // app, infra
-	Confidence     float64 // service detection confidence
-	PortConfidence float64 // port inference confidence
+	Runtime        string   // node, deno, python, go, rust, make, procfile, compose
+	Source         string   // source file/path used for detection
+	LogicalName    string   // canonical name used for profile conflict resolution
+	Strategy       string   // local, compose
+	Class          string   // app, infra
+	Confidence     float64  // service detection confidence
+	PortConfidence float64  // port inference confidence
+	Profiles       []string // compose profiles gating the service, if any
 }
 
 // Result holds all detected services for a directory.
@@ -28,6 +30,8 @@ type Result struct {
 	Services  []DetectedService
 	Conflicts []Conflict
 	Profile   string
+	// ComposeProfiles are the compose profiles whose services were included.
+	ComposeProfiles []string
 }
 
 // Conflict captures local/compose ambiguity for a logical service.
@@ -38,7 +42,8 @@ type Conflict struct {
 
 // Options controls detection resolution behavior.
 type Options struct {
-	Profile string // local, compose, hybrid
+	Profile         string   // local, compose, hybrid
+	ComposeProfiles []string // compose profiles to include; profile-less services always are
 }
 
 // Analysis is the raw pre-resolution output of all detectors.
@@ -72,18 +77,36 @@ func NormalizeProfile(profile string) string {
 	}
 }
 
+// ProgressFunc receives a short description of the detection step about to run.
+type ProgressFunc func(step string)
+
 // Analyze executes all detectors against a directory and returns unresolved candidates.
 func Analyze(dir string) Analysis {
-	detectors := []Detector{
-		&ComposeDetector{},
-		&NodeDetector{},
-		&GoDetector{},
-		&PythonDetector{},
+	return AnalyzeWithProgress(dir, nil)
+}
+
+// AnalyzeWithProgress is Analyze with a callback invoked before each detector runs.
+func AnalyzeWithProgress(dir string, progress ProgressFunc) Analysis {
+	detectors := []struct {
+		step     string
+		detector Detector
+	}{
+		{"reading compose", &ComposeDetector{}},
+		{"scanning workspaces", &NodeDetector{}},
+		{"reading deno.json", &DenoDetector{}},
+		{"checking go modules", &GoDetector{}},
+		{"reading Cargo.toml", &RustDetector{}},
+		{"checking python entrypoints", &PythonDetector{}},
+		{"reading Makefile", &MakefileDetector{}},
+		{"reading Procfile", &ProcfileDetector{}},
 	}
 
 	var candidates []DetectedService
 	for _, d := range detectors {
-		candidates = append(candidates, d.Detect(dir)...)
+		if progress != nil {
+			progress(d.step)
+		}
+		candidates = append(candidates, d.detector.Detect(dir)...)
 	}
 
 	sort.Slice(candidates, func(i, j int) bool {
@@ -153,7 +176,39 @@ func Resolve(analysis Analysis, profile string) Result {
 
 // Run executes all detectors and resolves services for the selected profile.
 func Run(dir string, opts Options) Result {
-	return Resolve(Analyze(dir), opts.Profile)
+	result := Resolve(WithComposeProfiles(Analyze(dir), opts.ComposeProfiles), opts.Profile)
+	result.ComposeProfiles = opts.ComposeProfiles
+	return result
+}
+
+// ComposeProfiles lists the compose profiles candidates are gated behind,
+// sorted and without duplicates.
+func ComposeProfiles(analysis Analysis) []string {
+	seen := make(map[string]bool)
+	var profiles []string
+	for _, svc := range analysis.Candidates {
+		for _, p := range svc.Profiles {
+			if !seen[p] {
+				seen[p] = true
+				profiles = append(profiles, p)
+			}
+		}
+	}
+	sort.Strings(profiles)
+	return profiles
+}
+
+// WithComposeProfiles drops candidates gated behind compose profiles that
+// are not in selected, the way docker compose up would skip them, and
+// recomputes conflicts for what remains.
+func WithComposeProfiles(analysis Analysis, selected []string) Analysis {
+	kept := make([]DetectedService, 0, len(analysis.Candidates))
+	for _, svc := range analysis.Candidates {
+		if len(svc.Profiles) == 0 || slices.ContainsFunc(svc.Profiles, func(p string) bool { return slices.Contains(selected, p) }) {
+			kept = append(kept, svc)
+		}
+	}
+	return Analysis{Candidates: kept, Conflicts: detectConflicts(kept)}
 }
 
 func detectConflicts(candidates []DetectedService) []Conflict {
@@ -1,6 +1,7 @@
 package detect
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -148,6 +149,40 @@ func TestUnknownNodePortDoesNotDefaultTo3000(t *testing.T) {
 	}
 }
 
+func TestNodePortFromDotEnvRanksBetweenScriptAndDefault(t *testing.T) {
+	dir := t.TempDir()
+	mustWrite(t, filepath.Join(dir, "api", "package.json"), `{"name": "api", "scripts": {"dev": "node server.js"}}`)
+	mustWrite(t, filepath.Join(dir, "api", ".env"), "DB_PORT=5432\nexport API_PORT=\"4000\" # local\n")
+	mustWrite(t, filepath.Join(dir, "web", "package.json"), `{"name": "web", "scripts": {"dev": "next dev"}}`)
+	mustWrite(t, filepath.Join(dir, "web", ".env"), "PORT=3100\n")
+	mustWrite(t, filepath.Join(dir, "web", ".env.local"), "PORT=3200\n")
+	mustWrite(t, filepath.Join(dir, "admin", "package.json"), `{"name": "admin", "scripts": {"dev": "next dev -p 3300"}}`)
+	mustWrite(t, filepath.Join(dir, "admin", ".env"), "PORT=3400\n")
+
+	tests := []struct {
+		dir  string
+		port int
+		env  string
+	}{
+		{"api", 4000, "API_PORT"},
+		{"web", 3200, "PORT"},
+		{"admin", 3300, ""},
+	}
+	for _, tc := range tests {
+		result := Run(filepath.Join(dir, tc.dir), Options{Profile: ProfileHybrid})
+		if len(result.Services) != 1 {
+			t.Fatalf("%s: expected one service, got %d", tc.dir, len(result.Services))
+		}
+		svc := result.Services[0]
+		if svc.Port != tc.port {
+			t.Fatalf("%s: port = %d, want %d", tc.dir, svc.Port, tc.port)
+		}
+		if tc.env != "" && svc.PortEnv != tc.env {
+			t.Fatalf("%s: port env = %q, want %q", tc.dir, svc.PortEnv, tc.env)
+		}
+	}
+}
+
 func TestComposePortParsingHandlesIpHostContainer(t *testing.T) {
 	dir := t.TempDir()
 	mustWrite(t, filepath.Join(dir, "docker-compose.yml"), `services:
@@ -163,6 +198,349 @@ func TestComposePortParsingHandlesIpHostContainer(t *testing.T) {
 	}
 }
 
+func TestComposeInfersPortFromEnvironmentAndEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	mustWrite(t, filepath.Join(dir, "docker-compose.yml"), `services:
+  api:
+    image: node:20
+    environment:
+      PORT: ${API_PORT:-4100}
+      LOG_LEVEL: debug
+  web:
+    image: node:20
+    environment:
+      - NODE_ENV=development
+      - PORT=3100
+  worker:
+    image: python:3.12
+    env_file:
+      - path: ./worker.env
+  docs:
+    image: nginx
+    env_file: docs.env
+    environment:
+      HTTP_PORT: 8088
+`)
+	mustWrite(t, filepath.Join(dir, "worker.env"), "REDIS_PORT=6379\nWORKER_PORT=7100\n")
+	mustWrite(t, filepath.Join(dir, "docs.env"), "HTTP_PORT=8080\n")
+
+	byName := toMap((&ComposeDetector{}).Detect(dir))
+	for name, want := range map[string]struct {
+		port int
+		env  string
+	}{
+		"api":    {4100, "API_PORT"},
+		"web":    {3100, ""},
+		"worker": {7100, ""},
+		"docs":   {8088, ""},
+	} {
+		got := byName[name]
+		if got.Port != want.port || got.PortEnv != want.env {
+			t.Fatalf("%s port = %d env %q, want %d env %q", name, got.Port, got.PortEnv, want.port, want.env)
+		}
+		if got.PortConfidence != composeEnvPortConfidence {
+			t.Fatalf("%s port confidence = %v, want %v", name, got.PortConfidence, composeEnvPortConfidence)
+		}
+	}
+}
+
+func TestComposeOverrideFileWinsForPortsAndImage(t *testing.T) {
+	dir := t.TempDir()
+	mustWrite(t, filepath.Join(dir, "docker-compose.yml"), `services:
+  api:
+    image: node:18
+    ports:
+      - "3000:3000"
+    depends_on: [db]
+  db:
+    image: postgres:15
+    ports:
+      - "5432:5432"
+`)
+	mustWrite(t, filepath.Join(dir, "docker-compose.override.yml"), `services:
+  api:
+    image: node:20
+    ports:
+      - "3300:3000"
+  mailhog:
+    image: mailhog/mailhog
+    ports:
+      - "8025:8025"
+`)
+
+	services := (&ComposeDetector{}).Detect(dir)
+	var names []string
+	for _, svc := range services {
+		names = append(names, svc.Name)
+	}
+	if got := strings.Join(names, ","); got != "api,db,mailhog" {
+		t.Fatalf("services = %s, want api,db,mailhog", got)
+	}
+	byName := toMap(services)
+	if api := byName["api"]; api.Port != 3300 || len(api.DependsOn) != 1 {
+		t.Fatalf("api = port %d depends_on %v, want override port 3300 and base depends_on", api.Port, api.DependsOn)
+	}
+	if byName["db"].Port != 5432 || byName["mailhog"].Port != 8025 {
+		t.Fatalf("db/mailhog ports = %d/%d, want 5432/8025", byName["db"].Port, byName["mailhog"].Port)
+	}
+}
+
+func TestComposeOverrideMergesEnvironmentByKey(t *testing.T) {
+	dir := t.TempDir()
+	mustWrite(t, filepath.Join(dir, "docker-compose.yml"), `services:
+  worker:
+    image: node:20
+    environment:
+      - PORT=4100
+      - LOG_LEVEL=info
+`)
+	mustWrite(t, filepath.Join(dir, "docker-compose.override.yml"), `services:
+  worker:
+    environment:
+      LOG_LEVEL: debug
+`)
+
+	byName := toMap((&ComposeDetector{}).Detect(dir))
+	if got := byName["worker"].Port; got != 4100 {
+		t.Fatalf("worker port = %d, want the base PORT kept beside the override's LOG_LEVEL", got)
+	}
+	merged := mergeComposeEnvironment([]interface{}{"PORT=4100", "TOKEN"}, map[string]interface{}{"PORT": 4200})
+	if env := merged.(map[string]interface{}); env["PORT"] != 4200 || len(env) != 2 {
+		t.Fatalf("merged environment = %v, want override PORT and the pass-through TOKEN", env)
+	}
+}
+
+func TestComposeProfilesGateServices(t *testing.T) {
+	dir := t.TempDir()
+	mustWrite(t, filepath.Join(dir, "compose.yml"), `services:
+  db:
+    image: postgres:16
+  mailhog:
+    image: mailhog/mailhog
+    profiles: [debug]
+  grafana:
+    image: grafana/grafana
+    profiles: ["metrics", "debug"]
+  loader:
+    image: busybox
+    profiles: [seed]
+`)
+
+	if got := strings.Join(ComposeProfiles(Analyze(dir)), ","); got != "debug,metrics,seed" {
+		t.Fatalf("compose profiles = %s, want debug,metrics,seed", got)
+	}
+	for _, tc := range []struct {
+		profiles []string
+		want     string
+	}{
+		{nil, "db"},
+		{[]string{"debug"}, "db,grafana,mailhog"},
+		{[]string{"metrics", "seed"}, "db,grafana,loader"},
+	} {
+		result := Run(dir, Options{Profile: ProfileCompose, ComposeProfiles: tc.profiles})
+		var names []string
+		for _, svc := range result.Services {
+			names = append(names, svc.Name)
+		}
+		if got := strings.Join(names, ","); got != tc.want {
+			t.Fatalf("profiles %v: services = %s, want %s", tc.profiles, got, tc.want)
+		}
+	}
+}
+
+func TestMakefileDetectorFindsServerTargets(t *testing.T) {
+	dir := t.TempDir()
+	mustWrite(t, filepath.Join(dir, "Makefile"), ".PHONY: dev worker build test clean\n"+
+		"GOFLAGS := -trimpath\n"+
+		"\n"+
+		"dev:\n"+
+		"\tgo run ./cmd/api --port 9090\n"+
+		"\n"+
+		"worker: deps\n"+
+		"\tpython -m app.worker\n"+
+		"\n"+
+		"build:\n"+
+		"\tgo build ./...\n"+
+		"\n"+
+		"test:\n"+
+		"\tgo test ./...\n"+
+		"\n"+
+		"clean:\n"+
+		"\trm -rf bin\n")
+
+	byName := toMap(Run(dir, Options{Profile: ProfileHybrid}).Services)
+	if len(byName) != 2 {
+		t.Fatalf("expected dev and worker services, got %v", keys(byName))
+	}
+	dev := byName["dev"]
+	if dev.Cmd != "make dev" || dev.Port != 9090 || dev.Runtime != "make" {
+		t.Fatalf("dev = %+v, want make dev on 9090", dev)
+	}
+	if byName["worker"].Cmd != "make worker" {
+		t.Fatalf("worker cmd = %q, want make worker", byName["worker"].Cmd)
+	}
+}
+
+func TestDenoDetectorUsesPrimaryTaskFromJSONC(t *testing.T) {
+	dir := t.TempDir()
+	mustWrite(t, filepath.Join(dir, "deno.jsonc"), `{
+  // JSR package name
+  "name": "@acme/api",
+  "tasks": {
+    "dev": "deno run --watch --allow-net main.ts --port 9000", /* local */
+    "check": { "command": "deno check main.ts", "description": "types" }, // lint next
+  },
+  "lock": false, /* kept
+  out of git */
+}`)
+
+	byName := toMap(Run(dir, Options{Profile: ProfileHybrid}).Services)
+	api, ok := byName["api"]
+	if !ok || len(byName) != 1 {
+		t.Fatalf("expected one api service, got %v", keys(byName))
+	}
+	if api.Cmd != "deno task dev" || api.Runtime != "deno" || api.Port != 9000 || api.Ready != "Listening on" {
+		t.Fatalf("api = %+v, want deno task dev on 9000", api)
+	}
+
+	other := t.TempDir()
+	mustWrite(t, filepath.Join(other, "deno.json"), `{"tasks": {"start": {"command": "deno run -A server.ts"}}}`)
+	got := (&DenoDetector{}).Detect(other)
+	if len(got) != 1 || got[0].Cmd != "deno task start" || got[0].Port != denoDefaultPort {
+		t.Fatalf("object task = %+v, want deno task start on the default port", got)
+	}
+}
+
+func TestRustDetectorRunsCargoWithFrameworkPort(t *testing.T) {
+	orig := lookPath
+	t.Cleanup(func() { lookPath = orig })
+	lookPath = func(string) (string, error) { return "", errors.New("not found") }
+
+	dir := t.TempDir()
+	mustWrite(t, filepath.Join(dir, "Cargo.toml"), "[package]\nname = \"ledger_api\"\nversion = \"0.1.0\"\n\n[dependencies]\nactix-web = \"4\"\n")
+	mustWrite(t, filepath.Join(dir, "src", "main.rs"), "fn main() {}\n")
+
+	byName := toMap(Run(dir, Options{Profile: ProfileHybrid}).Services)
+	api, ok := byName["ledger-api"]
+	if !ok || len(byName) != 1 {
+		t.Fatalf("expected one ledger-api service, got %v", keys(byName))
+	}
+	if api.Cmd != "cargo run" || api.Runtime != "rust" || api.Port != 8080 {
+		t.Fatalf("ledger-api = %+v, want cargo run on actix's 8080", api)
+	}
+
+	lookPath = func(string) (string, error) { return "/usr/local/bin/cargo-watch", nil }
+	mustWrite(t, filepath.Join(dir, ".env"), "PORT=9100\n")
+	got := (&RustDetector{}).Detect(dir)
+	if len(got) != 1 || got[0].Cmd != "cargo watch -x run" || got[0].Port != 9100 || got[0].PortEnv != "PORT" {
+		t.Fatalf("with cargo-watch and .env = %+v", got)
+	}
+
+	lib := t.TempDir()
+	mustWrite(t, filepath.Join(lib, "Cargo.toml"), "[package]\nname = \"util\"\n")
+	mustWrite(t, filepath.Join(lib, "src", "lib.rs"), "pub fn f() {}\n")
+	if got := (&RustDetector{}).Detect(lib); len(got) != 0 {
+		t.Fatalf("library crate detected as a service: %+v", got)
+	}
+}
+
+func TestProcfileDetectorEmitsOneServicePerProcess(t *testing.T) {
+	dir := t.TempDir()
+	mustWrite(t, filepath.Join(dir, "Procfile"), "# foreman\n"+
+		"web: bundle exec rails server -p $PORT\n"+
+		"worker: bundle exec sidekiq\n"+
+		"release: bin/rails db:migrate\n"+
+		"assets: yarn build --watch --port 3035\n")
+
+	byName := toMap(Run(dir, Options{Profile: ProfileHybrid}).Services)
+	if len(byName) != 3 {
+		t.Fatalf("expected web, worker, and assets, got %v", keys(byName))
+	}
+	web := byName["web"]
+	if web.Cmd != "bundle exec rails server -p $PORT" || web.Port != 5000 || web.PortEnv != "PORT" || web.Strategy != "local" {
+		t.Fatalf("web = %+v, want foreman's first $PORT", web)
+	}
+	if byName["worker"].Cmd != "bundle exec sidekiq" || byName["worker"].Port != 0 {
+		t.Fatalf("worker = %+v", byName["worker"])
+	}
+	if byName["assets"].Port != 3035 {
+		t.Fatalf("assets port = %d, want 3035", byName["assets"].Port)
+	}
+}
+
+func TestProcfileServiceConflictsWithComposeService(t *testing.T) {
+	dir := t.TempDir()
+	mustWrite(t, filepath.Join(dir, "Procfile"), "web: python app.py --port 8000\n")
+	mustWrite(t, filepath.Join(dir, "compose.yml"), "services:\n  web:\n    image: shop-web\n    ports:\n      - \"8000:8000\"\n")
+
+	conflicts := Analyze(dir).Conflicts
+	if len(conflicts) != 1 || conflicts[0].Name != "web" {
+		t.Fatalf("expected a web conflict between Procfile and compose, got %+v", conflicts)
+	}
+}
+
+func TestMakeDevTakesOverGoServiceItRuns(t *testing.T) {
+	dir := t.TempDir()
+	mustWrite(t, filepath.Join(dir, "go.mod"), "module github.com/acme/ledger\n\ngo 1.22\n")
+	mustWrite(t, filepath.Join(dir, "main.go"), "package main\n\nfunc main() {}\n")
+	mustWrite(t, filepath.Join(dir, "Makefile"), "dev:\n\tgo run . --port 9090\n\nbuild:\n\tgo build ./...\n")
+
+	byName := toMap(Run(dir, Options{Profile: ProfileHybrid}).Services)
+	if len(byName) != 1 {
+		t.Fatalf("expected make dev to replace go run, got %v", keys(byName))
+	}
+	ledger := byName["ledger"]
+	if ledger.Cmd != "make dev" || ledger.Runtime != "make" || ledger.Port != 9090 {
+		t.Fatalf("ledger = %+v, want make dev on 9090", ledger)
+	}
+
+	// A Makefile without a run target leaves the Go service alone.
+	mustWrite(t, filepath.Join(dir, "Makefile"), "build:\n\tgo build ./...\n")
+	byName = toMap(Run(dir, Options{Profile: ProfileHybrid}).Services)
+	if len(byName) != 1 || byName["ledger"].Cmd != "go run ." {
+		t.Fatalf("expected go run . without a make run target, got %+v", byName)
+	}
+}
+
+func TestPnpmWorkspaceYamlAddsWorkspacePackages(t *testing.T) {
+	dir := t.TempDir()
+	mustWrite(t, filepath.Join(dir, "package.json"), `{"name": "shop", "workspaces": ["apps/*"]}`)
+	mustWrite(t, filepath.Join(dir, "pnpm-workspace.yaml"), "packages:\n  - apps/*\n  - services/*\n  - '!services/legacy'\n")
+	mustWrite(t, filepath.Join(dir, "apps", "web", "package.json"), `{"name": "web", "scripts": {"dev": "vite --port 5173"}}`)
+	mustWrite(t, filepath.Join(dir, "services", "api", "package.json"), `{"name": "api", "scripts": {"dev": "node server.js --port 4000"}}`)
+	mustWrite(t, filepath.Join(dir, "services", "legacy", "package.json"), `{"name": "legacy", "scripts": {"dev": "node old.js"}}`)
+	mustWrite(t, filepath.Join(dir, "services", "node_modules", "dep", "package.json"), `{"name": "dep", "scripts": {"start": "node x.js"}}`)
+
+	got := resolveWorkspaceDirs(dir, []byte(`["apps/*"]`), "pnpm")
+	want := []string{filepath.Join(dir, "apps", "web"), filepath.Join(dir, "services", "api")}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("pnpm workspace dirs = %v, want %v", got, want)
+	}
+
+	byName := toMap(Run(dir, Options{Profile: ProfileHybrid}).Services)
+	if byName["api"].Cmd != "pnpm run dev" || byName["web"].Cmd != "pnpm run dev" {
+		t.Fatalf("expected pnpm workspace services, got %+v", byName)
+	}
+	if _, ok := byName["legacy"]; ok {
+		t.Fatal("excluded pnpm workspace package was detected")
+	}
+
+	// Without pnpm, the yaml is ignored.
+	if got := resolveWorkspaceDirs(dir, []byte(`["apps/*"]`), "npm"); len(got) != 1 {
+		t.Fatalf("npm workspace dirs = %v, want only apps/web", got)
+	}
+}
+
+func TestAnalyzeWithProgressReportsEachStep(t *testing.T) {
+	var steps []string
+	AnalyzeWithProgress(t.TempDir(), func(step string) {
+		steps = append(steps, step)
+	})
+	if len(steps) != 8 || steps[0] != "reading compose" || steps[1] != "scanning workspaces" {
+		t.Fatalf("unexpected progress steps: %v", steps)
+	}
+}
+
 func mustWrite(t *testing.T, path, content string) {
 	t.Helper()
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
@@ -188,3 +566,35 @@ func keys(m map[string]DetectedService) []string {
 	}
 	return out
 }
+
+func TestLiveReloadRunnersArePreferred(t *testing.T) {
+	dir := t.TempDir()
+	mustWrite(t, filepath.Join(dir, "api", "go.mod"), "module example.com/api\n\ngo 1.22\n")
+	mustWrite(t, filepath.Join(dir, "api", "main.go"), "package main\n")
+	mustWrite(t, filepath.Join(dir, "api", ".air.toml"), "root = \".\"\n")
+	mustWrite(t, filepath.Join(dir, "jobs", "package.json"), `{
+  "name": "jobs",
+  "scripts": {"start": "node --inspect server.js"},
+  "devDependencies": {"nodemon": "^3.0.0"}
+}`)
+	mustWrite(t, filepath.Join(dir, "jobs", "pnpm-lock.yaml"), "")
+	mustWrite(t, filepath.Join(dir, "plain", "package.json"), `{"name": "plain", "scripts": {"start": "node server.js"}}`)
+
+	api := Run(filepath.Join(dir, "api"), Options{Profile: ProfileHybrid}).Services
+	if len(api) != 1 || api[0].Cmd != "air" || api[0].Ready != "listening on" {
+		t.Fatalf("go service with .air.toml = %+v, want air waiting for the app's listen line", api)
+	}
+
+	jobs := Run(filepath.Join(dir, "jobs"), Options{Profile: ProfileHybrid}).Services
+	if len(jobs) != 1 || jobs[0].Cmd != "pnpm exec nodemon --inspect server.js" {
+		t.Fatalf("node service with nodemon = %+v, want pnpm exec nodemon", jobs)
+	}
+	if jobs[0].Ready == "" || strings.Contains(jobs[0].Ready, "nodemon") {
+		t.Fatalf("ready = %q, want the app's own pattern rather than nodemon's start line", jobs[0].Ready)
+	}
+
+	plain := Run(filepath.Join(dir, "plain"), Options{Profile: ProfileHybrid}).Services
+	if len(plain) != 1 || plain[0].Cmd != "npm run start" {
+		t.Fatalf("node service without nodemon = %+v, want npm run start", plain)
+	}
+}
@@ -28,27 +28,25 @@ func (d *GoDetector) Detect(dir string) []DetectedService {
 		}
 	}
 
-	if mainCmd == "" {
-		return nil
+	// The runner's own banner comes before the app listens, so readiness
+	// still waits for the app's line.
+	ready := "listening on"
+	if cmd := goLiveReloadCmd(dir); cmd != "" {
+		mainCmd = cmd
 	}
 
-	name := filepath.Base(dir)
-	data, _ := os.ReadFile(modPath)
-	if lines := strings.Split(string(data), "\n"); len(lines) > 0 {
-		modLine := strings.TrimPrefix(lines[0], "module ")
-		modLine = strings.TrimSpace(modLine)
-		if parts := strings.Split(modLine, "/"); len(parts) > 0 {
-			name = parts[len(parts)-1]
-		}
+	if mainCmd == "" {
+		return nil
 	}
 
+	name := goServiceName(dir)
 	return []DetectedService{
 		{
 			Name:           name,
 			LogicalName:    name,
 			Cmd:            mainCmd,
 			Port:           8080,
-			Ready:          "listening on",
+			Ready:          ready,
 			Runtime:        "go",
 			Strategy:       "local",
 			Class:          "app",
@@ -58,3 +56,34 @@ func (d *GoDetector) Detect(dir string) []DetectedService {
 		},
 	}
 }
+
+// goServiceName names a Go project's service after the last element of its
+// module path.
+func goServiceName(dir string) string {
+	name := filepath.Base(dir)
+	data, _ := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if lines := strings.Split(string(data), "\n"); len(lines) > 0 {
+		modLine := strings.TrimPrefix(lines[0], "module ")
+		modLine = strings.TrimSpace(modLine)
+		if parts := strings.Split(modLine, "/"); len(parts) > 0 {
+			name = parts[len(parts)-1]
+		}
+	}
+	return name
+}
+
+// goLiveReloadCmd returns the watch runner a Go project is set up for, since
+// that, not a bare go run, is how it's run in dev. air reads .air.toml on its
+// own; reflex needs its config passed explicitly.
+func goLiveReloadCmd(dir string) string {
+	if _, err := os.Stat(filepath.Join(dir, ".air.toml")); err == nil {
+		return "air"
+	}
+	if _, err := os.Stat(filepath.Join(dir, "air.toml")); err == nil {
+		return "air -c air.toml"
+	}
+	if _, err := os.Stat(filepath.Join(dir, "reflex.conf")); err == nil {
+		return "reflex -c reflex.conf"
+	}
+	return ""
+}
@@ -0,0 +1,164 @@
+package detect
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// MakefileDetector detects long-running make targets such as `make dev`.
+type MakefileDetector struct{}
+
+var (
+	makeTargetRegex     = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9_.-]*)\s*:([^=]|$)`)
+	makeServerHintRegex = regexp.MustCompile(`(?i)\b(run|serve|server|dev|watch|start)\b|\bport\b|--port|-p\s+\d|:\d{4,5}\b`)
+)
+
+// makeRunTargets are the targets a project is conventionally started with,
+// in order of preference.
+var makeRunTargets = []string{"dev", "run", "serve", "start"}
+
+var makeGoRunRegex = regexp.MustCompile(`\bgo run\b|(?:^|\s)(?:air|reflex)\b`)
+
+var makeServiceTargets = map[string]bool{
+	"dev": true, "run": true, "serve": true, "server": true, "start": true,
+	"watch": true, "worker": true, "api": true, "web": true, "frontend": true, "backend": true,
+}
+
+var makeSkipTargets = map[string]bool{
+	"all": true, "build": true, "test": true, "tests": true, "clean": true, "lint": true,
+	"fmt": true, "format": true, "install": true, "deps": true, "vet": true, "check": true,
+	"generate": true, "gen": true, "release": true, "dist": true, "help": true, "setup": true,
+	"migrate": true, "docker": true, "docker-build": true, "coverage": true, "bench": true,
+}
+
+func (d *MakefileDetector) Detect(dir string) []DetectedService {
+	path := findMakefile(dir)
+	if path == "" {
+		return nil
+	}
+	targets := parseMakeTargets(path)
+	goTarget := goRunTarget(dir, targets)
+	services := make([]DetectedService, 0, len(targets))
+	for _, t := range targets {
+		if !isLikelyMakeService(t.name, t.recipe) {
+			continue
+		}
+		name := normalizeServiceName(t.name)
+		if name == "" {
+			continue
+		}
+		port, portEnv := inferExplicitPort(t.recipe)
+		portConfidence := 0.0
+		if port > 0 {
+			portConfidence = 0.6
+		}
+		svc := DetectedService{
+			Name:           name,
+			LogicalName:    name,
+			Cmd:            "make " + t.name,
+			Port:           port,
+			PortEnv:        portEnv,
+			Ready:          readyPatternForScript(t.recipe, name),
+			Runtime:        "make",
+			Strategy:       "local",
+			Class:          "app",
+			Source:         filepath.ToSlash(path),
+			Confidence:     0.5,
+			PortConfidence: portConfidence,
+		}
+		if t.name == goTarget {
+			// This is how the Go module is run here: take over its service so
+			// resolution proposes make dev instead of a bare go run.
+			svc.Name = goServiceName(dir)
+			svc.LogicalName = svc.Name
+			svc.Ready = "listening on"
+			svc.Confidence = 0.8
+			if port == 0 {
+				svc.Port, svc.PortConfidence = 8080, 0.35
+			}
+		}
+		services = append(services, svc)
+	}
+	return services
+}
+
+// goRunTarget returns the first of makeRunTargets whose recipe runs the Go
+// module in dir, or "" when dir isn't a Go module or no such target exists.
+func goRunTarget(dir string, targets []makeTarget) string {
+	if !fileExists(filepath.Join(dir, "go.mod")) {
+		return ""
+	}
+	for _, name := range makeRunTargets {
+		for _, t := range targets {
+			if t.name == name && makeGoRunRegex.MatchString(t.recipe) {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+type makeTarget struct {
+	name   string
+	recipe string
+}
+
+func findMakefile(dir string) string {
+	for _, name := range []string{"GNUmakefile", "Makefile", "makefile"} {
+		path := filepath.Join(dir, name)
+		if fileExists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// parseMakeTargets returns explicit targets in file order with their recipe
+// lines joined. Pattern rules, variables, and special targets are ignored.
+func parseMakeTargets(path string) []makeTarget {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var targets []makeTarget
+	seen := make(map[string]bool)
+	current := -1
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "\t") {
+			if current >= 0 {
+				targets[current].recipe += strings.TrimSpace(line) + "\n"
+			}
+			continue
+		}
+		current = -1
+		m := makeTargetRegex.FindStringSubmatch(line)
+		if len(m) < 2 || seen[m[1]] {
+			continue
+		}
+		seen[m[1]] = true
+		targets = append(targets, makeTarget{name: m[1]})
+		current = len(targets) - 1
+	}
+	return targets
+}
+
+func isLikelyMakeService(name, recipe string) bool {
+	lower := strings.ToLower(name)
+	if makeSkipTargets[lower] || strings.HasPrefix(lower, "test") || strings.HasPrefix(lower, "build") || strings.HasPrefix(lower, "clean") {
+		return false
+	}
+	if strings.TrimSpace(recipe) == "" {
+		return false
+	}
+	if makeServiceTargets[lower] {
+		return true
+	}
+	return makeServerHintRegex.MatchString(recipe)
+}
@@ -5,19 +5,24 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 // NodeDetector detects Node.js projects via package.json.
 type NodeDetector struct{}
 
 type nodePackageJSON struct {
-	Name           string            `json:"name"`
-	Scripts        map[string]string `json:"scripts"`
-	PackageManager string            `json:"packageManager"`
-	Workspaces     json.RawMessage   `json:"workspaces"`
+	Name            string            `json:"name"`
+	Scripts         map[string]string `json:"scripts"`
+	PackageManager  string            `json:"packageManager"`
+	Workspaces      json.RawMessage   `json:"workspaces"`
+	Dependencies    map[string]string `json:"dependencies"`
+	DevDependencies map[string]string `json:"devDependencies"`
 }
 
 type nodePackageContext struct {
@@ -37,7 +42,7 @@ func (d *NodeDetector) Detect(dir string) []DetectedService {
 	}
 
 	rootRunner := detectPackageManager(dir, rootPkg.PackageManager, "")
-	workspaceDirs := resolveWorkspaceDirs(dir, rootPkg.Workspaces)
+	workspaceDirs := resolveWorkspaceDirs(dir, rootPkg.Workspaces, rootRunner)
 	hasWorkspaces := len(workspaceDirs) > 0
 
 	services := make([]DetectedService, 0)
@@ -197,11 +202,18 @@ func buildNodeService(ctx nodePackageContext, scriptName, scriptBody string, pri
 		confidence = 0.1
 	}
 
+	cmd := runScriptCommand(ctx.Runner, scriptName)
+	if watch, ok := nodemonCommand(ctx, scriptBody); ok {
+		// nodemon's own "starting" line comes before the app listens, so the
+		// app's ready pattern still applies.
+		cmd = watch
+	}
+
 	source := filepath.ToSlash(filepath.Join(ctx.Dir, "package.json"))
 	return DetectedService{
 		Name:           logical,
 		LogicalName:    logical,
-		Cmd:            runScriptCommand(ctx.Runner, scriptName),
+		Cmd:            cmd,
 		Cwd:            cwd,
 		Port:           port,
 		PortEnv:        portEnv,
@@ -224,6 +236,44 @@ func runScriptCommand(runner, script string) string {
 	}
 }
 
+// nodemonCommand rewrites a bare `node <file>` script to run under nodemon when
+// the package depends on it or carries a nodemon.json, so the generated service
+// reloads on edits the way the developer runs it. Scripts that already watch,
+// or chain other commands, are left alone.
+func nodemonCommand(ctx nodePackageContext, scriptBody string) (string, bool) {
+	_, dev := ctx.Pkg.DevDependencies["nodemon"]
+	_, dep := ctx.Pkg.Dependencies["nodemon"]
+	if !dev && !dep {
+		if _, err := os.Stat(filepath.Join(ctx.Dir, "nodemon.json")); err != nil {
+			return "", false
+		}
+	}
+	fields := strings.Fields(scriptBody)
+	if len(fields) < 2 || fields[0] != "node" || strings.ContainsAny(scriptBody, "&|;") {
+		return "", false
+	}
+	for _, f := range fields[1:] {
+		if f == "--watch" || strings.HasPrefix(f, "--watch=") {
+			return "", false
+		}
+	}
+	return packageExecCommand(ctx.Runner) + " nodemon " + strings.Join(fields[1:], " "), true
+}
+
+// packageExecCommand returns how runner invokes a locally installed binary.
+func packageExecCommand(runner string) string {
+	switch runner {
+	case "yarn":
+		return "yarn"
+	case "pnpm":
+		return "pnpm exec"
+	case "bun":
+		return "bunx"
+	default:
+		return "npx"
+	}
+}
+
 func inferNodeServiceName(ctx nodePackageContext, scriptName, scriptBody string, primary bool) string {
 	if primary {
 		if !ctx.IsRoot {
@@ -302,14 +352,23 @@ func inferNodePort(scriptName, scriptBody, dir, logicalName string) (port int, c
 		if p, ok := inferViteConfigPort(dir); ok {
 			return p, 0.9, "", "ready in"
 		}
+		if p, env, ok := inferDotEnvPort(dir); ok {
+			return p, dotEnvPortConfidence, env, "ready in"
+		}
 		return 5173, 0.6, "", "ready in"
 	}
 
 	if strings.Contains(lower, "next dev") {
+		if p, env, ok := inferDotEnvPort(dir); ok {
+			return p, dotEnvPortConfidence, env, "Ready in"
+		}
 		return 3000, 0.6, "PORT", "Ready in"
 	}
 
 	if strings.Contains(lower, "react-scripts start") {
+		if p, env, ok := inferDotEnvPort(dir); ok {
+			return p, dotEnvPortConfidence, env, "compiled successfully"
+		}
 		return 3000, 0.6, "PORT", "compiled successfully"
 	}
 
@@ -329,6 +388,11 @@ func inferNodePort(scriptName, scriptBody, dir, logicalName string) (port int, c
 		return 0, 0, "", readyPatternForScript(scriptBody, logicalName)
 	}
 
+	if isLikelyServerScript(scriptName, scriptBody) {
+		if p, env, ok := inferDotEnvPort(dir); ok {
+			return p, dotEnvPortConfidence, env, readyPatternForScript(scriptBody, logicalName)
+		}
+	}
 	return 0, 0, inferPortEnv(scriptBody, scriptName), readyPatternForScript(scriptBody, logicalName)
 }
 
@@ -401,12 +465,101 @@ func inferPythonLikePort(dir, script string) (int, float64, string) {
 		}
 	}
 
+	if p, env, ok := inferDotEnvPort(dir); ok {
+		return p, dotEnvPortConfidence, env
+	}
+
 	if strings.Contains(strings.ToLower(script), "run_server.py") || strings.Contains(strings.ToLower(script), "uvicorn") {
 		return 8000, 0.55, "API_PORT"
 	}
 	return 0, 0, ""
 }
 
+// dotEnvPortConfidence ranks a port read from a .env file below one spelled
+// out in the script but above any framework default.
+const dotEnvPortConfidence = 0.85
+
+// dotEnvFiles are checked in dotenv override order: the first file that
+// assigns a server port wins.
+var dotEnvFiles = []string{".env.local", ".env.development", ".env"}
+
+// dotEnvPortKeys are preferred, in order, over any other *_PORT assignment.
+var dotEnvPortKeys = []string{"PORT", "API_PORT", "SERVER_PORT", "APP_PORT", "HTTP_PORT"}
+
+// dotEnvIgnoredPortPrefixes mark *_PORT variables that point at other
+// services the app connects to rather than the port it listens on.
+var dotEnvIgnoredPortPrefixes = []string{"DB_", "DATABASE_", "POSTGRES_", "PG", "MYSQL_", "REDIS_", "MONGO", "SMTP_", "MAIL_", "RABBITMQ_", "AMQP_", "KAFKA_", "ELASTIC", "MINIO_", "S3_"}
+
+var dotEnvAssignRe = regexp.MustCompile(`^(?:export\s+)?([A-Za-z_][A-Za-z0-9_]*)\s*=\s*["']?(\d{2,5})["']?\s*(?:#.*)?$`)
+
+// inferDotEnvPort reads the port a service listens on from the .env files in
+// dir, returning the variable that sets it.
+func inferDotEnvPort(dir string) (port int, env string, ok bool) {
+	for _, name := range dotEnvFiles {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		var ports portVars
+		for _, line := range strings.Split(string(data), "\n") {
+			if m := dotEnvAssignRe.FindStringSubmatch(strings.TrimSpace(line)); len(m) == 3 {
+				ports.add(m[1], parsePort(m[2]))
+			}
+		}
+		if p, key, ok := ports.listenPort(); ok {
+			return p, key, true
+		}
+	}
+	return 0, "", false
+}
+
+// portVars collects *PORT variable assignments in the order they are seen;
+// a later assignment of the same variable overrides an earlier one.
+type portVars struct {
+	ports  map[string]int
+	others []string
+}
+
+func (v *portVars) add(key string, port int) {
+	key = strings.ToUpper(key)
+	if port == 0 || !strings.HasSuffix(key, "PORT") {
+		return
+	}
+	if v.ports == nil {
+		v.ports = make(map[string]int)
+	}
+	if _, seen := v.ports[key]; !seen && !slices.Contains(dotEnvPortKeys, key) {
+		v.others = append(v.others, key)
+	}
+	v.ports[key] = port
+}
+
+// listenPort picks the variable holding the port a service listens on: one
+// of dotEnvPortKeys, else the first *_PORT that isn't another service's.
+func (v *portVars) listenPort() (port int, key string, ok bool) {
+	for _, key := range dotEnvPortKeys {
+		if p, found := v.ports[key]; found {
+			return p, key, true
+		}
+	}
+	for _, key := range v.others {
+		if !strings.HasSuffix(key, "_PORT") || hasAnyPrefix(key, dotEnvIgnoredPortPrefixes) {
+			continue
+		}
+		return v.ports[key], key, true
+	}
+	return 0, "", false
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func inferViteConfigPort(dir string) (int, bool) {
 	for _, name := range []string{"vite.config.ts", "vite.config.js", "vite.config.mjs", "vite.config.cjs"} {
 		path := filepath.Join(dir, name)
@@ -495,6 +648,9 @@ func detectPackageManager(dir, packageManager, fallback string) string {
 	if _, err := os.Stat(filepath.Join(dir, "pnpm-lock.yaml")); err == nil {
 		return "pnpm"
 	}
+	if _, err := os.Stat(filepath.Join(dir, "pnpm-workspace.yaml")); err == nil {
+		return "pnpm"
+	}
 	if _, err := os.Stat(filepath.Join(dir, "yarn.lock")); err == nil {
 		return "yarn"
 	}
@@ -525,8 +681,16 @@ func packageManagerFromField(raw string) string {
 	}
 }
 
-func resolveWorkspaceDirs(root string, raw json.RawMessage) []string {
+// resolveWorkspaceDirs expands package.json workspaces, plus the packages of
+// pnpm-workspace.yaml when runner is pnpm, into package directories.
+func resolveWorkspaceDirs(root string, raw json.RawMessage, runner string) []string {
 	patterns := parseWorkspacePatterns(raw)
+	var excluded map[string]bool
+	if runner == "pnpm" {
+		include, exclude := pnpmWorkspacePatterns(root)
+		patterns = sanitizeWorkspacePatterns(append(patterns, include...))
+		excluded = globDirs(root, exclude)
+	}
 	seen := make(map[string]struct{})
 	out := make([]string, 0)
 
@@ -540,7 +704,7 @@ func resolveWorkspaceDirs(root string, raw json.RawMessage) []string {
 			if !isPackageDir(m) {
 				continue
 			}
-			if strings.Contains(filepath.ToSlash(m), "/node_modules/") {
+			if strings.Contains(filepath.ToSlash(m), "/node_modules/") || excluded[m] {
 				continue
 			}
 			if _, ok := seen[m]; ok {
@@ -606,6 +770,41 @@ func isPackageDir(path string) bool {
 	return true
 }
 
+// pnpmWorkspacePatterns reads the packages globs of pnpm-workspace.yaml,
+// splitting off the "!"-prefixed ones that exclude directories.
+func pnpmWorkspacePatterns(root string) (include, exclude []string) {
+	data, err := os.ReadFile(filepath.Join(root, "pnpm-workspace.yaml"))
+	if err != nil {
+		return nil, nil
+	}
+	var ws struct {
+		Packages []string `yaml:"packages"`
+	}
+	if err := yaml.Unmarshal(data, &ws); err != nil {
+		return nil, nil
+	}
+	for _, p := range ws.Packages {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(p), "!"); ok {
+			exclude = append(exclude, rest)
+			continue
+		}
+		include = append(include, p)
+	}
+	return include, exclude
+}
+
+// globDirs returns the paths under root matching any of patterns.
+func globDirs(root string, patterns []string) map[string]bool {
+	dirs := make(map[string]bool)
+	for _, pattern := range sanitizeWorkspacePatterns(patterns) {
+		matches, _ := filepath.Glob(filepath.Join(root, filepath.FromSlash(pattern)))
+		for _, m := range matches {
+			dirs[m] = true
+		}
+	}
+	return dirs
+}
+
 func parseWorkspacePatterns(raw json.RawMessage) []string {
 	if len(raw) == 0 {
 		return nil
@@ -0,0 +1,89 @@
+package detect
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ProcfileDetector detects the processes of a foreman/honcho-style Procfile.
+type ProcfileDetector struct{}
+
+var (
+	procfileLineRegex = regexp.MustCompile(`^([A-Za-z0-9_-]+):\s*(.+)$`)
+	procfilePortRegex = regexp.MustCompile(`\$\{?PORT\b`)
+)
+
+// procfileBasePort is where foreman starts handing out $PORT; each process
+// gets the next hundred.
+const procfileBasePort = 5000
+
+func (d *ProcfileDetector) Detect(dir string) []DetectedService {
+	path := findProcfile(dir)
+	if path == "" {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var services []DetectedService
+	index := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		m := procfileLineRegex.FindStringSubmatch(line)
+		if len(m) != 3 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		process, cmd := m[1], strings.TrimSpace(m[2])
+		slot := index
+		index++
+		if process == "release" {
+			continue // Heroku's one-off release phase, not a long-running process
+		}
+		name := normalizeServiceName(process)
+		if name == "" {
+			continue
+		}
+
+		port, portEnv := inferExplicitPort(cmd)
+		portConfidence := 0.0
+		if port > 0 {
+			portConfidence = 0.8
+		} else if procfilePortRegex.MatchString(cmd) {
+			port, portEnv, portConfidence = procfileBasePort+100*slot, "PORT", 0.4
+		}
+		services = append(services, DetectedService{
+			Name:           name,
+			LogicalName:    name,
+			Cmd:            cmd,
+			Port:           port,
+			PortEnv:        portEnv,
+			Ready:          readyPatternForScript(cmd, name),
+			Runtime:        "procfile",
+			Strategy:       "local",
+			Class:          "app",
+			Source:         filepath.ToSlash(path),
+			Confidence:     0.75,
+			PortConfidence: portConfidence,
+		})
+	}
+	return services
+}
+
+// findProcfile prefers Procfile.dev, which Rails and others keep for local
+// development next to a production Procfile.
+func findProcfile(dir string) string {
+	for _, name := range []string{"Procfile.dev", "Procfile"} {
+		path := filepath.Join(dir, name)
+		if fileExists(path) {
+			return path
+		}
+	}
+	return ""
+}
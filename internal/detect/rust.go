@@ -0,0 +1,110 @@
+package detect
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// RustDetector detects Rust binaries via Cargo.toml.
+type RustDetector struct{}
+
+// lookPath is swapped in tests so detection doesn't depend on what the
+// machine running them has installed.
+var lookPath = exec.LookPath
+
+var (
+	cargoPackageNameRegex = regexp.MustCompile(`(?m)^\s*name\s*=\s*"([^"]+)"`)
+	rustBindPortRegex     = regexp.MustCompile(`"(?:0\.0\.0\.0|127\.0\.0\.1|localhost|\[::\])?:(\d{2,5})"`)
+	rustPortEnvRegex      = regexp.MustCompile(`env::var\(\s*"(PORT|[A-Z0-9_]+_PORT)"`)
+	cargoKeyRegex         = regexp.MustCompile(`(?m)^\s*([A-Za-z0-9_-]+)\s*=`)
+)
+
+// rustFrameworkPorts are the ports web frameworks listen on by default.
+var rustFrameworkPorts = []struct {
+	crate string
+	port  int
+	ready string
+}{
+	{"actix-web", 8080, "listening on"},
+	{"rocket", 8000, "Rocket has launched"},
+}
+
+func (d *RustDetector) Detect(dir string) []DetectedService {
+	manifestPath := filepath.Join(dir, "Cargo.toml")
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil
+	}
+	manifest := string(data)
+	mainPath := filepath.Join(dir, "src", "main.rs")
+	if !fileExists(mainPath) && !strings.Contains(manifest, "[[bin]]") {
+		return nil // a library or a virtual workspace; nothing to cargo run
+	}
+
+	name := filepath.Base(dir)
+	if _, pkg, ok := strings.Cut(manifest, "[package]"); ok {
+		if m := cargoPackageNameRegex.FindStringSubmatch(pkg); len(m) == 2 {
+			name = m[1]
+		}
+	}
+	name = normalizeServiceName(name)
+	if name == "" {
+		return nil
+	}
+
+	cmd := "cargo run"
+	if _, err := lookPath("cargo-watch"); err == nil {
+		cmd = "cargo watch -x run"
+	}
+
+	port, portConfidence, portEnv, ready := inferRustPort(dir, manifest, mainPath)
+	return []DetectedService{
+		{
+			Name:           name,
+			LogicalName:    name,
+			Cmd:            cmd,
+			Port:           port,
+			PortEnv:        portEnv,
+			Ready:          ready,
+			Runtime:        "rust",
+			Strategy:       "local",
+			Class:          "app",
+			Source:         filepath.ToSlash(manifestPath),
+			Confidence:     0.7,
+			PortConfidence: portConfidence,
+		},
+	}
+}
+
+// inferRustPort looks for the port in .env, then a literal bind address in
+// src/main.rs, then the default of a web framework the crate depends on. A
+// PORT-style variable read in main.rs becomes the port env.
+func inferRustPort(dir, manifest, mainPath string) (port int, confidence float64, env, ready string) {
+	ready = "listening on"
+	keys := make(map[string]bool)
+	for _, m := range cargoKeyRegex.FindAllStringSubmatch(manifest, -1) {
+		keys[m[1]] = true
+	}
+	for _, fw := range rustFrameworkPorts {
+		if keys[fw.crate] {
+			port, confidence, ready = fw.port, 0.5, fw.ready
+			break
+		}
+	}
+	source, _ := os.ReadFile(mainPath)
+	if m := rustPortEnvRegex.FindSubmatch(source); len(m) == 2 {
+		env = string(m[1])
+	}
+	if m := rustBindPortRegex.FindSubmatch(source); len(m) == 2 {
+		if p := parsePort(string(m[1])); p > 0 {
+			port, confidence = p, 0.75
+		}
+	}
+	if p, dotEnv, ok := inferDotEnvPort(dir); ok {
+		port, confidence, env = p, dotEnvPortConfidence, dotEnv
+	}
+	return port, confidence, env, ready
+}
@@ -0,0 +1,60 @@
+// Package opener hands URLs, files, and directories to the desktop's
+// default application.
+package opener
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+)
+
+// Open opens target, a URL or a path, with the OS default handler.
+func Open(target string) error {
+	name, args, err := openCommand(runtime.GOOS, target)
+	if err != nil {
+		return err
+	}
+	return start(name, args)
+}
+
+// Reveal shows path selected in the OS file manager. Where the file manager
+// can't select a file from the command line, its directory is opened.
+func Reveal(path string) error {
+	name, args, err := revealCommand(runtime.GOOS, path)
+	if err != nil {
+		return err
+	}
+	return start(name, args)
+}
+
+// start launches the opener without waiting on it, reaping it in the
+// background so it doesn't linger as a zombie.
+func start(name string, args []string) error {
+	cmd := exec.Command(name, args...)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+func openCommand(goos, target string) (string, []string, error) {
+	switch goos {
+	case "darwin":
+		return "open", []string{target}, nil
+	case "linux":
+		return "xdg-open", []string{target}, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported platform")
+	}
+}
+
+func revealCommand(goos, path string) (string, []string, error) {
+	switch goos {
+	case "darwin":
+		return "open", []string{"-R", path}, nil
+	default:
+		return openCommand(goos, filepath.Dir(path))
+	}
+}
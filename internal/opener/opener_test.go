@@ -0,0 +1,28 @@
+package opener
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRevealSelectsTheFileWhereTheFileManagerCan(t *testing.T) {
+	cases := []struct {
+		goos string
+		want string
+	}{
+		{"darwin", "open -R /home/u/.hun/logs/shop/api.log"},
+		{"linux", "xdg-open /home/u/.hun/logs/shop"},
+	}
+	for _, tc := range cases {
+		name, args, err := revealCommand(tc.goos, "/home/u/.hun/logs/shop/api.log")
+		if err != nil {
+			t.Fatalf("%s: %v", tc.goos, err)
+		}
+		if got := strings.Join(append([]string{name}, args...), " "); got != tc.want {
+			t.Fatalf("%s: reveal = %q, want %q", tc.goos, got, tc.want)
+		}
+	}
+	if _, _, err := openCommand("plan9", "http://localhost:3000"); err == nil {
+		t.Fatal("expected an error on a platform without an opener")
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/sourabhrathourr/hun/internal/config"
 )
@@ -20,6 +22,17 @@ type State struct {
 	Projects      map[string]ProjectState `json:"projects"`
 	Registry      map[string]string       `json:"registry"` // name → path
 
+	// DaemonDirty is set while a daemon runs and cleared by a clean shutdown,
+	// so a daemon that finds it set knows its predecessor died mid-flight.
+	DaemonDirty bool `json:"daemon_dirty,omitempty"`
+
+	// RecoveredFrom is the backup path when Load found a corrupt state file
+	// and started fresh; empty otherwise.
+	RecoveredFrom string `json:"-"`
+	// RecoveryWarning describes the recovery, for the caller to report. Load
+	// doesn't print it because the TUI may own the terminal.
+	RecoveryWarning string `json:"-"`
+
 	mu   sync.Mutex `json:"-"`
 	path string     `json:"-"`
 }
@@ -34,6 +47,8 @@ type ProjectState struct {
 	LastNote  string                  `json:"last_note"`
 	StartedAt string                  `json:"started_at"`
 	IconPath  string                  `json:"icon_path,omitempty"`
+	Favorite  bool                    `json:"favorite,omitempty"` // sorted to the top of the TUI project picker
+	Pinned    []string                `json:"pinned,omitempty"`   // services sorted to the top of the TUI sidebar
 }
 
 // ServiceState holds runtime state for a single service.
@@ -51,13 +66,7 @@ func Load() (*State, error) {
 	}
 
 	path := filepath.Join(dir, "state.json")
-	s := &State{
-		SchemaVersion: CurrentSchemaVersion,
-		Mode:          "focus",
-		Projects:      make(map[string]ProjectState),
-		Registry:      make(map[string]string),
-		path:          path,
-	}
+	s := newState(path)
 
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -68,7 +77,14 @@ func Load() (*State, error) {
 	}
 
 	if err := json.Unmarshal(data, s); err != nil {
-		return nil, fmt.Errorf("parsing state: %w", err)
+		backup, backupErr := backupCorruptState(path)
+		if backupErr != nil {
+			return nil, fmt.Errorf("parsing state: %w (backup failed: %v)", err, backupErr)
+		}
+		fresh := newState(path)
+		fresh.RecoveredFrom = backup
+		fresh.RecoveryWarning = fmt.Sprintf("%s was corrupt (%v); moved it to %s and started fresh", path, err, backup)
+		return fresh, nil
 	}
 	s.path = path
 
@@ -101,6 +117,46 @@ func Load() (*State, error) {
 	return s, nil
 }
 
+func newState(path string) *State {
+	return &State{
+		SchemaVersion: CurrentSchemaVersion,
+		Mode:          "focus",
+		Projects:      make(map[string]ProjectState),
+		Registry:      make(map[string]string),
+		path:          path,
+	}
+}
+
+// Verify reports whether the state file on disk parses, without repairing it.
+// A missing file is not an error.
+func Verify() error {
+	dir, err := config.HunDir()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, "state.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reading state: %w", err)
+	}
+	var probe State
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return fmt.Errorf("%s is corrupt: %w", path, err)
+	}
+	return nil
+}
+
+func backupCorruptState(path string) (string, error) {
+	backup := fmt.Sprintf("%s.corrupt.%s", path, time.Now().UTC().Format("20060102-150405"))
+	if err := os.Rename(path, backup); err != nil {
+		return "", err
+	}
+	return backup, nil
+}
+
 // Save writes state to disk atomically.
 func (s *State) Save() error {
 	s.mu.Lock()
@@ -125,6 +181,33 @@ func (s *State) Save() error {
 	return nil
 }
 
+// Validate checks that s is a state hun can adopt wholesale, e.g. one being
+// imported from another machine.
+func (s *State) Validate() error {
+	if s.SchemaVersion > CurrentSchemaVersion {
+		return fmt.Errorf("schema_version %d is newer than this hun supports (%d)", s.SchemaVersion, CurrentSchemaVersion)
+	}
+	switch s.Mode {
+	case "", "focus", "multitask":
+	default:
+		return fmt.Errorf("mode %q must be focus or multitask", s.Mode)
+	}
+	for name, path := range s.Registry {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("registry has an entry without a project name")
+		}
+		if !filepath.IsAbs(path) {
+			return fmt.Errorf("registry path for %q must be absolute, got %q", name, path)
+		}
+	}
+	for name := range s.Projects {
+		if _, ok := s.Registry[name]; !ok {
+			return fmt.Errorf("project %q has state but is not in the registry", name)
+		}
+	}
+	return nil
+}
+
 // Register adds a project name → path mapping.
 func (s *State) Register(name, path string) {
 	s.mu.Lock()
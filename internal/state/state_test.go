@@ -3,6 +3,7 @@ package state
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -109,3 +110,62 @@ func TestLoadPrunesRegistryEntriesWithoutProjectConfig(t *testing.T) {
 		t.Fatalf("expected missing project absent after persistence")
 	}
 }
+
+func TestLoadBacksUpCorruptStateAndStartsFresh(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	hunDir := filepath.Join(home, ".hun")
+	if err := os.MkdirAll(hunDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	statePath := filepath.Join(hunDir, "state.json")
+	if err := os.WriteFile(statePath, []byte(`{"projects":{"proj":`), 0o644); err != nil {
+		t.Fatalf("write corrupt state: %v", err)
+	}
+
+	if err := Verify(); err == nil {
+		t.Fatal("expected Verify to report corrupt state")
+	}
+
+	st, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if st.RecoveredFrom == "" || len(st.Registry) != 0 {
+		t.Fatalf("expected fresh state with backup path, got %+v", st)
+	}
+	if !strings.Contains(st.RecoveryWarning, st.RecoveredFrom) {
+		t.Fatalf("recovery warning = %q, want it to name the backup %s", st.RecoveryWarning, st.RecoveredFrom)
+	}
+	backup, err := os.ReadFile(st.RecoveredFrom)
+	if err != nil {
+		t.Fatalf("read backup: %v", err)
+	}
+	if string(backup) != `{"projects":{"proj":` {
+		t.Fatalf("backup content = %q", backup)
+	}
+	if _, err := os.Stat(statePath); !os.IsNotExist(err) {
+		t.Fatalf("expected corrupt state.json to be moved aside, stat err = %v", err)
+	}
+	if err := Verify(); err != nil {
+		t.Fatalf("expected Verify to pass after recovery, got %v", err)
+	}
+}
+
+func TestValidateRejectsInconsistentState(t *testing.T) {
+	valid := &State{Mode: "focus", Registry: map[string]string{"shop": "/src/shop"}, Projects: map[string]ProjectState{"shop": {}}}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("valid state rejected: %v", err)
+	}
+	cases := map[string]*State{
+		"schema":   {SchemaVersion: CurrentSchemaVersion + 1},
+		"mode":     {Mode: "turbo"},
+		"relative": {Registry: map[string]string{"shop": "src/shop"}},
+		"orphan":   {Projects: map[string]ProjectState{"ghost": {}}},
+	}
+	for name, st := range cases {
+		if err := st.Validate(); err == nil {
+			t.Fatalf("%s: expected validation error", name)
+		}
+	}
+}
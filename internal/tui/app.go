@@ -3,7 +3,10 @@ package tui
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"path/filepath"
+	"regexp/syntax"
 	"sort"
 	"strings"
 	"time"
@@ -33,10 +36,36 @@ type Model struct {
 
 	focusedProject string
 	latestStatus   statusUpdateMsg
-	allLogs        map[string][]daemon.LogLine // "project:service" → lines
-	logCutoff      map[string]time.Time        // "project:service" → show logs after this time
-	startedAt      map[string]time.Time        // "project:service" → daemon-reported service start time
-	activePane     string                      // "services" or "logs"
+	allLogs        map[string][]daemon.LogLine   // "project:service" → lines
+	logCutoff      map[string]time.Time          // "project:service" → show logs after this time
+	startedAt      map[string]time.Time          // "project:service" → daemon-reported service start time
+	projectConfigs map[string]*projectConfigInfo // project → cached .hun.yml service info
+	activePane     string                        // "services" or "logs"
+	tagFilter      string                        // only show services carrying this tag
+	prevService    string                        // single service shown before switching to "all"
+	lastService    string                        // service shown before the current one; | splits with it
+	logActivity    map[string][]time.Time        // "project:service" → recent line timestamps for auto-follow
+	autoSwitchedAt time.Time                     // last time auto-follow changed the selected service
+	wrapByService  map[string]bool               // "project:service" → wrap preference set with w
+	wrapDefault    bool                          // wrap for the "all" view and services without a preference
+	activity       map[string]*activityRing      // "project:service" → per-second line counts for the sidebar sparkline
+	pinned         map[string]bool               // "project:service" → sorted to the top of the sidebar with *
+
+	favoritesGrouped bool // picker.favorites: grouped in the global config
+	enterStarts      bool // services.enter: start in the global config
+	helpVisible      bool // ? overlay listing key bindings
+	sidebarWidth     int  // requested sidebar columns, clamped to the terminal ([ and ])
+
+	// splitLogs is a second logs pane showing splitService of splitProject
+	// next to (or below) the selected service's logs; splitService is ""
+	// while the single-pane layout is in use.
+	splitLogs    logsModel
+	splitProject string
+	splitService string
+
+	// usageFetchedAt is when the last full status arrived. Unchanged polls
+	// carry no CPU or memory, so one is forced every usageRefreshInterval.
+	usageFetchedAt time.Time
 
 	logCh            chan daemon.LogLine
 	subErrCh         chan error
@@ -48,6 +77,12 @@ type Model struct {
 	searching bool
 	searchBuf string
 
+	jumping   bool // typing a service name to jump to in the sidebar
+	jumpTimer int
+
+	stopConfirm *stopConfirmation // pending stop of a protected project or service
+	restart     *projectRestart   // project restart in flight; its service list is held
+
 	focusPromptVisible  bool
 	focusPromptProjects []string
 	focusPromptSelected int
@@ -61,13 +96,33 @@ type Model struct {
 	toastTimer     int
 	copyFlashTimer int
 
+	pollID        int       // generation of the status poll chain; stale ticks are dropped
+	pollFastUntil time.Time // poll quickly until then because an action is settling
+	lastInputAt   time.Time // last key or mouse event, for slowing down when idle
+
+	statusFailures int    // consecutive failed status polls
+	statusRevision uint64 // daemon status revision of latestStatus; polls only fetch newer ones
+
+	onboardDir       string // un-onboarded cwd offered on the welcome screen
+	onboardRequested bool
+
 	err error
 }
 
-type tickMsg time.Time
+type tickMsg struct{ id int }
+type statusFailedMsg struct{ err error }
 type statusUpdateMsg map[string]map[string]daemon.ServiceInfo
+
+// statusDeltaMsg is a status poll's answer: either a new revision's status
+// or word that the revision the poll sent is still current.
+type statusDeltaMsg struct {
+	revision  uint64
+	unchanged bool
+	status    statusUpdateMsg
+}
 type logMsg daemon.LogLine
 type toastExpireMsg struct{ id int }
+type jumpExpireMsg struct{ id int }
 type copyFlashStartMsg struct{ id int }
 type copyFlashStepMsg struct{ id int }
 type subscriptionErrMsg struct{ err error }
@@ -78,6 +133,48 @@ type logsFetchedMsg struct {
 	lines   []daemon.LogLine
 }
 type stopServiceResultMsg struct{ err string }
+type pauseServiceResultMsg struct{ err string }
+type favoriteResultMsg struct {
+	project  string
+	favorite bool
+	err      string
+}
+type pinResultMsg struct {
+	project string
+	service string
+	pinned  bool
+	err     string
+}
+type projectRestartedMsg struct {
+	project string
+	err     string
+}
+
+// restartHoldMax bounds how long a restarting project's old service list is
+// shown when the daemon never answers the restart.
+const restartHoldMax = time.Minute
+
+// projectRestart keeps a restarting project's previous service list on screen
+// while the daemon stops and starts it, so the sidebar doesn't flash empty and
+// focus and scroll stay put.
+type projectRestart struct {
+	project  string
+	began    time.Time
+	previous map[string]daemon.ServiceInfo
+	done     bool // the daemon answered the restart request
+}
+
+// pending reports whether a service hasn't come back from the restart yet.
+func (r *projectRestart) pending(info daemon.ServiceInfo) bool {
+	return r != nil && !info.StartedAt.After(r.began) && info.Status != "disabled"
+}
+
+// stopConfirmation is a stop waiting for the user to confirm; an empty
+// service means the whole project.
+type stopConfirmation struct {
+	project string
+	service string
+}
 
 const (
 	paneServices = "services"
@@ -88,11 +185,21 @@ const (
 func New(multi bool) Model {
 	mode := "focus"
 	focused := ""
+	pinned := make(map[string]bool)
+	warning := ""
 	if st, err := state.Load(); err == nil {
+		if st.RecoveryWarning != "" {
+			warning = "Warning: " + st.RecoveryWarning
+		}
 		if st.Mode == "multitask" {
 			mode = "multitask"
 		}
 		focused = st.ActiveProject
+		for project, ps := range st.Projects {
+			for _, service := range ps.Pinned {
+				pinned[project+":"+service] = true
+			}
+		}
 	}
 	if multi {
 		mode = "multitask"
@@ -100,6 +207,18 @@ func New(multi bool) Model {
 
 	c, _ := client.New()
 
+	hideMeta := false
+	favoritesGrouped := false
+	enterStarts := false
+	themeName := ""
+	if g, err := config.LoadGlobal(); err == nil {
+		hideMeta = g.Logs.HideMeta
+		favoritesGrouped = g.Picker.Favorites == "grouped"
+		enterStarts = g.Services.Enter == "start"
+		themeName = g.Theme
+	}
+	applyTheme(themeByName(themeName))
+
 	m := Model{
 		client:         c,
 		mode:           mode,
@@ -107,23 +226,38 @@ func New(multi bool) Model {
 		allLogs:        make(map[string][]daemon.LogLine),
 		logCutoff:      make(map[string]time.Time),
 		startedAt:      make(map[string]time.Time),
+		projectConfigs: make(map[string]*projectConfigInfo),
+		wrapByService:  make(map[string]bool),
 		activePane:     paneServices,
 		logCh:          make(chan daemon.LogLine, 2048),
 		subErrCh:       make(chan error, 32),
 		topBar:         topBarModel{mode: mode},
-		logs:           logsModel{autoScroll: true, wrap: false},
-	}
+		logs:           logsModel{autoScroll: true, wrap: false, hideMeta: hideMeta},
+	}
+	m.logs.serviceStarts = m.startedAt
+	m.sidebarWidth = sidebarDefaultWidth
+	m.favoritesGrouped = favoritesGrouped
+	m.enterStarts = enterStarts
+	m.pinned = pinned
+	m.toast = warning // expired by Init
 	return m
 }
 
 // Init implements tea.Model.
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		m.fetchStatusCmd(),
 		m.tickCmd(),
 		m.waitForLogCmd(),
 		m.waitForSubErrCmd(),
-	)
+	}
+	if m.toast != "" {
+		id := m.toastTimer
+		cmds = append(cmds, tea.Tick(8*time.Second, func(time.Time) tea.Msg {
+			return toastExpireMsg{id: id}
+		}))
+	}
+	return tea.Batch(cmds...)
 }
 
 // Update implements tea.Model.
@@ -140,12 +274,35 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if cmd := m.noteInput(); cmd != nil {
+			model, keyCmd := m.handleKey(msg)
+			return model, tea.Batch(cmd, keyCmd)
+		}
 		return m.handleKey(msg)
 
 	case tea.MouseMsg:
+		if cmd := m.noteInput(); cmd != nil {
+			model, mouseCmd := m.handleMouse(msg)
+			return model, tea.Batch(cmd, mouseCmd)
+		}
 		return m.handleMouse(msg)
 
+	case statusDeltaMsg:
+		m.statusRevision = msg.revision
+		if !msg.unchanged {
+			return m.Update(msg.status)
+		}
+		m.statusFailures = 0
+		m.statusBar.health = daemonConnected
+		if m.restart != nil {
+			// A held restart can time out without the daemon's status moving.
+			return m, tea.Batch(m.applyStatus(m.latestStatus)...)
+		}
+		return m, nil
+
 	case statusUpdateMsg:
+		m.statusFailures = 0
+		m.statusBar.health = daemonConnected
 		cmds := m.applyStatus(msg)
 		if len(cmds) == 0 {
 			return m, nil
@@ -157,12 +314,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if !m.logPassesCutoff(line) {
 			return m, m.waitForLogCmd()
 		}
+		m.recordActivity(msg, time.Now())
 		key := projectServiceKey(line.Project, line.Service)
 		m.allLogs[key] = append(m.allLogs[key], line)
 		if len(m.allLogs[key]) > 10000 {
 			m.allLogs[key] = m.allLogs[key][len(m.allLogs[key])-10000:]
 		}
 
+		if m.splitService != "" && m.splitProject == line.Project && m.splitService == line.Service {
+			m.splitLogs.setLines(m.allLogs[key])
+		}
 		if m.logs.service == "all" {
 			m.refreshAllLogs()
 		} else if m.focusedProject == line.Project && len(m.services.items) > 0 {
@@ -170,6 +331,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if sel == line.Service {
 				m.logs.setLines(m.allLogs[key])
 			}
+			if m.logs.autoFollow {
+				return m, tea.Batch(m.waitForLogCmd(), m.autoFollowActivity(line))
+			}
 		}
 		return m, m.waitForLogCmd()
 
@@ -177,6 +341,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		key := projectServiceKey(msg.project, msg.service)
 		lines := m.filterLinesForKey(key, msg.lines)
 		m.allLogs[key] = lines
+		if m.splitService != "" && m.splitProject == msg.project && m.splitService == msg.service {
+			m.splitLogs.setLines(lines)
+		}
 		if m.logs.service == "all" {
 			m.refreshAllLogs()
 		} else if m.focusedProject == msg.project && len(m.services.items) > 0 {
@@ -193,8 +360,44 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, tea.Batch(m.fetchStatusCmd(), m.showToast("Stop service failed: "+msg.err))
 
+	case projectRestartedMsg:
+		if m.restart == nil || m.restart.project != msg.project {
+			return m, nil
+		}
+		if msg.err != "" {
+			m.restart = nil
+			return m, tea.Batch(m.fetchStatusCmd(), m.showToast("Restart failed: "+msg.err))
+		}
+		m.restart.done = true
+		return m, m.fetchStatusCmd()
+
+	case pinResultMsg:
+		if msg.err == "" {
+			return m, nil
+		}
+		// The daemon didn't save it; put the sidebar back the way state has it.
+		m.setPinned(msg.project, msg.service, !msg.pinned)
+		return m, m.showToast("Pin failed: " + msg.err)
+
+	case favoriteResultMsg:
+		if msg.err == "" {
+			return m, nil
+		}
+		// The daemon didn't save it; put the picker back the way state has it.
+		m.picker.setFavorite(msg.project, !msg.favorite)
+		return m, m.showToast("Favorite failed: " + msg.err)
+
+	case pauseServiceResultMsg:
+		if msg.err == "" {
+			return m, m.fetchStatusCmd()
+		}
+		return m, tea.Batch(m.fetchStatusCmd(), m.showToast("Pause failed: "+msg.err))
+
 	case subscriptionErrMsg:
 		m.err = msg.err
+		if m.statusBar.health != daemonDisconnected {
+			m.statusBar.health = daemonReconnecting
+		}
 		cmd := m.showToast("Log stream reconnecting...")
 		m.forceResubscribe = true
 		return m, tea.Batch(m.waitForSubErrCmd(), cmd, m.retrySubscribeCmd())
@@ -204,6 +407,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tickMsg:
+		if msg.id != m.pollID {
+			return m, nil // superseded by a rescheduled poll
+		}
+		now := time.Now()
+		m.syncActivity(now)
+		if now.Sub(m.usageFetchedAt) >= usageRefreshInterval {
+			m.usageFetchedAt = now
+			m.statusRevision = 0 // ask for the full status, with fresh usage
+		}
 		return m, tea.Batch(m.fetchStatusCmd(), m.tickCmd())
 
 	case toastExpireMsg:
@@ -212,6 +424,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case jumpExpireMsg:
+		if msg.id == m.jumpTimer {
+			m.endJump()
+		}
+		return m, nil
+
 	case copyFlashStartMsg:
 		if msg.id != m.copyFlashTimer {
 			return m, nil
@@ -230,6 +448,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case statusFailedMsg:
+		m.err = msg.err
+		m.statusFailures++
+		m.statusBar.health = daemonReconnecting
+		if m.statusFailures >= statusFailuresBeforeDown {
+			m.statusBar.health = daemonDisconnected
+		}
+		return m, nil
+
 	case error:
 		m.err = msg
 		return m, nil
@@ -246,7 +473,7 @@ func (m Model) View() string {
 
 	var view string
 
-	if len(m.services.items) == 0 && len(m.topBar.projects) == 0 {
+	if m.showingWelcome() {
 		view = m.viewWelcome()
 	} else {
 		topBar := m.topBar.View()
@@ -256,36 +483,29 @@ func (m Model) View() string {
 		m.statusBar.selectionMode = m.logs.selectionMode
 		statusBar := m.statusBar.View()
 
-		// top bar (1) + separators (2) + toast row (1) + status bar (2)
-		middleHeight := m.height - 6
-		if middleHeight < 1 {
-			middleHeight = 1
-		}
-		sidebarWidth := 24
-		if m.width < 60 {
-			sidebarWidth = 16
-		}
-
-		m.services.width = sidebarWidth
-		m.services.height = middleHeight
+		layout := m.layoutInfo()
+		m.services.width = layout.sidebarWidth
+		m.services.height = layout.middleHeight
 		m.services.active = m.activePane == paneServices
 
-		logsWidth := m.width - sidebarWidth - 3
-		m.logs.width = logsWidth
-		m.logs.height = middleHeight
+		m.logs.width = layout.logsWidth
+		m.logs.height = layout.logsHeight
 		m.logs.active = m.activePane == paneLogs
 
 		sidebar := m.services.View()
 		logView := m.logs.View()
+		if m.splitService != "" {
+			logView = m.viewSplitLogs(layout, logView)
+		}
 
 		middle := lipgloss.JoinHorizontal(
 			lipgloss.Top,
 			sidebar,
-			lipgloss.NewStyle().Foreground(colorBorder).Render(" │ "),
+			lipgloss.NewStyle().Foreground(theme.Border).Render(" │ "),
 			logView,
 		)
 
-		sep := lipgloss.NewStyle().Foreground(colorBorder).Width(m.width).Render(
+		sep := lipgloss.NewStyle().Foreground(theme.Border).Width(m.width).Render(
 			"─" + repeat("─", m.width-1),
 		)
 
@@ -300,11 +520,36 @@ func (m Model) View() string {
 	if m.focusPromptVisible {
 		view = placeOverlay(m.width, m.height, m.viewFocusPrompt(), view)
 	}
+	if m.stopConfirm != nil {
+		view = placeOverlay(m.width, m.height, m.viewStopConfirm(), view)
+	}
+	if m.helpVisible {
+		view = placeOverlay(m.width, m.height, viewHelp(m.width, m.height), view)
+	}
 
 	// Always paint a full-frame buffer to avoid stale artifacts from previous frames.
 	return lipgloss.NewStyle().Width(m.width).Height(m.height).Render(view)
 }
 
+// OfferOnboarding makes the welcome screen suggest onboarding dir, which
+// should be an unregistered directory that looks like a project.
+func (m *Model) OfferOnboarding(dir string) {
+	m.onboardDir = dir
+}
+
+// OnboardRequested returns the directory the user chose to onboard from the
+// welcome screen, or "" if they did not.
+func (m Model) OnboardRequested() string {
+	if !m.onboardRequested {
+		return ""
+	}
+	return m.onboardDir
+}
+
+func (m Model) showingWelcome() bool {
+	return len(m.services.items) == 0 && len(m.topBar.projects) == 0
+}
+
 func (m Model) viewWelcome() string {
 	title := welcomeTitleStyle.Render("Welcome to hun")
 	subtitle := welcomeTextStyle.Render("Seamless dev project context switching")
@@ -313,6 +558,10 @@ func (m Model) viewWelcome() string {
 		welcomeKeyStyle.Render("q") + welcomeTextStyle.Render(" quit")
 
 	hint := welcomeTextStyle.Render("or run ") + welcomeKeyStyle.Render("hun run <project>") + welcomeTextStyle.Render(" from your terminal")
+	if m.onboardDir != "" {
+		subtitle = welcomeTextStyle.Render("This directory looks like a project: " + filepath.Base(m.onboardDir))
+		keys = welcomeKeyStyle.Render("o") + welcomeTextStyle.Render(" onboard it") + "    " + keys
+	}
 
 	content := lipgloss.JoinVertical(lipgloss.Center,
 		"",
@@ -385,6 +634,9 @@ func (m Model) copyFlashStepCmd(id int) tea.Cmd {
 }
 
 func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.stopConfirm != nil {
+		return m.handleStopConfirmKey(msg)
+	}
 	if m.focusPromptVisible {
 		return m.handleFocusPromptKey(msg)
 	}
@@ -394,12 +646,29 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.searching {
 		return m.handleSearchKey(msg)
 	}
+	if m.jumping {
+		return m.handleJumpKey(msg)
+	}
+	if m.helpVisible {
+		switch msg.String() {
+		case "esc", "?":
+			m.helpVisible = false
+		case "ctrl+c":
+			m.cancelSubscription()
+			return m, tea.Quit
+		}
+		return m, nil
+	}
 
 	switch {
 	case key.Matches(msg, key.NewBinding(key.WithKeys("q", "ctrl+c"))):
 		m.cancelSubscription()
 		return m, tea.Quit
 
+	case key.Matches(msg, key.NewBinding(key.WithKeys("?"))):
+		m.helpVisible = true
+		return m, nil
+
 	case key.Matches(msg, key.NewBinding(key.WithKeys("left"))):
 		m.activePane = paneServices
 		return m, nil
@@ -510,15 +779,145 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case key.Matches(msg, key.NewBinding(key.WithKeys("t"))):
+		if m.activePane != paneLogs {
+			return m, nil
+		}
+		m.logs.toggleSticky()
+		if m.logs.sticky {
+			return m, m.showToast("Sticky tail: follows while at the last line")
+		}
+		return m, m.showToast("Explicit live mode")
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("A"))):
+		m.logs.autoFollow = !m.logs.autoFollow
+		m.logActivity = make(map[string][]time.Time)
+		m.ensureSubscription()
+		if m.logs.autoFollow {
+			return m, m.showToast("Auto-follow: logs jump to the busiest service")
+		}
+		return m, m.showToast("Auto-follow off")
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("z"))):
+		if m.activePane != paneLogs {
+			return m, nil
+		}
+		m.logs.toggleGroupTraces()
+		if m.logs.groupTraces {
+			return m, m.showToast("Grouping multi-line traces")
+		}
+		return m, m.showToast("Trace grouping off")
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("[", "]"))):
+		delta := sidebarResizeStep
+		if msg.String() == "[" {
+			delta = -delta
+		}
+		m.resizeSidebar(delta)
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("T"))):
+		if m.activePane != paneLogs {
+			return m, nil
+		}
+		m.logs.toggleRelativeTime()
+		if m.splitService != "" {
+			m.splitLogs.relativeTime = m.logs.relativeTime
+			m.splitLogs.normalize()
+		}
+		if m.logs.relativeTime {
+			return m, m.showToast("Timestamps relative to service start")
+		}
+		return m, m.showToast("Clock timestamps")
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+l"))):
+		if m.activePane != paneLogs || m.logs.service == "" {
+			return m, nil
+		}
+		if m.logs.service == "all" {
+			return m, m.showToast("Clearing works per service; pick one to clear its logs")
+		}
+		m.markFreshLogsForService(m.focusedProject, m.logs.service, time.Now())
+		return m, m.showToast("Cleared logs for " + m.logs.service)
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("1", "2", "3", "4"))):
+		thresholds := map[string]logSeverity{
+			"1": logSeverityNeutral,
+			"2": logSeverityInfo,
+			"3": logSeverityWarning,
+			"4": logSeverityError,
+		}
+		m.logs.setMinSeverity(thresholds[msg.String()])
+		if label := severityFilterLabel(m.logs.minSeverity); label != "" {
+			return m, m.showToast("Showing " + label + " log lines")
+		}
+		return m, m.showToast("Showing all log levels")
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("M"))):
+		if m.activePane != paneLogs {
+			return m, nil
+		}
+		m.logs.toggleHideMeta()
+		if m.logs.hideMeta {
+			return m, m.showToast("Hiding hun's own log lines")
+		}
+		return m, m.showToast("Showing hun's own log lines")
+
 	case key.Matches(msg, key.NewBinding(key.WithKeys("w"))):
 		if m.activePane == paneLogs {
 			m.logs.toggleWrap()
+			if m.logs.service == "all" || m.logs.service == "" {
+				m.wrapDefault = m.logs.wrap
+			} else {
+				m.wrapByService[projectServiceKey(m.focusedProject, m.logs.service)] = m.logs.wrap
+			}
+		}
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("i"))):
+		m.logs.showInfo = !m.logs.showInfo
+		if m.logs.showInfo {
+			// Re-read .hun.yml on every toggle so edits show up without restarting the TUI.
+			delete(m.projectConfigs, m.focusedProject)
+		}
+		m.syncServiceInfo()
+		m.logs.normalize()
+		if m.logs.showInfo && m.logs.service != "" && m.logs.service != "all" && m.logs.serviceCmd == "" {
+			return m, m.showToast("No command info for " + m.logs.service)
 		}
 		return m, nil
 
+	case key.Matches(msg, key.NewBinding(key.WithKeys("'"))):
+		if m.activePane != paneServices || len(m.services.items) == 0 {
+			return m, nil
+		}
+		m.jumping = true
+		m.services.jumping = true
+		m.services.jumpQuery = ""
+		return m, m.jumpExpireCmd()
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("#"))):
+		if m.focusedProject == "" {
+			return m, nil
+		}
+		delete(m.projectConfigs, m.focusedProject)
+		tags := m.projectConfig(m.focusedProject).tags()
+		if len(tags) == 0 {
+			m.tagFilter = ""
+			return m, m.showToast("No tagged services in " + m.focusedProject)
+		}
+		m.tagFilter = nextTag(tags, m.tagFilter)
+		cmds := m.refreshServices()
+		toast := "Showing all services"
+		if m.tagFilter != "" {
+			toast = "Showing services tagged " + m.tagFilter
+		}
+		cmds = append(cmds, m.showToast(toast))
+		return m, tea.Batch(cmds...)
+
 	case key.Matches(msg, key.NewBinding(key.WithKeys("v", "V"))):
 		if m.activePane == paneLogs {
-			m.logs.startSelectionMode()
+			m.logs.startSelectionMode(msg.String() == "V")
 		}
 		return m, nil
 
@@ -528,6 +927,13 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			m.activePane = paneLogs
+			svc := m.services.items[m.services.selected]
+			if m.enterStarts && (svc.stopped || svc.crashed) && !svc.disabled && !svc.holding {
+				m.markFreshLogsForService(m.focusedProject, svc.name, time.Now())
+				cmd := m.refreshLogs()
+				m.followFreshLogs()
+				return m, tea.Batch(cmd, m.restartServiceCmd(), m.showToast("Starting "+svc.name+"..."))
+			}
 			if cmd := m.refreshLogs(); cmd != nil {
 				return m, cmd
 			}
@@ -564,6 +970,76 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, tea.Batch(flashCmd, m.showToast("Copied "+pluralizeLines(count)))
 
+	case key.Matches(msg, key.NewBinding(key.WithKeys("H"))):
+		if m.activePane != paneLogs || m.logs.service == "" {
+			return m, nil
+		}
+		rows := m.logs.exportRows()
+		if len(rows) == 0 {
+			return m, m.showToast("Nothing to export")
+		}
+		title := m.focusedProject + " / " + m.logs.service
+		path, err := writeLogExport(m.focusedProject, m.logs.service, "html", renderLogsHTML(title, rows))
+		if err != nil {
+			return m, m.showToast("Export failed: " + err.Error())
+		}
+		if m.logs.selectionMode {
+			m.logs.clearSelection()
+		}
+		return m, m.showToast("Exported HTML to " + path)
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("e"))):
+		if m.activePane != paneLogs || m.logs.service == "" {
+			return m, nil
+		}
+		text, count := m.logs.exportText()
+		if count == 0 {
+			return m, m.showToast("Nothing to export")
+		}
+		path, err := writeLogExport(m.focusedProject, m.logs.service, "log", text)
+		if err != nil {
+			return m, m.showToast("Export failed: " + err.Error())
+		}
+		return m, m.showToast(fmt.Sprintf("Exported %d lines to %s", count, path))
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("O"))):
+		if m.focusedProject == "" {
+			return m, nil
+		}
+		service := ""
+		if m.logs.service != "all" && len(m.services.items) > 0 {
+			service = m.services.items[m.services.selected].name
+		}
+		return m, m.showToast(revealLogFiles(m.focusedProject, service))
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("C"))):
+		if m.activePane != paneServices || len(m.services.items) == 0 || m.focusedProject == "" {
+			return m, nil
+		}
+		path, err := daemon.LogFilePath(m.focusedProject, m.services.items[m.services.selected].name)
+		if err != nil {
+			return m, m.showToast("Copy failed: " + err.Error())
+		}
+		if err := copyToClipboard(path); err != nil {
+			return m, m.showToast("Copy failed: " + err.Error())
+		}
+		return m, m.showToast("Copied " + path)
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("B"))):
+		if len(m.services.items) == 0 || m.focusedProject == "" {
+			return m, nil
+		}
+		name := m.services.items[m.services.selected].name
+		var svc projectServiceInfo
+		if proj := m.projectConfig(m.focusedProject); proj != nil {
+			svc = proj.Services[name]
+		}
+		payload := reproBundle(m.focusedProject, name, m.latestStatus[m.focusedProject][name], svc, m.reproLines(name))
+		if err := copyToClipboard(payload); err != nil {
+			return m, m.showToast("Copy failed: " + err.Error())
+		}
+		return m, m.showToast("Copied reproduction for " + name)
+
 	case key.Matches(msg, key.NewBinding(key.WithKeys("y", "Y"))):
 		if m.activePane != paneLogs {
 			return m, nil
@@ -586,12 +1062,24 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if len(m.services.items) > 0 {
 			svcName = m.services.items[m.services.selected].name
 			m.markFreshLogsForService(m.focusedProject, svcName, time.Now())
+			if m.logs.service == svcName || m.logs.service == "all" {
+				m.followFreshLogs()
+			}
 		}
 		cmd := tea.Batch(m.restartServiceCmd(), m.showToast("Restarting "+svcName+"..."))
 		return m, cmd
 
 	case key.Matches(msg, key.NewBinding(key.WithKeys("R"))):
+		if m.focusedProject != "" {
+			previous := make(map[string]daemon.ServiceInfo, len(m.latestStatus[m.focusedProject]))
+			for name, info := range m.latestStatus[m.focusedProject] {
+				previous[name] = info
+			}
+			m.restart = &projectRestart{project: m.focusedProject, began: time.Now(), previous: previous}
+			m.refreshServices()
+		}
 		m.markFreshLogsForProject(m.focusedProject, time.Now())
+		m.followFreshLogs()
 		cmd := tea.Batch(m.restartProjectCmd(), m.showToast("Restarting project..."))
 		return m, cmd
 
@@ -628,27 +1116,52 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if len(m.services.items) == 0 || m.focusedProject == "" {
 			return m, nil
 		}
-		svc := &m.services.items[m.services.selected]
+		svc := m.services.items[m.services.selected]
 		if !svc.running && !svc.crashed {
 			return m, m.showToast(svc.name + " already stopped")
 		}
-		svc.running = false
-		svc.ready = false
-		svc.crashed = false
-		svc.stopped = true
-		if m.logs.service == svc.name {
-			m.logs.serviceStatus = "stopped"
-			m.logs.clearSelection()
+		if m.projectConfig(m.focusedProject).protects(svc.name) {
+			m.stopConfirm = &stopConfirmation{project: m.focusedProject, service: svc.name}
+			return m, nil
 		}
-		m.projectStopGuard = time.Now().Add(500 * time.Millisecond)
-		cmd := tea.Batch(m.stopServiceCmd(svc.name), m.showToast("Stopping "+svc.name+"..."))
-		return m, cmd
+		return m, m.stopSelectedService()
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("*"))):
+		if len(m.services.items) == 0 || m.focusedProject == "" {
+			return m, nil
+		}
+		svc := m.services.items[m.services.selected]
+		pinned := !svc.pinned
+		m.setPinned(m.focusedProject, svc.name, pinned)
+		toast := "Pinned " + svc.name
+		if !pinned {
+			toast = "Unpinned " + svc.name
+		}
+		return m, tea.Batch(m.setPinnedCmd(m.focusedProject, svc.name, pinned), m.showToast(toast))
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("P"))):
+		if len(m.services.items) == 0 || m.focusedProject == "" {
+			return m, nil
+		}
+		svc := &m.services.items[m.services.selected]
+		if !svc.running {
+			return m, m.showToast(svc.name + " is not running")
+		}
+		svc.paused = !svc.paused
+		if svc.paused {
+			return m, tea.Batch(m.pauseServiceCmd(svc.name, "pause"), m.showToast("Paused "+svc.name))
+		}
+		return m, tea.Batch(m.pauseServiceCmd(svc.name, "resume"), m.showToast("Resumed "+svc.name))
 
 	case key.Matches(msg, key.NewBinding(key.WithKeys("s"))):
 		if time.Now().Before(m.projectStopGuard) {
 			return m, nil
 		}
 		if m.focusedProject != "" {
+			if m.projectConfig(m.focusedProject).protects("") {
+				m.stopConfirm = &stopConfirmation{project: m.focusedProject}
+				return m, nil
+			}
 			cmd := tea.Batch(m.stopFocusedProjectCmd(), m.showToast("Stopping "+m.focusedProject+"..."))
 			return m, cmd
 		}
@@ -659,8 +1172,51 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.searchBuf = ""
 		m.logs.searching = true
 
+	case key.Matches(msg, key.NewBinding(key.WithKeys("n", "N"))):
+		if m.activePane != paneLogs || m.logs.search == "" {
+			return m, nil
+		}
+		var found, wrapped bool
+		if msg.String() == "n" {
+			found, wrapped = m.logs.nextMatch()
+		} else {
+			found, wrapped = m.logs.prevMatch()
+		}
+		switch {
+		case !found:
+			return m, m.showToast("No matches")
+		case wrapped && msg.String() == "n":
+			return m, m.showToast("Search wrapped to top")
+		case wrapped:
+			return m, m.showToast("Search wrapped to bottom")
+		}
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("o"))):
+		if m.onboardDir != "" && m.showingWelcome() {
+			m.onboardRequested = true
+			m.cancelSubscription()
+			return m, tea.Quit
+		}
+		if m.activePane != paneServices || len(m.services.items) == 0 {
+			return m, nil
+		}
+		item := m.services.items[m.services.selected]
+		return m, m.showToast(openServiceURL(item.name, item.port))
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("|"))):
+		return m, m.toggleSplit()
+
 	case key.Matches(msg, key.NewBinding(key.WithKeys("a"))):
 		m.activePane = paneLogs
+		m.closeSplit()
+		if m.logs.service == "all" {
+			m.logs.clearSelection()
+			if idx := m.services.indexOf(m.prevService); idx >= 0 {
+				m.services.selected = idx
+			}
+			return m, m.refreshLogs()
+		}
+		m.prevService = m.logs.service
 		m.logs.service = "all"
 		m.logs.serviceStatus = ""
 		m.logs.clearSelection()
@@ -688,6 +1244,61 @@ func isPaneToggleEasterEgg(msg tea.KeyMsg) bool {
 	}
 }
 
+// stopSelectedService stops the service under the sidebar cursor, marking it
+// stopped right away so the sidebar doesn't wait for the next status poll.
+func (m *Model) stopSelectedService() tea.Cmd {
+	svc := &m.services.items[m.services.selected]
+	svc.running = false
+	svc.ready = false
+	svc.crashed = false
+	svc.stopped = true
+	if m.logs.service == svc.name {
+		m.logs.serviceStatus = "stopped"
+		m.logs.clearSelection()
+	}
+	m.projectStopGuard = time.Now().Add(500 * time.Millisecond)
+	return tea.Batch(m.stopServiceCmd(svc.name), m.showToast("Stopping "+svc.name+"..."))
+}
+
+func (m Model) handleStopConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	pending := m.stopConfirm
+	switch msg.String() {
+	case "y", "Y", "enter":
+		m.stopConfirm = nil
+	case "n", "N", "esc", "q":
+		m.stopConfirm = nil
+		return m, m.showToast("Stop cancelled")
+	default:
+		return m, nil
+	}
+	if pending.project != m.focusedProject {
+		return m, nil
+	}
+	if pending.service == "" {
+		return m, tea.Batch(m.stopFocusedProjectCmd(), m.showToast("Stopping "+m.focusedProject+"..."))
+	}
+	if len(m.services.items) == 0 || m.services.items[m.services.selected].name != pending.service {
+		return m, nil
+	}
+	return m, m.stopSelectedService()
+}
+
+func (m Model) viewStopConfirm() string {
+	target := m.stopConfirm.project
+	if m.stopConfirm.service != "" {
+		target = m.stopConfirm.project + " / " + m.stopConfirm.service
+	}
+	lines := []string{
+		pickerTitle.Render("protected"),
+		"",
+		descStyle.Render("Stop " + target + "?"),
+		"",
+		descStyle.Render("It is marked protect: true in .hun.yml."),
+		descStyle.Render("[y] stop  [n] cancel"),
+	}
+	return pickerStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
 func (m Model) handleFocusPromptKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
 	case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
@@ -742,6 +1353,12 @@ func (m Model) handlePickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m.activatePickerItem(item)
 		}
 
+	case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+f"))):
+		if item, ok := m.picker.toggleFavorite(); ok {
+			m.picker.height = pickerHeightFor(m.picker.filtered, m.height)
+			return m, m.setFavoriteCmd(item.name, item.favorite)
+		}
+
 	case key.Matches(msg, key.NewBinding(key.WithKeys("up"))):
 		m.picker.move(-1)
 	case key.Matches(msg, key.NewBinding(key.WithKeys("down"))):
@@ -798,26 +1415,92 @@ func (m Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
 		m.searching = false
 		m.logs.searching = false
-		m.logs.setSearch(m.searchBuf)
+		return m, m.badRegexToast(m.logs.setSearch(m.searchBuf))
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+r"))):
+		if err := m.logs.toggleSearchRegex(); err != nil {
+			return m, m.badRegexToast(err)
+		}
+		if m.logs.searchRegex {
+			return m, m.showToast("Regex search")
+		}
+		return m, m.showToast("Substring search")
 
 	case key.Matches(msg, key.NewBinding(key.WithKeys("backspace"))):
 		if len(m.searchBuf) > 0 {
 			m.searchBuf = m.searchBuf[:len(m.searchBuf)-1]
-			m.logs.setSearch(m.searchBuf)
+			return m, m.badRegexToast(m.logs.setSearch(m.searchBuf))
 		}
 
 	default:
 		if len(msg.Runes) > 0 {
 			m.searchBuf += string(msg.Runes)
-			m.logs.setSearch(m.searchBuf)
+			return m, m.badRegexToast(m.logs.setSearch(m.searchBuf))
 		}
 	}
 
 	return m, nil
 }
 
+// badRegexToast reports a regex search that didn't compile; the previous
+// pattern keeps filtering until the text compiles again.
+func (m *Model) badRegexToast(err error) tea.Cmd {
+	if err == nil {
+		return nil
+	}
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) {
+		return m.showToast("bad regex: " + string(syntaxErr.Code))
+	}
+	return m.showToast("bad regex: " + err.Error())
+}
+
+// handleJumpKey extends the sidebar jump query. Keys other than text, backspace,
+// enter and esc end the jump and are handled normally.
+func (m Model) handleJumpKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("esc", "enter"))):
+		m.endJump()
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("backspace"))):
+		if q := []rune(m.services.jumpQuery); len(q) > 0 {
+			m.services.jumpQuery = string(q[:len(q)-1])
+		}
+		return m, m.jumpExpireCmd()
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) > 0:
+		m.services.jumpQuery += string(msg.Runes)
+		cmds := []tea.Cmd{m.jumpExpireCmd()}
+		if idx := m.services.jumpTarget(m.services.jumpQuery); idx >= 0 && idx != m.services.selected {
+			m.services.selected = idx
+			if cmd := m.refreshLogs(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+		return m, tea.Batch(cmds...)
+	}
+
+	m.endJump()
+	return m.handleKey(msg)
+}
+
+func (m *Model) endJump() {
+	m.jumping = false
+	m.services.jumping = false
+	m.services.jumpQuery = ""
+}
+
+func (m *Model) jumpExpireCmd() tea.Cmd {
+	m.jumpTimer++
+	id := m.jumpTimer
+	return tea.Tick(1500*time.Millisecond, func(t time.Time) tea.Msg {
+		return jumpExpireMsg{id: id}
+	})
+}
+
 func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
-	if m.focusPromptVisible {
+	if m.focusPromptVisible || m.stopConfirm != nil {
 		return m, nil
 	}
 	if m.picker.visible {
@@ -895,8 +1578,20 @@ func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	// Split logs pane: the wheel scrolls it; selection stays in the main pane.
+	if m.splitService != "" && msg.X >= layout.splitX && msg.X < layout.splitX+layout.splitWidth &&
+		msg.Y >= layout.splitY && msg.Y < layout.splitY+layout.splitHeight {
+		switch {
+		case isWheelUp:
+			m.splitLogs.scrollRows(-2)
+		case isWheelDown:
+			m.splitLogs.scrollRows(2)
+		}
+		return m, nil
+	}
+
 	// Logs pane.
-	if msg.X >= layout.logsX && msg.X < layout.logsX+layout.logsWidth {
+	if msg.X >= layout.logsX && msg.X < layout.logsX+layout.logsWidth && msg.Y < layout.middleY+layout.logsHeight {
 		m.activePane = paneLogs
 		switch {
 		case isWheelUp:
@@ -906,7 +1601,7 @@ func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 			m.logs.scrollRows(2)
 			return m, nil
 		case isLeftPress:
-			row := msg.Y - layout.middleY - 2 // header + spacer
+			row := msg.Y - layout.middleY - m.logs.headerRows()
 			if row >= 0 {
 				if msg.Shift {
 					m.logs.setCursorFromVisibleRow(row, true)
@@ -917,7 +1612,7 @@ func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		case isLeftDrag:
-			row := msg.Y - layout.middleY - 2 // header + spacer
+			row := msg.Y - layout.middleY - m.logs.headerRows()
 			if row >= 0 {
 				m.logs.setCursorFromVisibleRow(row, true)
 			}
@@ -1000,28 +1695,80 @@ type layoutInfo struct {
 	sidebarWidth int
 	logsX        int
 	logsWidth    int
+	logsHeight   int
+
+	// The split logs pane, when open: beside the main one on wide
+	// terminals (splitBeside), else below it.
+	splitBeside bool
+	splitX      int
+	splitY      int
+	splitWidth  int
+	splitHeight int
 }
 
+// Sidebar width bounds; [ and ] resize it within them.
+const (
+	sidebarMinWidth     = 16
+	sidebarDefaultWidth = 24
+	sidebarResizeStep   = 2
+)
+
 func (m Model) layoutInfo() layoutInfo {
 	middleHeight := m.height - 6 // top bar + separators + toast + statusbar
 	if middleHeight < 1 {
 		middleHeight = 1
 	}
-	sidebarWidth := 24
-	if m.width < 60 {
-		sidebarWidth = 16
-	}
+	sidebarWidth := m.clampSidebarWidth(m.sidebarWidth)
 	logsWidth := m.width - sidebarWidth - 3
 	if logsWidth < 1 {
 		logsWidth = 1
 	}
-	return layoutInfo{
+	layout := layoutInfo{
 		middleY:      2,
 		middleHeight: middleHeight,
 		sidebarWidth: sidebarWidth,
 		logsX:        sidebarWidth + 3,
 		logsWidth:    logsWidth,
+		logsHeight:   middleHeight,
+	}
+	if m.splitService == "" {
+		return layout
+	}
+	if logsWidth >= splitBesideMinWidth {
+		layout.splitBeside = true
+		layout.logsWidth = (logsWidth - 3) / 2
+		layout.splitX = layout.logsX + layout.logsWidth + 3
+		layout.splitY = layout.middleY
+		layout.splitWidth = logsWidth - layout.logsWidth - 3
+		layout.splitHeight = middleHeight
+	} else {
+		layout.logsHeight = max((middleHeight-1)/2, 1)
+		layout.splitX = layout.logsX
+		layout.splitY = layout.middleY + layout.logsHeight + 1
+		layout.splitWidth = logsWidth
+		layout.splitHeight = max(middleHeight-layout.logsHeight-1, 1)
 	}
+	return layout
+}
+
+// splitBesideMinWidth is the narrowest logs area that puts the split pane
+// beside the main one rather than below it.
+const splitBesideMinWidth = 100
+
+// viewSplitLogs lays the split pane out next to main, the rendered main
+// logs pane, separated by a rule.
+func (m Model) viewSplitLogs(layout layoutInfo, main string) string {
+	m.splitLogs.width = layout.splitWidth
+	m.splitLogs.height = layout.splitHeight
+	m.splitLogs.hideMeta = m.logs.hideMeta
+	m.splitLogs.relativeTime = m.logs.relativeTime
+	m.splitLogs.serviceStarts = m.startedAt
+	main = lipgloss.NewStyle().Width(layout.logsWidth).Height(layout.logsHeight).MaxHeight(layout.logsHeight).Render(main)
+	rule := lipgloss.NewStyle().Foreground(theme.Border)
+	if layout.splitBeside {
+		return lipgloss.JoinHorizontal(lipgloss.Top, main, rule.Render(" │ "), m.splitLogs.View())
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, main, rule.Render(repeat("─", layout.splitWidth)), m.splitLogs.View())
 }
 
 func (m Model) pickerBounds() (x int, y int, w int, h int) {
@@ -1057,25 +1804,35 @@ func (m *Model) updateLayout() {
 	m.statusBar.activePane = m.activePane
 	m.statusBar.selectionMode = m.logs.selectionMode
 
-	middleHeight := m.height - 6
-	if middleHeight < 1 {
-		middleHeight = 1
-	}
-	sidebarWidth := 24
+	layout := m.layoutInfo()
+	m.services.width = layout.sidebarWidth
+	m.services.height = layout.middleHeight
+	m.logs.width = layout.logsWidth
+	m.logs.height = layout.logsHeight
+	m.splitLogs.width = layout.splitWidth
+	m.splitLogs.height = layout.splitHeight
+}
+
+// clampSidebarWidth fits a requested sidebar width to the terminal: at
+// least sidebarMinWidth, and at most a third of the width (or the default,
+// when that is larger). Narrow terminals always get the minimum.
+func (m Model) clampSidebarWidth(width int) int {
 	if m.width < 60 {
-		sidebarWidth = 16
-	}
-	logsWidth := m.width - sidebarWidth - 3
-	if logsWidth < 1 {
-		logsWidth = 1
+		return sidebarMinWidth
 	}
-	m.services.width = sidebarWidth
-	m.services.height = middleHeight
-	m.logs.width = logsWidth
-	m.logs.height = middleHeight
+	upper := max(sidebarDefaultWidth, m.width/3)
+	return min(max(width, sidebarMinWidth), upper)
+}
+
+// resizeSidebar grows or shrinks the sidebar by delta columns.
+func (m *Model) resizeSidebar(delta int) {
+	m.sidebarWidth = m.clampSidebarWidth(m.clampSidebarWidth(m.sidebarWidth) + delta)
+	m.updateLayout()
+	m.logs.normalize()
 }
 
 func (m *Model) applyStatus(status statusUpdateMsg) []tea.Cmd {
+	status = m.holdRestartingProject(status)
 	m.latestStatus = status
 	m.applyServiceStartMarkers(status)
 
@@ -1122,6 +1879,34 @@ func (m *Model) applyStatus(status statusUpdateMsg) []tea.Cmd {
 	return cmds
 }
 
+// holdRestartingProject fills a restarting project's services that the daemon
+// doesn't report yet with their state from before the restart. The hold ends
+// once the daemon has answered and the project is back in status.
+func (m *Model) holdRestartingProject(status statusUpdateMsg) statusUpdateMsg {
+	r := m.restart
+	if r == nil {
+		return status
+	}
+	current, present := status[r.project]
+	if (r.done && present) || time.Since(r.began) > restartHoldMax {
+		m.restart = nil
+		return status
+	}
+	merged := make(map[string]daemon.ServiceInfo, len(r.previous))
+	for name, info := range r.previous {
+		merged[name] = info
+	}
+	for name, info := range current {
+		merged[name] = info
+	}
+	held := make(statusUpdateMsg, len(status)+1)
+	for project, services := range status {
+		held[project] = services
+	}
+	held[r.project] = merged
+	return held
+}
+
 func (m *Model) applyServiceStartMarkers(status statusUpdateMsg) {
 	seen := make(map[string]struct{})
 	for project, services := range status {
@@ -1135,6 +1920,8 @@ func (m *Model) applyServiceStartMarkers(status statusUpdateMsg) {
 			if !ok || !info.StartedAt.Equal(prev) {
 				m.startedAt[key] = info.StartedAt
 				m.markFreshLogsForService(project, service, info.StartedAt)
+				// The daemon re-read .hun.yml for this start; so do we.
+				delete(m.projectConfigs, project)
 			}
 		}
 	}
@@ -1166,8 +1953,17 @@ func (m *Model) refreshServices() []tea.Cmd {
 		}
 	}
 
+	proj := m.projectConfig(m.focusedProject)
+	if m.tagFilter != "" && !containsString(proj.tags(), m.tagFilter) {
+		m.tagFilter = ""
+	}
+	m.services.tagFilter = m.tagFilter
+
 	items := make([]serviceItem, 0, len(svcs))
 	for name, info := range svcs {
+		if !proj.serviceHasTag(name, m.tagFilter) {
+			continue
+		}
 		status := strings.TrimSpace(strings.ToLower(info.Status))
 		if status == "" {
 			if info.Running {
@@ -1177,16 +1973,24 @@ func (m *Model) refreshServices() []tea.Cmd {
 			}
 		}
 		items = append(items, serviceItem{
-			name:    name,
-			port:    info.Port,
-			running: info.Running,
-			ready:   info.Ready && info.Running,
-			crashed: !info.Running && status == "crashed",
-			stopped: !info.Running && status != "crashed",
+			name:     name,
+			port:     info.Port,
+			running:  info.Running,
+			ready:    info.Ready && info.Running,
+			crashed:  !info.Running && status == "crashed",
+			stopped:  !info.Running && status != "crashed",
+			disabled: !info.Running && status == "disabled",
+			holding:  m.restart != nil && m.restart.project == m.focusedProject && m.restart.pending(info),
+			paused:   info.Paused && info.Running,
+			restarts: info.Restarts,
+			cpu:      info.CPU,
+			memBytes: info.MemBytes,
+			pinned:   m.pinned[m.focusedProject+":"+name],
 		})
 	}
-	sort.Slice(items, func(i, j int) bool { return items[i].name < items[j].name })
+	sortServiceItems(items)
 	m.services.items = items
+	m.syncActivity(time.Now())
 
 	if m.services.selected >= len(items) {
 		m.services.selected = len(items) - 1
@@ -1204,9 +2008,83 @@ func (m *Model) refreshServices() []tea.Cmd {
 			cmds = append(cmds, cmd)
 		}
 	}
+	if cmd := m.refreshSplitLogs(); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
 	return cmds
 }
 
+// toggleSplit opens a second logs pane on the previously shown service, or
+// closes it. The main pane keeps following the sidebar selection.
+func (m *Model) toggleSplit() tea.Cmd {
+	if m.splitService != "" {
+		m.closeSplit()
+		return m.showToast("Split closed")
+	}
+	if m.focusedProject == "" || len(m.services.items) == 0 || m.logs.service == "all" {
+		return m.showToast("Select a service to split its logs")
+	}
+	if m.lastService == "" || m.lastService == m.logs.service || m.services.indexOf(m.lastService) < 0 {
+		return m.showToast("Show another service first, then press | to split")
+	}
+	m.splitProject = m.focusedProject
+	m.splitService = m.lastService
+	m.splitLogs = logsModel{
+		autoScroll:    true,
+		hideMeta:      m.logs.hideMeta,
+		relativeTime:  m.logs.relativeTime,
+		serviceStarts: m.startedAt,
+	}
+	m.updateLayout()
+	m.logs.normalize()
+	m.ensureSubscription()
+	cmds := []tea.Cmd{m.showToast("Split: " + m.logs.service + " | " + m.splitService)}
+	if cmd := m.refreshSplitLogs(); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) closeSplit() {
+	if m.splitService == "" {
+		return
+	}
+	m.splitProject = ""
+	m.splitService = ""
+	m.splitLogs = logsModel{}
+	m.updateLayout()
+	m.logs.normalize()
+	m.ensureSubscription()
+}
+
+// refreshSplitLogs loads the split pane's service from the log cache,
+// fetching its history the first time it is shown. The split closes once its
+// service leaves the sidebar, e.g. on a project switch or tag filter.
+func (m *Model) refreshSplitLogs() tea.Cmd {
+	if m.splitService == "" {
+		return nil
+	}
+	idx := m.services.indexOf(m.splitService)
+	if m.splitProject != m.focusedProject || idx < 0 {
+		m.closeSplit()
+		return nil
+	}
+	svc := m.services.items[idx]
+	m.splitLogs.service = svc.name
+	m.splitLogs.serviceStatus = svc.logStatus()
+	m.splitLogs.setWrap(m.serviceWrap(m.splitProject, svc.name))
+	m.splitLogs.logFormats = m.logs.logFormats
+	if m.splitLogs.serviceStatus == "stopped" {
+		m.splitLogs.setLines(nil)
+		return nil
+	}
+	m.splitLogs.setLines(m.allLogs[projectServiceKey(m.splitProject, svc.name)])
+	if len(m.splitLogs.lines) == 0 {
+		return m.fetchLogsCmd(m.splitProject, svc.name)
+	}
+	return nil
+}
+
 func (m *Model) refreshLogs() tea.Cmd {
 	if len(m.services.items) == 0 {
 		m.logs.setLines(nil)
@@ -1220,16 +2098,14 @@ func (m *Model) refreshLogs() tea.Cmd {
 	svc := m.services.items[m.services.selected]
 	if m.logs.service != svc.name {
 		m.logs.clearSelection()
+		if m.logs.service != "" && m.logs.service != "all" {
+			m.lastService = m.logs.service
+		}
 	}
 	m.logs.service = svc.name
-	switch {
-	case svc.crashed:
-		m.logs.serviceStatus = "crashed"
-	case svc.running:
-		m.logs.serviceStatus = "running"
-	default:
-		m.logs.serviceStatus = "stopped"
-	}
+	m.logs.setWrap(m.serviceWrap(m.focusedProject, svc.name))
+	m.syncServiceInfo()
+	m.logs.serviceStatus = svc.logStatus()
 	if m.logs.serviceStatus == "stopped" {
 		m.logs.setLines(nil)
 		m.ensureSubscription()
@@ -1246,14 +2122,146 @@ func (m *Model) refreshLogs() tea.Cmd {
 	return nil
 }
 
+const (
+	autoFollowWindow   = 5 * time.Second
+	autoFollowCooldown = 3 * time.Second
+	autoFollowMinLines = 3
+)
+
+// autoFollowActivity records line for the focused project and moves the logs
+// pane to the busiest service once it clearly out-logs the current one. The
+// cooldown and 2x margin keep two chatty services from flipping back and forth.
+func (m *Model) autoFollowActivity(line daemon.LogLine) tea.Cmd {
+	if m.logActivity == nil {
+		m.logActivity = make(map[string][]time.Time)
+	}
+	now := line.Timestamp
+	key := projectServiceKey(line.Project, line.Service)
+	m.logActivity[key] = append(m.logActivity[key], now)
+
+	cutoff := now.Add(-autoFollowWindow)
+	counts := make(map[string]int)
+	for k, stamps := range m.logActivity {
+		kept := stamps[:0]
+		for _, ts := range stamps {
+			if ts.After(cutoff) {
+				kept = append(kept, ts)
+			}
+		}
+		if len(kept) == 0 {
+			delete(m.logActivity, k)
+			continue
+		}
+		m.logActivity[k] = kept
+		counts[k] = len(kept)
+	}
+
+	if !m.autoSwitchedAt.IsZero() && now.Sub(m.autoSwitchedAt) < autoFollowCooldown {
+		return nil
+	}
+	best, bestCount := "", 0
+	for _, item := range m.services.items {
+		if c := counts[projectServiceKey(m.focusedProject, item.name)]; c > bestCount {
+			best, bestCount = item.name, c
+		}
+	}
+	current := counts[projectServiceKey(m.focusedProject, m.logs.service)]
+	if best == "" || best == m.logs.service || bestCount < autoFollowMinLines || bestCount <= 2*current {
+		return nil
+	}
+	idx := m.services.indexOf(best)
+	if idx < 0 {
+		return nil
+	}
+	m.services.selected = idx
+	m.autoSwitchedAt = now
+	return m.refreshLogs()
+}
+
+// syncServiceInfo copies the project's log formats into the logs pane and,
+// while the info line is shown, the selected service's command and cwd.
+func (m *Model) syncServiceInfo() {
+	m.logs.serviceCmd = ""
+	m.logs.serviceCwd = ""
+	m.logs.logFormats = m.projectConfig(m.focusedProject).logFormats()
+	if !m.logs.showInfo || m.focusedProject == "" || m.logs.service == "" || m.logs.service == "all" {
+		return
+	}
+	proj := m.projectConfig(m.focusedProject)
+	if proj == nil {
+		return
+	}
+	if svc, ok := proj.Services[m.logs.service]; ok {
+		m.logs.serviceCmd = svc.Cmd
+		m.logs.serviceCwd = svc.Cwd
+	}
+}
+
+// projectConfig returns the cached .hun.yml info for project, loading it via
+// the registry on first use. A nil result (unknown or invalid project) is cached too.
+func (m *Model) projectConfig(project string) *projectConfigInfo {
+	if project == "" {
+		return nil
+	}
+	if proj, ok := m.projectConfigs[project]; ok {
+		return proj
+	}
+	var proj *projectConfigInfo
+	if st, err := state.Load(); err == nil {
+		if path, ok := st.Registry[project]; ok {
+			if loaded, err := loadProjectConfig(path); err == nil {
+				proj = loaded
+			}
+		}
+	}
+	m.projectConfigs[project] = proj
+	return proj
+}
+
+// reproLines returns the buffered log lines for one service of the focused
+// project, from the "all" view or the service's own view, whichever is loaded.
+func (m *Model) reproLines(service string) []daemon.LogLine {
+	if lines, ok := m.allLogs[m.focusedProject+":"+service]; ok && len(lines) > 0 {
+		return lines
+	}
+	if m.logs.service != service && m.logs.service != "all" {
+		return nil
+	}
+	var lines []daemon.LogLine
+	for _, line := range m.logs.lines {
+		if line.Service == service {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// serviceWrap reports whether service's logs should wrap: its remembered w
+// preference, or the global default when it has none yet.
+func (m *Model) serviceWrap(project, service string) bool {
+	if wrap, ok := m.wrapByService[projectServiceKey(project, service)]; ok {
+		return wrap
+	}
+	return m.wrapDefault
+}
+
 func (m *Model) refreshAllLogs() {
 	m.logs.serviceStatus = ""
+	m.logs.setWrap(m.wrapDefault)
 	all := make([]daemon.LogLine, 0)
 	prefix := m.focusedProject + ":"
+	var proj *projectConfigInfo
+	if m.tagFilter != "" {
+		proj = m.projectConfig(m.focusedProject)
+	}
 	for key, lines := range m.allLogs {
-		if strings.HasPrefix(key, prefix) {
-			all = append(all, lines...)
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if m.tagFilter != "" && !proj.serviceHasTag(strings.TrimPrefix(key, prefix), m.tagFilter) {
+			continue
 		}
+		all = append(all, lines...)
 	}
 	sort.Slice(all, func(i, j int) bool {
 		return all[i].Timestamp.Before(all[j].Timestamp)
@@ -1267,7 +2275,7 @@ func (m *Model) ensureSubscription() {
 
 	if m.focusedProject != "" {
 		targetProject = m.focusedProject
-		if m.logs.service == "all" {
+		if m.logs.service == "all" || m.logs.autoFollow || m.splitService != "" {
 			targetService = ""
 		} else if len(m.services.items) > 0 && m.services.selected >= 0 && m.services.selected < len(m.services.items) {
 			targetService = m.services.items[m.services.selected].name
@@ -1348,21 +2356,17 @@ func (m *Model) openPicker() {
 				}
 			}
 		}
-		items = append(items, pickerItem{name: name, running: running, svcs: svcs})
+		items = append(items, pickerItem{name: name, running: running, favorite: st.Projects[name].Favorite, svcs: svcs})
 	}
 
-	sort.Slice(items, func(i, j int) bool {
-		if items[i].running != items[j].running {
-			return items[i].running
-		}
-		return items[i].name < items[j].name
-	})
+	sort.Slice(items, func(i, j int) bool { return items[i].name < items[j].name })
 
 	m.picker = pickerModel{
-		visible:  true,
-		items:    items,
-		filtered: items,
-		width:    m.pickerWidth(),
+		visible:          true,
+		items:            items,
+		filtered:         items,
+		width:            m.pickerWidth(),
+		favoritesGrouped: m.favoritesGrouped,
 	}
 	m.picker.filter()
 	m.picker.height = pickerHeightFor(m.picker.filtered, m.height)
@@ -1441,18 +2445,11 @@ func pickerContentRows(items []pickerItem) int {
 		return 1
 	}
 	rows := len(items)
-	seenRunning := false
-	seenStopped := false
-	for _, item := range items {
-		if item.running {
-			seenRunning = true
-		} else {
-			seenStopped = true
+	for i := 1; i < len(items); i++ {
+		if items[i].group != items[i-1].group {
+			rows++ // separator row between sections
 		}
 	}
-	if seenRunning && seenStopped {
-		rows++ // separator row between running and stopped groups
-	}
 	return rows
 }
 
@@ -1463,16 +2460,16 @@ func (m Model) fetchStatusCmd() tea.Cmd {
 		if m.client == nil {
 			return nil
 		}
-		resp, err := m.client.Send(daemon.Request{Action: "status"})
+		resp, err := m.client.Send(daemon.Request{Action: "status_since", Revision: m.statusRevision})
 		if err != nil {
-			return err
+			return statusFailedMsg{err: err}
 		}
 		if !resp.OK {
 			return nil
 		}
-		var status statusUpdateMsg
-		_ = json.Unmarshal(resp.Data, &status)
-		return status
+		var delta daemon.StatusDelta
+		_ = json.Unmarshal(resp.Data, &delta)
+		return statusDeltaMsg{revision: delta.Revision, unchanged: delta.Unchanged, status: delta.Status}
 	}
 }
 
@@ -1496,12 +2493,65 @@ func (m Model) fetchLogsCmd(project, service string) tea.Cmd {
 	}
 }
 
+// statusFailuresBeforeDown is how many polls in a row must fail before the
+// status bar reports the daemon as down rather than reconnecting.
+const statusFailuresBeforeDown = 2
+
+// Status polling adapts to activity: quick while a start/stop/restart settles,
+// slow once nobody has touched the TUI for a while.
+const (
+	pollIntervalActive = time.Second
+	pollIntervalNormal = 2 * time.Second
+	pollIntervalIdle   = 5 * time.Second
+	pollActiveWindow   = 10 * time.Second
+	pollIdleAfter      = time.Minute
+)
+
+// usageRefreshInterval is how often the sidebar's CPU and memory update.
+const usageRefreshInterval = 5 * time.Second
+
+func (m Model) pollInterval(now time.Time) time.Duration {
+	switch {
+	case now.Before(m.pollFastUntil):
+		return pollIntervalActive
+	case !m.lastInputAt.IsZero() && now.Sub(m.lastInputAt) >= pollIdleAfter:
+		return pollIntervalIdle
+	}
+	return pollIntervalNormal
+}
+
 func (m Model) tickCmd() tea.Cmd {
-	return tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
-		return tickMsg(t)
+	id := m.pollID
+	return tea.Tick(m.pollInterval(time.Now()), func(time.Time) tea.Msg {
+		return tickMsg{id: id}
 	})
 }
 
+// reschedulePoll replaces the pending status tick with one at the current
+// interval, so a shorter interval takes effect immediately.
+func (m *Model) reschedulePoll() tea.Cmd {
+	m.pollID++
+	return m.tickCmd()
+}
+
+// expectStatusChange switches to fast polling after a lifecycle action.
+func (m *Model) expectStatusChange() tea.Cmd {
+	m.pollFastUntil = time.Now().Add(pollActiveWindow)
+	return m.reschedulePoll()
+}
+
+// noteInput records user activity. Coming back from idle refreshes status
+// right away instead of waiting out the slow interval.
+func (m *Model) noteInput() tea.Cmd {
+	now := time.Now()
+	wasIdle := m.pollInterval(now) == pollIntervalIdle
+	m.lastInputAt = now
+	if !wasIdle {
+		return nil
+	}
+	return tea.Batch(m.fetchStatusCmd(), m.reschedulePoll())
+}
+
 func (m Model) waitForLogCmd() tea.Cmd {
 	return func() tea.Msg {
 		line := <-m.logCh
@@ -1522,8 +2572,8 @@ func (m Model) retrySubscribeCmd() tea.Cmd {
 	})
 }
 
-func (m Model) restartServiceCmd() tea.Cmd {
-	return func() tea.Msg {
+func (m *Model) restartServiceCmd() tea.Cmd {
+	return tea.Batch(m.expectStatusChange(), func() tea.Msg {
 		if len(m.services.items) == 0 || m.client == nil {
 			return nil
 		}
@@ -1534,28 +2584,35 @@ func (m Model) restartServiceCmd() tea.Cmd {
 			Service: svc.name,
 		})
 		return nil
-	}
+	})
 }
 
-func (m Model) restartProjectCmd() tea.Cmd {
-	return func() tea.Msg {
-		if m.focusedProject == "" || m.client == nil {
+func (m *Model) restartProjectCmd() tea.Cmd {
+	project := m.focusedProject
+	return tea.Batch(m.expectStatusChange(), func() tea.Msg {
+		if project == "" || m.client == nil {
 			return nil
 		}
-		_, _ = m.client.Send(daemon.Request{
+		resp, err := m.client.Send(daemon.Request{
 			Action:  "restart",
-			Project: m.focusedProject,
+			Project: project,
 		})
-		return nil
-	}
+		if err != nil {
+			return projectRestartedMsg{project: project, err: err.Error()}
+		}
+		if !resp.OK {
+			return projectRestartedMsg{project: project, err: resp.Error}
+		}
+		return projectRestartedMsg{project: project}
+	})
 }
 
-func (m Model) stopFocusedProjectCmd() tea.Cmd {
+func (m *Model) stopFocusedProjectCmd() tea.Cmd {
 	return m.stopProjectCmd(m.focusedProject)
 }
 
-func (m Model) stopServiceCmd(service string) tea.Cmd {
-	return func() tea.Msg {
+func (m *Model) stopServiceCmd(service string) tea.Cmd {
+	return tea.Batch(m.expectStatusChange(), func() tea.Msg {
 		if service == "" || m.focusedProject == "" || m.client == nil {
 			return nil
 		}
@@ -1572,27 +2629,55 @@ func (m Model) stopServiceCmd(service string) tea.Cmd {
 		}
 		msg := strings.TrimSpace(resp.Error)
 		if strings.Contains(msg, "unknown action: stop_service") {
-			msg = "daemon is stale; restart hun daemon and retry"
+			msg = "daemon is stale; run `hun daemon restart` and retry"
 		}
 		if msg == "" {
 			msg = "unknown daemon error"
 		}
 		return stopServiceResultMsg{err: msg}
-	}
+	})
 }
 
-func (m Model) stopProjectCmd(project string) tea.Cmd {
-	return func() tea.Msg {
+// pauseServiceCmd sends a "pause" or "resume" action for service.
+func (m *Model) pauseServiceCmd(service, action string) tea.Cmd {
+	return tea.Batch(m.expectStatusChange(), func() tea.Msg {
+		if service == "" || m.focusedProject == "" || m.client == nil {
+			return nil
+		}
+		resp, err := m.client.Send(daemon.Request{
+			Action:  action,
+			Project: m.focusedProject,
+			Service: service,
+		})
+		if err != nil {
+			return pauseServiceResultMsg{err: err.Error()}
+		}
+		if resp == nil || resp.OK {
+			return pauseServiceResultMsg{}
+		}
+		msg := strings.TrimSpace(resp.Error)
+		if strings.Contains(msg, "unknown action: "+action) {
+			msg = "daemon is stale; run `hun daemon restart` and retry"
+		}
+		if msg == "" {
+			msg = action + " failed"
+		}
+		return pauseServiceResultMsg{err: msg}
+	})
+}
+
+func (m *Model) stopProjectCmd(project string) tea.Cmd {
+	return tea.Batch(m.expectStatusChange(), func() tea.Msg {
 		if project == "" || m.client == nil {
 			return nil
 		}
 		_, _ = m.client.Send(daemon.Request{Action: "stop", Project: project})
 		return nil
-	}
+	})
 }
 
-func (m Model) startProject(name string) tea.Cmd {
-	return func() tea.Msg {
+func (m *Model) startProject(name string) tea.Cmd {
+	return tea.Batch(m.expectStatusChange(), func() tea.Msg {
 		if m.client == nil {
 			return nil
 		}
@@ -1606,6 +2691,69 @@ func (m Model) startProject(name string) tea.Cmd {
 			Mode:    mode,
 		})
 		return nil
+	})
+}
+
+func (m Model) setFavoriteCmd(project string, favorite bool) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return nil
+		}
+		action := "clear_project_favorite"
+		if favorite {
+			action = "set_project_favorite"
+		}
+		return favoriteResultMsg{project: project, favorite: favorite, err: sendFailure(m.client.Send(daemon.Request{Action: action, Project: project}))}
+	}
+}
+
+// sendFailure describes why a request failed, or is empty when it succeeded.
+func sendFailure(resp *daemon.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	if resp == nil || resp.OK {
+		return ""
+	}
+	if msg := strings.TrimSpace(resp.Error); msg != "" {
+		return msg
+	}
+	return "request failed"
+}
+
+func (m Model) setPinnedCmd(project, service string, pinned bool) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return nil
+		}
+		action := "unpin_service"
+		if pinned {
+			action = "pin_service"
+		}
+		resp, err := m.client.Send(daemon.Request{Action: action, Project: project, Service: service})
+		return pinResultMsg{project: project, service: service, pinned: pinned, err: sendFailure(resp, err)}
+	}
+}
+
+// setPinned records a service's pin and, when its project is focused,
+// re-sorts the sidebar keeping the selection on the same service.
+func (m *Model) setPinned(project, service string, pinned bool) {
+	m.pinned[project+":"+service] = pinned
+	if project != m.focusedProject {
+		return
+	}
+	selected := ""
+	if m.services.selected < len(m.services.items) {
+		selected = m.services.items[m.services.selected].name
+	}
+	for i := range m.services.items {
+		if m.services.items[i].name == service {
+			m.services.items[i].pinned = pinned
+		}
+	}
+	sortServiceItems(m.services.items)
+	if selected != "" {
+		m.services.selected = m.services.indexOf(selected)
 	}
 }
 
@@ -1657,6 +2805,13 @@ func (m *Model) markFreshLogsForService(project, service string, cutoff time.Tim
 	}
 }
 
+// followFreshLogs puts the logs pane in LIVE mode at the bottom so output from
+// a restart streams in as it arrives. The all view stays aggregated.
+func (m *Model) followFreshLogs() {
+	m.logs.clearSelection()
+	m.logs.jumpBottom()
+}
+
 func (m *Model) markFreshLogsForProject(project string, cutoff time.Time) {
 	if project == "" {
 		return
@@ -1753,13 +2908,123 @@ func loadProjectConfig(path string) (*projectConfigInfo, error) {
 	if err != nil {
 		return nil, err
 	}
-	services := make(map[string]interface{}, len(proj.Services))
-	for name := range proj.Services {
-		services[name] = struct{}{}
-	}
-	return &projectConfigInfo{Services: services}, nil
+	services := make(map[string]projectServiceInfo, len(proj.Services))
+	for name, svc := range proj.Services {
+		// A cwd using $HUN_PORT keeps the reference, since the port is only
+		// picked at start.
+		expanded := *svc
+		expanded.Cwd, _ = daemon.ExpandHunNames(svc.Cwd, proj.Name, name)
+		services[name] = projectServiceInfo{
+			Cmd:       svc.Cmd,
+			Cwd:       expanded.WorkDir(path),
+			Tags:      svc.Tags,
+			LogFormat: svc.LogFormat,
+			Protect:   svc.Protect,
+		}
+	}
+	return &projectConfigInfo{Services: services, Protect: proj.Protect}, nil
 }
 
 type projectConfigInfo struct {
-	Services map[string]interface{}
+	Services map[string]projectServiceInfo
+	Protect  bool
+}
+
+type projectServiceInfo struct {
+	Cmd       string
+	Cwd       string // resolved against the project root
+	Tags      []string
+	LogFormat string
+	Protect   bool
+}
+
+// protects mirrors config.Project.Protects for the cached config.
+func (p *projectConfigInfo) protects(service string) bool {
+	if p == nil {
+		return false
+	}
+	if p.Protect {
+		return true
+	}
+	if service != "" {
+		return p.Services[service].Protect
+	}
+	for _, svc := range p.Services {
+		if svc.Protect {
+			return true
+		}
+	}
+	return false
+}
+
+// logFormats maps services to their declared log_format, omitting unset ones.
+func (p *projectConfigInfo) logFormats() map[string]string {
+	if p == nil {
+		return nil
+	}
+	formats := make(map[string]string)
+	for name, svc := range p.Services {
+		if svc.LogFormat != "" {
+			formats[name] = svc.LogFormat
+		}
+	}
+	return formats
+}
+
+func (p *projectConfigInfo) tags() []string {
+	if p == nil {
+		return nil
+	}
+	seen := make(map[string]struct{})
+	for _, svc := range p.Services {
+		for _, tag := range svc.Tags {
+			seen[strings.ToLower(tag)] = struct{}{}
+		}
+	}
+	tags := make([]string, 0, len(seen))
+	for tag := range seen {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// serviceHasTag reports whether service passes the tag filter. An empty tag
+// matches everything, and services missing from the config are kept visible.
+func (p *projectConfigInfo) serviceHasTag(service, tag string) bool {
+	if tag == "" || p == nil {
+		return true
+	}
+	svc, ok := p.Services[service]
+	if !ok {
+		return true
+	}
+	for _, t := range svc.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextTag cycles through tags and back to "" (no filter).
+func nextTag(tags []string, current string) string {
+	if current == "" {
+		return tags[0]
+	}
+	for i, tag := range tags {
+		if tag == current && i+1 < len(tags) {
+			return tags[i+1]
+		}
+	}
+	return ""
+}
+
+func containsString(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
 }
@@ -2,15 +2,20 @@ package tui
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sourabhrathourr/hun/internal/client"
+	"github.com/sourabhrathourr/hun/internal/config"
 	"github.com/sourabhrathourr/hun/internal/daemon"
+	"github.com/sourabhrathourr/hun/internal/state"
 )
 
 func TestNewRestoresModeAndActiveProjectFromState(t *testing.T) {
@@ -100,6 +105,92 @@ func TestPickerEnterSwitchesFocusImmediately(t *testing.T) {
 	}
 }
 
+func TestPickerListsFavoritesFirstAndCtrlFTogglesThem(t *testing.T) {
+	m := New(false)
+	m.client = nil
+	m.picker = pickerModel{visible: true, items: []pickerItem{
+		{name: "alpha"},
+		{name: "beta", running: true},
+		{name: "zeta", favorite: true},
+	}}
+	m.picker.filter()
+	names := func(items []pickerItem) string {
+		var out []string
+		for _, item := range items {
+			out = append(out, item.name)
+		}
+		return strings.Join(out, ",")
+	}
+	if got := names(m.picker.filtered); got != "zeta,beta,alpha" {
+		t.Fatalf("order = %s, want the stopped favorite above running projects", got)
+	}
+	if rows := pickerContentRows(m.picker.filtered); rows != 5 {
+		t.Fatalf("content rows = %d, want 3 items and 2 separators", rows)
+	}
+
+	m.picker.selected = 2
+	updated, _ := m.handlePickerKey(tea.KeyMsg{Type: tea.KeyCtrlF})
+	m = updated.(Model)
+	if got := names(m.picker.filtered); got != "alpha,zeta,beta" {
+		t.Fatalf("order after ctrl+f = %s", got)
+	}
+	if item, _ := m.picker.selectedItem(); item.name != "alpha" || !item.favorite {
+		t.Fatalf("cursor should follow the toggled project, got %+v", item)
+	}
+
+	m.picker.favoritesGrouped = true
+	m.picker.filter()
+	if got := names(m.picker.filtered); got != "beta,alpha,zeta" {
+		t.Fatalf("grouped order = %s, want favorites first within running and stopped", got)
+	}
+}
+
+func TestFailedPinRevertsSidebarAndShowsToast(t *testing.T) {
+	m := New(false)
+	m.client = nil
+	m.focusedProject = "shop"
+	m.services.items = []serviceItem{{name: "api"}, {name: "web"}}
+	m.services.selected = 1
+
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'*'}})
+	m = updated.(Model)
+	if m.services.items[0].name != "web" || !m.services.items[0].pinned {
+		t.Fatalf("pinning should sort web first, got %+v", m.services.items)
+	}
+
+	updated, _ = m.Update(pinResultMsg{project: "shop", service: "web", pinned: true, err: "state.json is read-only"})
+	m = updated.(Model)
+	if m.pinned["shop:web"] || m.services.items[0].name != "api" || m.services.items[1].pinned {
+		t.Fatalf("a pin the daemon didn't save should be undone, got %+v", m.services.items)
+	}
+	if item := m.services.items[m.services.selected]; item.name != "web" {
+		t.Fatalf("selection should stay on web, got %s", item.name)
+	}
+	if !strings.Contains(m.toast, "Pin failed: state.json is read-only") {
+		t.Fatalf("toast = %q", m.toast)
+	}
+}
+
+func TestFailedFavoriteRevertsPickerAndShowsToast(t *testing.T) {
+	m := New(false)
+	m.client = nil
+	m.picker = pickerModel{visible: true, items: []pickerItem{{name: "alpha"}, {name: "zeta"}}}
+	m.picker.filter()
+	m.picker.selected = 1
+	item, _ := m.picker.toggleFavorite()
+
+	updated, _ := m.Update(favoriteResultMsg{project: item.name, favorite: item.favorite, err: "state.json is read-only"})
+	m = updated.(Model)
+	for _, it := range m.picker.items {
+		if it.favorite {
+			t.Fatalf("%s still marked favorite after the daemon failed to save it", it.name)
+		}
+	}
+	if !strings.Contains(m.toast, "Favorite failed: state.json is read-only") {
+		t.Fatalf("toast = %q", m.toast)
+	}
+}
+
 func TestOpenPickerUsesCompactHeight(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
@@ -795,7 +886,7 @@ func TestKeyCClearsSelectionAfterCopy(t *testing.T) {
 		{Project: "proj", Service: "svc", Text: "line-1", Timestamp: time.Now().Add(-time.Second)},
 		{Project: "proj", Service: "svc", Text: "line-2", Timestamp: time.Now()},
 	})
-	m.logs.startSelectionMode()
+	m.logs.startSelectionMode(false)
 	m.logs.moveCursor(-1)
 	if !m.logs.selectionMode {
 		t.Fatal("expected selection mode enabled before copy")
@@ -845,7 +936,7 @@ func TestKeyYClearsSelectionAfterYank(t *testing.T) {
 		{Project: "proj", Service: "svc", Text: "line-1", Timestamp: time.Now().Add(-time.Second)},
 		{Project: "proj", Service: "svc", Text: "line-2", Timestamp: time.Now()},
 	})
-	m.logs.startSelectionMode()
+	m.logs.startSelectionMode(false)
 	m.logs.moveCursor(-1)
 	if !m.logs.selectionMode {
 		t.Fatal("expected selection mode enabled before yank")
@@ -1016,3 +1107,1077 @@ func TestKeyFInLogsPaneOpensFocusPromptInMultitask(t *testing.T) {
 		t.Fatalf("mode changed unexpectedly: %q", m2.mode)
 	}
 }
+
+func TestKeyIShowsServiceCommandInLogsHeader(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	projectDir := t.TempDir()
+	projectYAML := "name: proj\nservices:\n  api:\n    cmd: go run ./cmd/api\n    cwd: ./backend\n"
+	if err := os.WriteFile(filepath.Join(projectDir, ".hun.yml"), []byte(projectYAML), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+	hunDir := filepath.Join(home, ".hun")
+	if err := os.MkdirAll(hunDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	stateJSON := `{"projects":{},"registry":{"proj":"` + projectDir + `"}}`
+	if err := os.WriteFile(filepath.Join(hunDir, "state.json"), []byte(stateJSON), 0o644); err != nil {
+		t.Fatalf("write state: %v", err)
+	}
+
+	m := New(false)
+	m.client = nil
+	m.focusedProject = "proj"
+	m.services.items = []serviceItem{{name: "api", running: true}}
+	m.logs.service = "api"
+	m.logs.width = 100
+	m.logs.height = 12
+	visibleBefore := m.logs.visibleRows()
+
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	m2 := updated.(Model)
+	if m2.logs.serviceCmd != "go run ./cmd/api" {
+		t.Fatalf("serviceCmd = %q, want go run ./cmd/api", m2.logs.serviceCmd)
+	}
+	if want := filepath.Join(projectDir, "backend"); m2.logs.serviceCwd != want {
+		t.Fatalf("serviceCwd = %q, want %q", m2.logs.serviceCwd, want)
+	}
+	if !strings.Contains(m2.logs.View(), "$ go run ./cmd/api") {
+		t.Fatalf("expected command info line in logs header, got:\n%s", m2.logs.View())
+	}
+	if m2.logs.visibleRows() != visibleBefore-1 {
+		t.Fatalf("visibleRows = %d, want %d with info line", m2.logs.visibleRows(), visibleBefore-1)
+	}
+
+	updated2, _ := m2.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	m3 := updated2.(Model)
+	if strings.Contains(m3.logs.View(), "go run ./cmd/api") {
+		t.Fatal("expected command info line hidden after second toggle")
+	}
+}
+
+func TestServiceRestartRereadsLogFormatFromConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	projectDir := t.TempDir()
+	writeConfig := func(format string) {
+		t.Helper()
+		projectYAML := "name: proj\nservices:\n  api:\n    cmd: go run .\n    log_format: " + format + "\n"
+		if err := os.WriteFile(filepath.Join(projectDir, ".hun.yml"), []byte(projectYAML), 0o644); err != nil {
+			t.Fatalf("write project config: %v", err)
+		}
+	}
+	writeConfig("json")
+	hunDir := filepath.Join(home, ".hun")
+	if err := os.MkdirAll(hunDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	stateJSON := `{"projects":{},"registry":{"proj":"` + projectDir + `"}}`
+	if err := os.WriteFile(filepath.Join(hunDir, "state.json"), []byte(stateJSON), 0o644); err != nil {
+		t.Fatalf("write state: %v", err)
+	}
+
+	m := New(false)
+	m.client = nil
+	m.focusedProject = "proj"
+	started := time.Now()
+	m.applyServiceStartMarkers(statusUpdateMsg{"proj": {"api": {Running: true, StartedAt: started}}})
+	if got := m.projectConfig("proj").logFormats()["api"]; got != "json" {
+		t.Fatalf("log format = %q, want json", got)
+	}
+
+	writeConfig("logfmt")
+	m.applyServiceStartMarkers(statusUpdateMsg{"proj": {"api": {Running: true, StartedAt: started}}})
+	if got := m.projectConfig("proj").logFormats()["api"]; got != "json" {
+		t.Fatalf("log format = %q, want the cached json until a restart", got)
+	}
+	m.applyServiceStartMarkers(statusUpdateMsg{"proj": {"api": {Running: true, StartedAt: started.Add(time.Second)}}})
+	if got := m.projectConfig("proj").logFormats()["api"]; got != "logfmt" {
+		t.Fatalf("log format = %q, want logfmt after the restart", got)
+	}
+}
+
+func TestHashKeyCyclesSidebarTagFilter(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	projectDir := t.TempDir()
+	projectYAML := "name: proj\nservices:\n  api:\n    cmd: go run .\n    tags: [backend]\n  db:\n    cmd: postgres\n    tags: [infra]\n  web:\n    cmd: bun run dev\n"
+	if err := os.WriteFile(filepath.Join(projectDir, ".hun.yml"), []byte(projectYAML), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+	hunDir := filepath.Join(home, ".hun")
+	if err := os.MkdirAll(hunDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	stateJSON := `{"projects":{},"registry":{"proj":"` + projectDir + `"}}`
+	if err := os.WriteFile(filepath.Join(hunDir, "state.json"), []byte(stateJSON), 0o644); err != nil {
+		t.Fatalf("write state: %v", err)
+	}
+
+	m := New(false)
+	m.client = nil
+	m.focusedProject = "proj"
+	m.latestStatus = statusUpdateMsg{
+		"proj": {
+			"api": daemon.ServiceInfo{Running: true},
+			"db":  daemon.ServiceInfo{Running: true},
+			"web": daemon.ServiceInfo{Running: true},
+		},
+	}
+	m.refreshServices()
+	if len(m.services.items) != 3 {
+		t.Fatalf("expected 3 services without filter, got %d", len(m.services.items))
+	}
+
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("#")})
+	m2 := updated.(Model)
+	if m2.tagFilter != "backend" {
+		t.Fatalf("tagFilter = %q, want backend", m2.tagFilter)
+	}
+	if len(m2.services.items) != 1 || m2.services.items[0].name != "api" {
+		t.Fatalf("expected only api for #backend, got %+v", m2.services.items)
+	}
+
+	updated2, _ := m2.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("#")})
+	m3 := updated2.(Model)
+	if m3.tagFilter != "infra" {
+		t.Fatalf("tagFilter = %q, want infra", m3.tagFilter)
+	}
+
+	updated3, _ := m3.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("#")})
+	m4 := updated3.(Model)
+	if m4.tagFilter != "" || len(m4.services.items) != 3 {
+		t.Fatalf("expected filter cleared after last tag, got %q with %d services", m4.tagFilter, len(m4.services.items))
+	}
+}
+
+func TestStarPinsServiceAboveOthersAndKeepsSelection(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	st, err := state.Load()
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	st.Projects["proj"] = state.ProjectState{Pinned: []string{"worker"}}
+	if err := st.Save(); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	m := New(false)
+	m.client = nil
+	m.focusedProject = "proj"
+	m.latestStatus = statusUpdateMsg{
+		"proj": {
+			"api":    daemon.ServiceInfo{Running: true},
+			"web":    daemon.ServiceInfo{Running: true},
+			"worker": daemon.ServiceInfo{Running: true},
+		},
+	}
+	m.refreshServices()
+	names := func(m Model) string {
+		var out []string
+		for _, item := range m.services.items {
+			out = append(out, item.name)
+		}
+		return strings.Join(out, ",")
+	}
+	if got := names(m); got != "worker,api,web" {
+		t.Fatalf("order = %s, want worker pinned from state first", got)
+	}
+
+	m.services.selected = m.services.indexOf("web")
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("*")})
+	m = updated.(Model)
+	if got := names(m); got != "web,worker,api" {
+		t.Fatalf("order after pinning web = %s, want web,worker,api", got)
+	}
+	if m.services.items[m.services.selected].name != "web" {
+		t.Fatalf("selection = %q, want web to stay selected", m.services.items[m.services.selected].name)
+	}
+	if !strings.Contains(m.services.View(), "web "+pinMark) {
+		t.Fatalf("pinned web should render with the pin mark:\n%s", m.services.View())
+	}
+
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("*")})
+	m = updated.(Model)
+	m.refreshServices()
+	if got := names(m); got != "worker,api,web" {
+		t.Fatalf("order after unpinning web = %s, want worker,api,web", got)
+	}
+}
+
+func TestKeyAToggleReturnsToPreviousService(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	m := New(false)
+	m.client = nil
+	m.focusedProject = "proj"
+	m.latestStatus = statusUpdateMsg{
+		"proj": {
+			"api": daemon.ServiceInfo{Running: true},
+			"web": daemon.ServiceInfo{Running: true},
+		},
+	}
+	m.refreshServices()
+	m.services.selected = m.services.indexOf("web")
+	m.refreshLogs()
+	if m.logs.service != "web" {
+		t.Fatalf("service = %q, want web", m.logs.service)
+	}
+
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m2 := updated.(Model)
+	if m2.logs.service != "all" {
+		t.Fatalf("service = %q, want all", m2.logs.service)
+	}
+
+	m2.services.selected = m2.services.indexOf("api")
+	updated2, _ := m2.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m3 := updated2.(Model)
+	if m3.logs.service != "web" {
+		t.Fatalf("service = %q, want web restored from all view", m3.logs.service)
+	}
+	if m3.services.items[m3.services.selected].name != "web" {
+		t.Fatalf("sidebar selection = %q, want web", m3.services.items[m3.services.selected].name)
+	}
+}
+
+func TestRestartKeyForcesLiveTail(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	m := New(false)
+	m.client = nil
+	m.focusedProject = "proj"
+	m.latestStatus = statusUpdateMsg{
+		"proj": {
+			"api": daemon.ServiceInfo{Running: true},
+			"web": daemon.ServiceInfo{Running: true},
+		},
+	}
+	m.refreshServices()
+	m.services.selected = m.services.indexOf("api")
+	m.refreshLogs()
+
+	for _, view := range []string{"api", "all"} {
+		m.logs.service = view
+		m.logs.autoScroll = false
+		m.logs.startSelectionMode(false)
+
+		updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+		got := updated.(Model)
+		if !got.logs.autoScroll || got.logs.selectionMode {
+			t.Fatalf("%s view: autoScroll=%v selection=%v, want LIVE without selection", view, got.logs.autoScroll, got.logs.selectionMode)
+		}
+		if got.logs.service != view {
+			t.Fatalf("restart changed logs view to %q, want %q", got.logs.service, view)
+		}
+	}
+}
+
+func TestSidebarTypeToJumpSelectsMatchingService(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	m := New(false)
+	m.client = nil
+	m.focusedProject = "proj"
+	m.activePane = paneServices
+	m.latestStatus = statusUpdateMsg{
+		"proj": {
+			"api":           daemon.ServiceInfo{Running: true},
+			"billing-queue": daemon.ServiceInfo{Running: true},
+			"worker":        daemon.ServiceInfo{Running: true},
+			"web":           daemon.ServiceInfo{Running: true},
+		},
+	}
+	m.refreshServices()
+
+	press := func(m Model, keys string) Model {
+		for _, r := range keys {
+			updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			m = updated.(Model)
+		}
+		return m
+	}
+
+	m = press(m, "'wo")
+	if got := m.services.items[m.services.selected].name; got != "worker" {
+		t.Fatalf("selected = %q, want worker", got)
+	}
+	if m.logs.service != "worker" || !strings.Contains(m.services.View(), "'wo") {
+		t.Fatalf("expected logs to follow and query shown, logs=%q", m.logs.service)
+	}
+
+	// "queue" has no prefix match, so it falls back to a substring match.
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	m = press(updated.(Model), "'queue")
+	if got := m.services.items[m.services.selected].name; got != "billing-queue" {
+		t.Fatalf("selected = %q, want billing-queue", got)
+	}
+
+	// Non-text keys end the jump and keep their usual meaning.
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(Model)
+	if m.jumping || m.services.items[m.services.selected].name != "web" {
+		t.Fatalf("expected jump ended and selection moved down, jumping=%v selected=%q", m.jumping, m.services.items[m.services.selected].name)
+	}
+}
+
+func TestAutoFollowSwitchesToBusiestService(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	m := New(false)
+	m.client = nil
+	m.focusedProject = "proj"
+	m.latestStatus = statusUpdateMsg{
+		"proj": {
+			"api":    daemon.ServiceInfo{Running: true},
+			"worker": daemon.ServiceInfo{Running: true},
+		},
+	}
+	m.refreshServices()
+	m.services.selected = m.services.indexOf("api")
+	m.refreshLogs()
+
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	m = updated.(Model)
+	if !m.logs.autoFollow {
+		t.Fatal("expected auto-follow to be enabled")
+	}
+
+	base := time.Now()
+	send := func(service string, offset time.Duration) {
+		next, _ := m.Update(logMsg(daemon.LogLine{Project: "proj", Service: service, Text: "tick", Timestamp: base.Add(offset)}))
+		m = next.(Model)
+	}
+	send("api", 0)
+	send("worker", 100*time.Millisecond)
+	send("worker", 200*time.Millisecond)
+	if m.logs.service != "api" {
+		t.Fatalf("service = %q, want api before worker clearly leads", m.logs.service)
+	}
+	send("worker", 300*time.Millisecond)
+	if m.logs.service != "worker" {
+		t.Fatalf("service = %q, want worker after it out-logs api", m.logs.service)
+	}
+
+	// Within the cooldown a burst from api must not flip the pane back.
+	for i := 0; i < 10; i++ {
+		send("api", time.Second+time.Duration(i)*time.Millisecond)
+	}
+	if m.logs.service != "worker" {
+		t.Fatalf("service = %q, want worker held during cooldown", m.logs.service)
+	}
+}
+
+func TestWelcomeOffersOnboardingForProjectCwd(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	m := New(false)
+	m.client = nil
+	m.width = 100
+	m.height = 30
+	if updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")}); updated.(Model).OnboardRequested() != "" {
+		t.Fatal("expected o to do nothing without an onboarding candidate")
+	}
+
+	dir := filepath.Join(t.TempDir(), "shop-api")
+	m.OfferOnboarding(dir)
+	if view := m.View(); !strings.Contains(view, "shop-api") || !strings.Contains(view, "onboard it") {
+		t.Fatalf("expected welcome screen to offer onboarding, got:\n%s", view)
+	}
+
+	updated, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if got := updated.(Model).OnboardRequested(); got != dir {
+		t.Fatalf("OnboardRequested() = %q, want %q", got, dir)
+	}
+	if cmd == nil {
+		t.Fatal("expected o to quit the TUI so onboarding can run")
+	}
+}
+
+func TestStatusPollingAdaptsToActivity(t *testing.T) {
+	m := New(false)
+	m.client = nil
+	now := time.Now()
+
+	if got := m.pollInterval(now); got != pollIntervalNormal {
+		t.Fatalf("initial interval = %v, want %v", got, pollIntervalNormal)
+	}
+
+	m.lastInputAt = now.Add(-2 * pollIdleAfter)
+	if got := m.pollInterval(now); got != pollIntervalIdle {
+		t.Fatalf("idle interval = %v, want %v", got, pollIntervalIdle)
+	}
+
+	staleID := m.pollID
+	m.stopServiceCmd("api")
+	if got := m.pollInterval(time.Now()); got != pollIntervalActive {
+		t.Fatalf("interval after an action = %v, want %v", got, pollIntervalActive)
+	}
+	if _, cmd := m.Update(tickMsg{id: staleID}); cmd != nil {
+		t.Fatal("expected the superseded tick to be dropped")
+	}
+
+	m.pollFastUntil = time.Time{}
+	if cmd := m.noteInput(); cmd == nil {
+		t.Fatal("expected input after idle to refresh status immediately")
+	}
+	if got := m.pollInterval(time.Now()); got != pollIntervalNormal {
+		t.Fatalf("interval after input = %v, want %v", got, pollIntervalNormal)
+	}
+}
+
+func TestStatusBarShowsDaemonHealth(t *testing.T) {
+	m := New(false)
+	m.client = nil
+	m.width = 200
+
+	updated, _ := m.Update(statusFailedMsg{err: errors.New("connection refused")})
+	m = updated.(Model)
+	if m.statusBar.health != daemonReconnecting {
+		t.Fatalf("health after one failure = %v, want reconnecting", m.statusBar.health)
+	}
+	updated, _ = m.Update(statusFailedMsg{err: errors.New("connection refused")})
+	m = updated.(Model)
+	m.statusBar.width = m.width
+	if view := m.statusBar.View(); !strings.Contains(view, "run hun doctor") {
+		t.Fatalf("expected a doctor hint once the daemon is down, got:\n%s", view)
+	}
+
+	updated, _ = m.Update(statusUpdateMsg{})
+	m = updated.(Model)
+	if m.statusBar.health != daemonConnected {
+		t.Fatalf("health after a successful poll = %v, want connected", m.statusBar.health)
+	}
+}
+
+func TestStoppingProtectedServiceAsksFirst(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	m := New(false)
+	m.client = nil
+	m.focusedProject = "proj"
+	m.projectConfigs["proj"] = &projectConfigInfo{Services: map[string]projectServiceInfo{
+		"migrate": {Cmd: "make migrate", Protect: true},
+		"web":     {Cmd: "npm run dev"},
+	}}
+	m.latestStatus = statusUpdateMsg{
+		"proj": {
+			"migrate": daemon.ServiceInfo{Running: true},
+			"web":     daemon.ServiceInfo{Running: true},
+		},
+	}
+	m.refreshServices()
+	m.services.selected = m.services.indexOf("migrate")
+
+	press := func(m Model, key string) Model {
+		updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		return updated.(Model)
+	}
+
+	m = press(m, "x")
+	if m.stopConfirm == nil || m.stopConfirm.service != "migrate" {
+		t.Fatalf("expected a confirmation for the protected service, got %+v", m.stopConfirm)
+	}
+	m = press(m, "n")
+	if m.stopConfirm != nil || m.services.items[m.services.selected].stopped {
+		t.Fatal("expected n to cancel the stop")
+	}
+
+	m = press(m, "x")
+	m = press(m, "y")
+	if m.stopConfirm != nil || !m.services.items[m.services.selected].stopped {
+		t.Fatal("expected y to stop the protected service")
+	}
+
+	m.projectStopGuard = time.Time{}
+	m = press(m, "s")
+	if m.stopConfirm == nil || m.stopConfirm.service != "" {
+		t.Fatalf("expected stopping the project to ask because it has a protected service, got %+v", m.stopConfirm)
+	}
+}
+
+func TestDisabledServiceShowsAsOff(t *testing.T) {
+	m := New(false)
+	m.client = nil
+	m.focusedProject = "proj"
+	m.latestStatus = statusUpdateMsg{
+		"proj": {
+			"web":    daemon.ServiceInfo{Running: true, Ready: true, Status: "running"},
+			"worker": daemon.ServiceInfo{Status: "disabled"},
+		},
+	}
+	m.refreshServices()
+
+	var worker serviceItem
+	for _, item := range m.services.items {
+		if item.name == "worker" {
+			worker = item
+		}
+	}
+	if !worker.disabled || worker.running {
+		t.Fatalf("worker item = %+v, want disabled", worker)
+	}
+	m.services.width = 40
+	if view := m.services.View(); !strings.Contains(view, "off") {
+		t.Fatalf("expected disabled service to be labelled off, got:\n%s", view)
+	}
+}
+
+func TestRestartProjectHoldsServiceListUntilDaemonReports(t *testing.T) {
+	m := New(false)
+	m.client = nil
+	m.focusedProject = "proj"
+	before := time.Now().Add(-time.Minute)
+	m.applyStatus(statusUpdateMsg{
+		"proj":  {"api": daemon.ServiceInfo{Running: true, Ready: true, StartedAt: before}, "web": daemon.ServiceInfo{Running: true, StartedAt: before}},
+		"other": {"db": daemon.ServiceInfo{Running: true, StartedAt: before}},
+	})
+	m.services.selected = m.services.indexOf("web")
+
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	m = updated.(Model)
+	if m.restart == nil {
+		t.Fatal("R should hold the project's service list")
+	}
+
+	// The daemon has stopped the project and not started it yet.
+	m.applyStatus(statusUpdateMsg{"other": {"db": daemon.ServiceInfo{Running: true, StartedAt: before}}})
+	if m.focusedProject != "proj" || len(m.services.items) != 2 {
+		t.Fatalf("restarting project should stay focused with its services, got %q %+v", m.focusedProject, m.services.items)
+	}
+	if name := m.services.items[m.services.selected].name; name != "web" {
+		t.Fatalf("selection moved to %q during restart", name)
+	}
+	if !m.services.items[0].holding || !strings.Contains(m.services.View(), "restarting") {
+		t.Fatalf("held services should carry a restarting badge: %+v", m.services.items)
+	}
+
+	updated, _ = m.Update(projectRestartedMsg{project: "proj"})
+	m = updated.(Model)
+	after := time.Now()
+	m.applyStatus(statusUpdateMsg{
+		"proj":  {"api": daemon.ServiceInfo{Running: true, StartedAt: after}, "web": daemon.ServiceInfo{Running: true, StartedAt: after}},
+		"other": {"db": daemon.ServiceInfo{Running: true, StartedAt: before}},
+	})
+	if m.restart != nil {
+		t.Fatal("hold should end once the restarted project reports")
+	}
+	for _, item := range m.services.items {
+		if item.holding {
+			t.Fatalf("%s still marked as restarting", item.name)
+		}
+	}
+}
+
+// runCmdTimeout is how long runCmd waits for commands. Poll ticks finish
+// within a few seconds; waits on the log channel never do and are abandoned.
+// It is generous so daemon round trips complete on a loaded CI machine.
+const runCmdTimeout = 5 * time.Second
+
+// runCmd executes cmd and any batched commands concurrently, returning the
+// messages they produce within runCmdTimeout.
+func runCmd(cmd tea.Cmd) []tea.Msg {
+	msgs := make(chan tea.Msg, 64)
+	var wg sync.WaitGroup
+	var run func(tea.Cmd)
+	run = func(c tea.Cmd) {
+		if c == nil {
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			msg := c()
+			if batch, ok := msg.(tea.BatchMsg); ok {
+				for _, b := range batch {
+					run(b)
+				}
+				return
+			}
+			if msg != nil {
+				msgs <- msg
+			}
+		}()
+	}
+	run(cmd)
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(runCmdTimeout):
+	}
+	var out []tea.Msg
+	for {
+		select {
+		case msg := <-msgs:
+			out = append(out, msg)
+		default:
+			return out
+		}
+	}
+}
+
+func TestStopServiceKeyStopsServiceThroughInProcessDaemon(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	mgr, err := daemon.NewManager()
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer mgr.Shutdown()
+	proj := &config.Project{Name: "proj", Services: map[string]*config.Service{
+		"api": {Cmd: "sleep 5"},
+		"web": {Cmd: "sleep 5"},
+	}}
+	if err := mgr.StartProject("proj", proj, t.TempDir(), false); err != nil {
+		t.Fatalf("start project: %v", err)
+	}
+
+	m := New(false)
+	m.client = client.NewWithTransport(client.InProcess(daemon.NewInProcess(mgr)))
+	m.focusedProject = "proj"
+	for _, msg := range runCmd(m.fetchStatusCmd()) {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	m.services.selected = m.services.indexOf("api")
+	if m.services.selected < 0 || !m.services.items[m.services.selected].running {
+		t.Fatalf("api should be listed as running: %+v", m.services.items)
+	}
+
+	updated, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = updated.(Model)
+	for _, msg := range runCmd(cmd) {
+		if res, ok := msg.(stopServiceResultMsg); ok && res.err != "" {
+			t.Fatalf("stop_service failed: %s", res.err)
+		}
+	}
+	status := mgr.Status()["proj"]
+	if status["api"].Running || !status["web"].Running {
+		t.Fatalf("x should stop only api in the daemon: %+v", status)
+	}
+
+	for _, msg := range runCmd(m.fetchStatusCmd()) {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	if item := m.services.items[m.services.indexOf("api")]; item.running || !item.stopped {
+		t.Fatalf("refreshed sidebar should show api stopped: %+v", item)
+	}
+}
+
+func TestWrapPreferenceIsRememberedPerService(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	m := New(false)
+	m.client = nil
+	m.focusedProject = "proj"
+	m.latestStatus = statusUpdateMsg{
+		"proj": {
+			"api":    daemon.ServiceInfo{Running: true},
+			"web":    daemon.ServiceInfo{Running: true},
+			"worker": daemon.ServiceInfo{Running: true},
+		},
+	}
+	m.refreshServices()
+	m.activePane = paneLogs
+	m.services.selected = m.services.indexOf("web")
+	m.refreshLogs()
+
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	m = updated.(Model)
+	if !m.logs.wrap {
+		t.Fatal("w should wrap web's logs")
+	}
+
+	m.services.selected = m.services.indexOf("api")
+	m.refreshLogs()
+	if m.logs.wrap {
+		t.Fatal("api has no preference and should follow the unwrapped default")
+	}
+
+	// Toggling in the all view changes the default for services without a preference.
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m = updated.(Model)
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	m = updated.(Model)
+	if !m.wrapDefault {
+		t.Fatal("w in the all view should set the default")
+	}
+
+	m.services.selected = m.services.indexOf("worker")
+	m.refreshLogs()
+	if !m.logs.wrap {
+		t.Fatal("worker should pick up the new default")
+	}
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	m = updated.(Model)
+
+	m.services.selected = m.services.indexOf("web")
+	m.refreshLogs()
+	if !m.logs.wrap {
+		t.Fatal("web should keep its own wrap preference")
+	}
+	m.services.selected = m.services.indexOf("worker")
+	m.refreshLogs()
+	if m.logs.wrap {
+		t.Fatal("worker should keep its truncated preference")
+	}
+}
+
+func TestKeyUpperMHidesHunMetaLines(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".hun"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".hun", "config.yml"), []byte("logs:\n  hide_meta: true\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := New(false)
+	m.client = nil
+	m.activePane = paneLogs
+	m.logs.height = 12
+	m.logs.width = 100
+	m.logs.setLines([]daemon.LogLine{
+		{Project: "proj", Service: "api", Text: "GET /health 200", Timestamp: time.Now()},
+		{Project: "proj", Service: "api", Text: "[hun] detected runtime port 8081 (base 8080, offset 1)", IsMeta: true, Timestamp: time.Now()},
+		{Project: "proj", Service: "api", Text: "[hun] in the service's own output", Timestamp: time.Now()},
+	})
+	if got := len(m.logs.filteredLines()); got != 2 {
+		t.Fatalf("hide_meta should hide only hun's own line, got %d lines", got)
+	}
+
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")})
+	m = updated.(Model)
+	if got := len(m.logs.filteredLines()); got != 3 || !strings.Contains(m.logs.View(), "detected runtime port") {
+		t.Fatalf("M should show hun's lines again, got %d lines", got)
+	}
+}
+
+func TestEnterStartsStoppedServiceWhenConfigured(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".hun"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".hun", "config.yml"), []byte("services:\n  enter: start\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := New(false)
+	m.client = nil
+	m.focusedProject = "proj"
+	m.activePane = paneServices
+	m.services.items = []serviceItem{
+		{name: "api", running: true},
+		{name: "worker", crashed: true},
+		{name: "jobs", stopped: true, disabled: true},
+	}
+
+	m.services.selected = 0
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	got := updated.(Model)
+	if got.activePane != paneLogs || got.toast != "" {
+		t.Fatalf("enter on a running service should only focus logs, pane=%q toast=%q", got.activePane, got.toast)
+	}
+
+	m.services.selected = 1
+	updated, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	got = updated.(Model)
+	if got.activePane != paneLogs || got.toast != "Starting worker..." || cmd == nil {
+		t.Fatalf("enter on a crashed service should start it, pane=%q toast=%q", got.activePane, got.toast)
+	}
+
+	m.services.selected = 2
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := updated.(Model); got.toast != "" {
+		t.Fatalf("a disabled service should not be started, toast=%q", got.toast)
+	}
+
+	m.enterStarts = false
+	m.services.selected = 1
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := updated.(Model); got.toast != "" {
+		t.Fatalf("default enter should not start services, toast=%q", got.toast)
+	}
+}
+
+func TestStatusDeltaAppliesOnlyNewRevisions(t *testing.T) {
+	m := New(false)
+	m.client = nil
+	status := statusUpdateMsg{"proj": {"api": daemon.ServiceInfo{Running: true, Port: 3000}}}
+
+	updated, _ := m.Update(statusDeltaMsg{revision: 7, status: status})
+	m = updated.(Model)
+	if m.statusRevision != 7 || len(m.services.items) != 1 {
+		t.Fatalf("new revision should be applied, rev=%d items=%+v", m.statusRevision, m.services.items)
+	}
+
+	m.statusFailures = 2
+	updated, _ = m.Update(statusDeltaMsg{revision: 7, unchanged: true})
+	m = updated.(Model)
+	if m.statusFailures != 0 || m.latestStatus["proj"]["api"].Port != 3000 {
+		t.Fatalf("unchanged answer should keep the last status and count as a good poll, got %+v", m.latestStatus)
+	}
+}
+
+func TestHelpOverlayTogglesAndSwallowsKeys(t *testing.T) {
+	m := New(false)
+	m.client = nil
+	m.width, m.height = 100, 30
+	m.activePane = paneServices
+
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	m = updated.(Model)
+	if !m.helpVisible || !strings.Contains(m.View(), "esc or ? to close") {
+		t.Fatal("? should open the help overlay")
+	}
+
+	// Keys other than esc and ? don't act behind the overlay.
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyRight})
+	m = updated.(Model)
+	if !m.helpVisible || m.activePane != paneServices {
+		t.Fatalf("help visible=%v pane=%q after right arrow", m.helpVisible, m.activePane)
+	}
+
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.helpVisible {
+		t.Fatal("esc should close the help overlay")
+	}
+}
+
+func TestHelpOverlayFitsSmallTerminalsAndHidesEasterEgg(t *testing.T) {
+	for _, size := range [][2]int{{160, 50}, {100, 30}, {60, 20}} {
+		view := viewHelp(size[0], size[1])
+		if w, h := lipgloss.Width(view), lipgloss.Height(view); w > size[0] || h > size[1] {
+			t.Fatalf("help at %dx%d renders %dx%d", size[0], size[1], w, h)
+		}
+	}
+	for _, s := range helpSections {
+		for _, b := range s.bindings {
+			if strings.Contains(b.keys, "E") {
+				t.Fatalf("help lists the pane toggle easter egg: %+v", b)
+			}
+		}
+	}
+}
+
+func TestCtrlLClearsSelectedServiceLogsOnly(t *testing.T) {
+	m := New(false)
+	m.client = nil
+	m.focusedProject = "proj"
+	m.activePane = paneLogs
+	m.logs.service = "svc"
+	key := projectServiceKey("proj", "svc")
+	old := []daemon.LogLine{{Project: "proj", Service: "svc", Text: "noise", Timestamp: time.Now().Add(-time.Second)}}
+	m.allLogs[key] = old
+	m.logs.setLines(old)
+
+	updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyCtrlL})
+	m = updated.(Model)
+	if len(m.allLogs[key]) != 0 || len(m.logs.lines) != 0 {
+		t.Fatalf("logs not cleared: cache %d, pane %d", len(m.allLogs[key]), len(m.logs.lines))
+	}
+	if m.logPassesCutoff(old[0]) || m.toast != "Cleared logs for svc" {
+		t.Fatalf("old line passes cutoff or toast = %q", m.toast)
+	}
+
+	m.logs.service = "all"
+	m.allLogs[key] = old
+	updated, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyCtrlL})
+	m = updated.(Model)
+	if len(m.allLogs[key]) != 1 || !strings.HasPrefix(m.toast, "Clearing works per service") {
+		t.Fatalf("all view should not clear: cache %d, toast %q", len(m.allLogs[key]), m.toast)
+	}
+}
+
+func TestBracketsResizeSidebarWithinBounds(t *testing.T) {
+	m := New(false)
+	m.client = nil
+	m.width, m.height = 120, 40
+	m.updateLayout()
+
+	press := func(r rune) {
+		updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+	}
+	press(']')
+	if m.services.width != 26 || m.logs.width != 120-26-3 {
+		t.Fatalf("after ]: sidebar %d, logs %d", m.services.width, m.logs.width)
+	}
+	for i := 0; i < 20; i++ {
+		press(']')
+	}
+	if m.services.width != 40 || m.layoutInfo().logsX != 43 {
+		t.Fatalf("sidebar should stop at a third of the width: %d", m.services.width)
+	}
+	for i := 0; i < 20; i++ {
+		press('[')
+	}
+	if m.services.width != sidebarMinWidth || m.logs.width != 120-sidebarMinWidth-3 {
+		t.Fatalf("sidebar should stop at the minimum: sidebar %d, logs %d", m.services.width, m.logs.width)
+	}
+	press(']')
+	if m.services.width != sidebarMinWidth+sidebarResizeStep {
+		t.Fatalf("shrinking past the minimum should not be remembered: %d", m.services.width)
+	}
+}
+
+func TestNewAppliesThemeFromGlobalConfig(t *testing.T) {
+	t.Setenv("HUN_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte("theme: light\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HUN_CONFIG", path)
+	t.Cleanup(func() { applyTheme(darkTheme) })
+
+	New(false)
+	if theme != lightTheme {
+		t.Fatalf("theme = %+v, want the light theme", theme)
+	}
+	if got := lineStyleWithState(logText, true, 0).GetBackground(); got != lightTheme.Selection {
+		t.Fatalf("selection background = %v, want %v", got, lightTheme.Selection)
+	}
+	if themeByName("") != darkTheme || themeByName("dark") != darkTheme {
+		t.Fatal("dark should be the default theme")
+	}
+}
+
+func TestPipeSplitsLogsWithPreviousServiceAndRoutesLines(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	m := New(false)
+	m.client = nil
+	m.width, m.height = 140, 30
+	m.focusedProject = "proj"
+	m.latestStatus = statusUpdateMsg{
+		"proj": {
+			"api": daemon.ServiceInfo{Running: true},
+			"web": daemon.ServiceInfo{Running: true},
+		},
+	}
+	now := time.Now()
+	m.allLogs["proj:api"] = []daemon.LogLine{{Project: "proj", Service: "api", Text: "api listening", Timestamp: now}}
+	m.allLogs["proj:web"] = []daemon.LogLine{{Project: "proj", Service: "web", Text: "web compiled", Timestamp: now}}
+	m.updateLayout()
+	m.refreshServices()
+
+	press := func() {
+		updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("|")})
+		m = updated.(Model)
+	}
+	press()
+	if m.splitService != "" {
+		t.Fatalf("split opened with no previous service: %q", m.splitService)
+	}
+
+	m.services.selected = m.services.indexOf("web")
+	m.refreshLogs()
+	press()
+	if m.splitService != "api" || m.logs.service != "web" {
+		t.Fatalf("split = %q beside %q, want api beside web", m.splitService, m.logs.service)
+	}
+	layout := m.layoutInfo()
+	if !layout.splitBeside || m.logs.width+3+m.splitLogs.width != 140-layout.logsX {
+		t.Fatalf("wide terminal should split side by side: main %d, split %d", m.logs.width, m.splitLogs.width)
+	}
+	view := m.View()
+	if !strings.Contains(view, "api listening") || !strings.Contains(view, "web compiled") {
+		t.Fatalf("split view should show both services:\n%s", view)
+	}
+
+	updated, _ := m.Update(logMsg(daemon.LogLine{Project: "proj", Service: "api", Text: "GET /health", Timestamp: now}))
+	m = updated.(Model)
+	if n := len(m.splitLogs.lines); n != 2 || m.splitLogs.lines[1].Text != "GET /health" {
+		t.Fatalf("split pane lines = %d, want the new api line routed to it", n)
+	}
+
+	m.width = 90
+	m.updateLayout()
+	if layout := m.layoutInfo(); layout.splitBeside || m.logs.height+1+m.splitLogs.height != layout.middleHeight {
+		t.Fatalf("narrow terminal should stack panes: main %d, split %d", m.logs.height, m.splitLogs.height)
+	}
+
+	press()
+	if m.splitService != "" || m.logs.height != m.layoutInfo().middleHeight {
+		t.Fatalf("| again should restore the single pane: split %q, height %d", m.splitService, m.logs.height)
+	}
+}
+
+func TestRelativeTimestampsApplyToSplitPane(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	m := New(false)
+	m.client = nil
+	m.width, m.height = 140, 30
+	m.focusedProject = "proj"
+	m.latestStatus = statusUpdateMsg{
+		"proj": {
+			"api": daemon.ServiceInfo{Running: true},
+			"web": daemon.ServiceInfo{Running: true},
+		},
+	}
+	started := time.Now().Add(-time.Minute)
+	m.startedAt["proj:api"] = started
+	m.allLogs["proj:api"] = []daemon.LogLine{{Project: "proj", Service: "api", Text: "api listening", Timestamp: started.Add(2300 * time.Millisecond)}}
+	m.updateLayout()
+	m.refreshServices()
+	m.refreshLogs()
+	m.services.selected = m.services.indexOf("web")
+	m.refreshLogs()
+	m.activePane = paneLogs
+
+	press := func(k string) {
+		updated, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		m = updated.(Model)
+	}
+	press("|")
+	if m.splitService != "api" {
+		t.Fatalf("split = %q, want api", m.splitService)
+	}
+	press("T")
+	if !m.splitLogs.relativeTime {
+		t.Fatal("T should switch the split pane to relative timestamps too")
+	}
+	if !strings.Contains(m.View(), "+2.3s") {
+		t.Fatalf("split pane should stamp lines relative to the api start:\n%s", m.View())
+	}
+	press("T")
+	if m.splitLogs.relativeTime {
+		t.Fatal("T again should restore clock timestamps in the split pane")
+	}
+}
+
+func TestLoadProjectConfigExpandsHunNamesInCwd(t *testing.T) {
+	projectDir := t.TempDir()
+	yml := "name: shop\nservices:\n  api:\n    cmd: echo ok\n    cwd: ./${HUN_SERVICE}\n  web:\n    cmd: echo ok\n    cwd: ./web-$HUN_PORT\n"
+	if err := os.WriteFile(filepath.Join(projectDir, ".hun.yml"), []byte(yml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	info, err := loadProjectConfig(projectDir)
+	if err != nil {
+		t.Fatalf("loadProjectConfig: %v", err)
+	}
+	if got, want := info.Services["api"].Cwd, filepath.Join(projectDir, "api"); got != want {
+		t.Fatalf("api cwd = %q, want %q", got, want)
+	}
+	if got, want := info.Services["web"].Cwd, filepath.Join(projectDir, "web-$HUN_PORT"); got != want {
+		t.Fatalf("web cwd = %q, want the port reference kept as %q", got, want)
+	}
+}
+
+func TestStatusBarKeepsHelpVisibleWhenNarrow(t *testing.T) {
+	bar := statusBarModel{mode: "focus", width: 80, activePane: paneLogs, health: daemonReconnecting}
+	view := bar.View()
+	if !strings.Contains(view, "help") || !strings.Contains(view, "quit") {
+		t.Fatalf("status bar at 80 columns should still show ? help and q quit:\n%s", view)
+	}
+}
@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sourabhrathourr/hun/internal/config"
+	"github.com/sourabhrathourr/hun/internal/daemon"
+	"github.com/sourabhrathourr/hun/internal/opener"
+)
+
+// exportRows returns the rendered rows to export: the selection when there is
+// one, otherwise the rows currently visible in the viewport.
+func (m logsModel) exportRows() []renderedLogRow {
+	rows := m.buildRenderedRows(m.filteredLines())
+	if len(rows) == 0 {
+		return nil
+	}
+	if start, end, ok := m.selectionBounds(len(rows)); ok {
+		start, end = m.expandRowsToGroups(rows, start, end)
+		return rows[start : end+1]
+	}
+	start, end := m.visibleRange(len(rows), m.visibleRows())
+	return rows[start:end]
+}
+
+// exportText returns every line the logs pane currently shows, after search
+// and filters, formatted as they are copied, and how many lines that is.
+func (m logsModel) exportText() (string, int) {
+	filtered := m.filteredLines()
+	if len(filtered) == 0 {
+		return "", 0
+	}
+	return m.formatCopyBlock(filtered) + "\n", len(filtered)
+}
+
+// renderLogsHTML renders rows as a standalone HTML page using the same
+// severity palette and search highlighting as the terminal view.
+func renderLogsHTML(title string, rows []renderedLogRow) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n</head>\n", html.EscapeString(title))
+	fmt.Fprintf(&b, "<body style=\"margin:0;background:%s\">\n", cssColor(theme.Bg))
+	fmt.Fprintf(&b, "<pre style=\"margin:0;padding:12px;font-family:ui-monospace,Menlo,monospace;font-size:13px;color:%s\">\n", cssColor(theme.LogText))
+	for _, row := range rows {
+		ts := row.timestamp
+		if row.continuation {
+			ts = strings.Repeat(" ", len([]rune(ts)))
+		}
+		fmt.Fprintf(&b, "<span style=\"color:%s\">%s</span> ", cssStyleColor(logTimestamp), html.EscapeString(ts))
+		fmt.Fprintf(&b, "<span style=\"color:%s\">", cssStyleColor(styleForSeverity(row.severity)))
+		pos := 0
+		for _, span := range row.highlights {
+			b.WriteString(html.EscapeString(row.text[pos:span.start]))
+			fmt.Fprintf(&b, "<mark style=\"color:%s;background:%s\">%s</mark>",
+				cssStyleColor(searchMatchStyle), cssColor(searchMatchStyle.GetBackground()), html.EscapeString(row.text[span.start:span.end]))
+			pos = span.end
+		}
+		b.WriteString(html.EscapeString(row.text[pos:]))
+		b.WriteString("</span>\n")
+	}
+	b.WriteString("</pre>\n</body>\n</html>\n")
+	return b.String()
+}
+
+func cssStyleColor(style lipgloss.Style) string {
+	return cssColor(style.GetForeground())
+}
+
+func cssColor(c lipgloss.TerminalColor) string {
+	if hex, ok := c.(lipgloss.Color); ok && strings.HasPrefix(string(hex), "#") {
+		return string(hex)
+	}
+	return "inherit"
+}
+
+// Swapped in tests so O and o don't launch a file manager or browser.
+var (
+	openPath   = opener.Open
+	revealPath = opener.Reveal
+)
+
+// revealLogFiles shows service's log file in the file manager, or the
+// project's log folder when there is no service or it has no file yet, and
+// returns the toast describing what happened.
+func revealLogFiles(project, service string) string {
+	if service != "" {
+		path, err := daemon.LogFilePath(project, service)
+		if _, statErr := os.Stat(path); err == nil && statErr == nil {
+			if err := revealPath(path); err != nil {
+				return "Open failed: " + err.Error()
+			}
+			return "Revealed " + path
+		}
+	}
+	dir, err := daemon.ProjectLogDir(project)
+	if err != nil {
+		return "Open failed: " + err.Error()
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return "No logs written yet for " + project
+	}
+	if err := openPath(dir); err != nil {
+		return "Open failed: " + err.Error()
+	}
+	return "Opened " + dir
+}
+
+// openServiceURL opens http://localhost:<port> for a service in the default
+// browser and returns the toast describing what happened.
+func openServiceURL(service string, port int) string {
+	if port <= 0 {
+		return "no port for " + service
+	}
+	url := fmt.Sprintf("http://localhost:%d", port)
+	if err := openPath(url); err != nil {
+		return "Open failed: " + err.Error()
+	}
+	return "Opened " + url
+}
+
+// writeLogExport writes data to ~/.hun/exports/<project>-<service>-<timestamp>.<ext>.
+func writeLogExport(project, service, ext, data string) (string, error) {
+	dir, err := config.HunDir()
+	if err != nil {
+		return "", err
+	}
+	dir = filepath.Join(dir, "exports")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s-%s-%s.%s", project, service, time.Now().Format("20060102-150405"), ext)
+	path := filepath.Join(dir, strings.ReplaceAll(name, string(filepath.Separator), "_"))
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
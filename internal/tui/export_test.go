@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourabhrathourr/hun/internal/daemon"
+)
+
+func TestExportVisibleRowsAsColoredHTML(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	m := logsModel{
+		service: "api",
+		width:   80,
+		height:  10,
+		search:  "boom",
+		lines: []daemon.LogLine{
+			{Timestamp: time.Now(), Text: "ERROR: <script> boom"},
+			{Timestamp: time.Now(), Text: "boom again"},
+		},
+	}
+
+	rows := m.exportRows()
+	if len(rows) != 2 {
+		t.Fatalf("exportRows() = %d rows, want 2 visible rows", len(rows))
+	}
+	page := renderLogsHTML("shop / api", rows)
+	for _, want := range []string{
+		"&lt;script&gt;",
+		`<span style="color:` + string(theme.LogError) + `">`,
+		"<mark",
+		">boom</mark>",
+	} {
+		if !strings.Contains(page, want) {
+			t.Fatalf("html missing %q:\n%s", want, page)
+		}
+	}
+
+	path, err := writeLogExport("shop", "api", "html", page)
+	if err != nil {
+		t.Fatalf("write export: %v", err)
+	}
+	if filepath.Dir(path) != filepath.Join(home, ".hun", "exports") || !strings.HasPrefix(filepath.Base(path), "shop-api-") {
+		t.Fatalf("export path = %s", path)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != page {
+		t.Fatalf("export contents mismatch (err %v)", err)
+	}
+}
+
+func TestRevealLogFilesPrefersServiceFileThenProjectFolder(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var opened, revealed []string
+	origOpen, origReveal := openPath, revealPath
+	openPath = func(p string) error { opened = append(opened, p); return nil }
+	revealPath = func(p string) error { revealed = append(revealed, p); return nil }
+	t.Cleanup(func() { openPath, revealPath = origOpen, origReveal })
+
+	if got := revealLogFiles("shop", "api"); !strings.HasPrefix(got, "No logs written yet") {
+		t.Fatalf("toast before any logs = %q", got)
+	}
+
+	apiLog, _ := daemon.LogFilePath("shop", "api")
+	if err := os.MkdirAll(filepath.Dir(apiLog), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(apiLog, []byte("[2024-03-01 10:00:00] [out] up\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	revealLogFiles("shop", "api")
+	revealLogFiles("shop", "worker")
+	dir, _ := daemon.ProjectLogDir("shop")
+	if len(revealed) != 1 || revealed[0] != apiLog {
+		t.Fatalf("revealed = %v, want the api log file", revealed)
+	}
+	if len(opened) != 1 || opened[0] != dir {
+		t.Fatalf("opened = %v, want the project folder for a service without a file", opened)
+	}
+}
+
+func TestExportTextWritesFilteredLinesWithServicePrefix(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local)
+	m := logsModel{
+		service: "all",
+		width:   80,
+		height:  10,
+		search:  "ready",
+		lines: []daemon.LogLine{
+			{Project: "shop", Service: "api", Seq: 1, Timestamp: ts, Text: "api ready"},
+			{Project: "shop", Service: "web", Seq: 2, Timestamp: ts, Text: "compiling"},
+			{Project: "shop", Service: "web", Seq: 3, Timestamp: ts, Text: "web ready"},
+		},
+	}
+	text, count := m.exportText()
+	want := "[10:00:00] [api] api ready\n[10:00:00] [web] web ready\n"
+	if count != 2 || text != want {
+		t.Fatalf("exportText() = %d, %q; want 2, %q", count, text, want)
+	}
+
+	m.service = "web"
+	m.search = ""
+	m.lines = m.lines[1:]
+	if text, _ := m.exportText(); text != "[10:00:00] compiling\n[10:00:00] web ready\n" {
+		t.Fatalf("single-service export = %q", text)
+	}
+}
+
+func TestOpenServiceURLUsesLocalhostPort(t *testing.T) {
+	var opened []string
+	origOpen := openPath
+	openPath = func(p string) error { opened = append(opened, p); return nil }
+	t.Cleanup(func() { openPath = origOpen })
+
+	if got := openServiceURL("worker", 0); got != "no port for worker" || len(opened) != 0 {
+		t.Fatalf("portless service: toast %q, opened %v", got, opened)
+	}
+	if got := openServiceURL("web", 5173); got != "Opened http://localhost:5173" {
+		t.Fatalf("toast = %q", got)
+	}
+	if len(opened) != 1 || opened[0] != "http://localhost:5173" {
+		t.Fatalf("opened = %v", opened)
+	}
+}
@@ -0,0 +1,169 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// helpBinding is one row of the ? overlay.
+type helpBinding struct {
+	keys string
+	desc string
+}
+
+// helpSection groups bindings by where they apply.
+type helpSection struct {
+	title    string
+	bindings []helpBinding
+}
+
+var helpSections = []helpSection{
+	{"General", []helpBinding{
+		{"← →", "switch pane"},
+		{"tab", "next project (multitask)"},
+		{"p", "project picker (ctrl+f: favorite)"},
+		{"m / f", "multitask / focus mode"},
+		{"s", "stop project"},
+		{"R", "restart project"},
+		{"[ / ]", "narrower / wider sidebar"},
+		{"|", "split logs with the previous service"},
+		{"?", "this help"},
+		{"q", "quit (services keep running)"},
+	}},
+	{"Services", []helpBinding{
+		{"↑↓ j k", "select service"},
+		{"enter", "show its logs"},
+		{"r", "restart service"},
+		{"x", "stop service"},
+		{"P", "pause / resume"},
+		{"*", "pin to top / unpin"},
+		{"o", "open localhost:<port>"},
+		{"'", "jump to service by name"},
+		{"#", "cycle tag filter"},
+		{"a", "all services' logs"},
+		{"A", "auto-follow busiest service"},
+		{"B", "copy repro"},
+		{"C", "copy log path"},
+		{"O", "reveal log file"},
+	}},
+	{"Logs", []helpBinding{
+		{"↑↓ j k", "move cursor"},
+		{"u / d", "fast scroll"},
+		{"g / G", "top / bottom"},
+		{"l", "live mode"},
+		{"t", "sticky tail"},
+		{"T", "relative timestamps"},
+		{"w", "wrap lines"},
+		{"i", "command info"},
+		{"z", "group traces"},
+		{"M", "hide hun notices"},
+		{"ctrl+l", "clear service logs"},
+		{"1-4", "all / INFO+ / WARN+ / ERR+"},
+		{"v / V", "select rows / lines"},
+		{"c / y", "copy"},
+		{"e / H", "export .log / .html"},
+	}},
+	{"Search", []helpBinding{
+		{"/", "search logs"},
+		{"ctrl+r", "regex (while typing)"},
+		{"svc:name", "scope the all view (in search)"},
+		{"n / N", "next / previous match"},
+		{"esc", "clear search"},
+	}},
+}
+
+// viewHelp renders the key binding overlay, laying sections out in as many
+// columns as it takes to fit height, and never wider than width.
+func viewHelp(width, height int) string {
+	// Border (2) and padding (2 rows, 4 columns) around the content.
+	maxRows := max(height-4, 1)
+	maxWidth := max(width-6, 10)
+	title := pickerTitle.Render("keys") + "  " + descStyle.Render("esc or ? to close")
+
+	var columns [][]helpSection
+	for n := 1; n <= len(helpSections); n++ {
+		columns = splitHelpSections(n)
+		if helpColumnRows(columns)+2 <= maxRows {
+			break
+		}
+	}
+	colWidth := max((maxWidth-2*(len(columns)-1))/len(columns), 8)
+
+	rendered := make([]string, 0, len(columns)*2)
+	for i, col := range columns {
+		if i > 0 {
+			rendered = append(rendered, "  ")
+		}
+		rendered = append(rendered, renderHelpColumn(col, colWidth))
+	}
+	body := lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+	content := lipgloss.JoinVertical(lipgloss.Left, title, "", body)
+	lines := strings.Split(content, "\n")
+	if len(lines) > maxRows {
+		lines = lines[:maxRows]
+	}
+	return pickerStyle.Render(strings.Join(lines, "\n"))
+}
+
+// splitHelpSections deals the sections into n columns in order, keeping
+// each column's row count close to the others.
+func splitHelpSections(n int) [][]helpSection {
+	total := 0
+	for _, s := range helpSections {
+		total += helpSectionRows(s)
+	}
+	target := (total + n - 1) / n
+	columns := make([][]helpSection, 0, n)
+	var col []helpSection
+	rows := 0
+	for _, s := range helpSections {
+		if len(col) > 0 && rows+helpSectionRows(s) > target && len(columns) < n-1 {
+			columns = append(columns, col)
+			col, rows = nil, 0
+		}
+		col = append(col, s)
+		rows += helpSectionRows(s)
+	}
+	return append(columns, col)
+}
+
+// helpSectionRows is the title, its bindings, and the blank line after them.
+func helpSectionRows(s helpSection) int {
+	return len(s.bindings) + 2
+}
+
+func helpColumnRows(columns [][]helpSection) int {
+	tallest := 0
+	for _, col := range columns {
+		rows := 0
+		for _, s := range col {
+			rows += helpSectionRows(s)
+		}
+		tallest = max(tallest, rows)
+	}
+	return tallest
+}
+
+func renderHelpColumn(sections []helpSection, width int) string {
+	keyWidth := 0
+	for _, s := range sections {
+		for _, b := range s.bindings {
+			keyWidth = max(keyWidth, runewidth.StringWidth(b.keys))
+		}
+	}
+	var lines []string
+	for i, s := range sections {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, pickerItemActive.Render(s.title))
+		for _, b := range s.bindings {
+			keys := b.keys + strings.Repeat(" ", keyWidth-runewidth.StringWidth(b.keys))
+			desc := truncateDisplayWidth(b.desc, max(width-keyWidth-2, 1))
+			lines = append(lines, keyStyle.Render(keys)+"  "+descStyle.Render(desc))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
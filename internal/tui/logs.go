@@ -1,10 +1,13 @@
 package tui
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
@@ -26,6 +29,23 @@ type logsModel struct {
 	searching     bool
 	wrap          bool
 	unread        int
+	sticky        bool // follow implicitly while the cursor sits on the last line
+	groupTraces   bool // treat stack-trace continuation lines as one unit for selection/copy
+	autoFollow    bool // switch to whichever service is logging the most
+	hideMeta      bool // leave out lines hun wrote itself (IsMeta)
+
+	searchRegex bool           // search is a regular expression (ctrl+r while searching)
+	searchRe    *regexp.Regexp // last search that compiled in regex mode; nil matches everything
+	minSeverity logSeverity    // hide lines ranked below this (1-4 keys); neutral shows everything
+
+	relativeTime  bool                 // stamp lines with time since their service started (T)
+	serviceStarts map[string]time.Time // "project:service" → start time, shared with the Model
+
+	showInfo   bool   // render the service command/cwd line under the header
+	serviceCmd string // resolved command for the current service, if known
+	serviceCwd string // resolved working directory for the current service
+
+	logFormats map[string]string // service → declared log_format (json, logfmt, plain)
 
 	cursor          int // index in filtered log lines (derived from cursorRow)
 	cursorRow       int // index in rendered rows
@@ -33,6 +53,7 @@ type logsModel struct {
 	selectionAnchor int // index in rendered rows
 	selectionEnd    int // index in rendered rows
 	selectionPrimed bool
+	selectionByLine bool // j/k step over whole log lines instead of rendered rows
 
 	copyFlashActive bool
 	copyFlashQueued bool
@@ -43,12 +64,20 @@ type logsModel struct {
 
 type renderedLogRow struct {
 	lineIndex    int
+	groupID      int // lineIndex of the first line in this row's multi-line group
 	timestamp    string
 	text         string
+	highlights   []textRange // byte ranges of text that match the search
 	severity     logSeverity
 	continuation bool
 }
 
+// textRange is a half-open byte range [start, end).
+type textRange struct {
+	start int
+	end   int
+}
+
 type logSeverity int
 
 const (
@@ -73,6 +102,8 @@ var (
 	strongFailureTokenRegex = regexp.MustCompile(`(?i)\b(failed|failure|cannot|can't|unable|refused|timeout|timed out|permission denied|no such file)\b`)
 	benignErrorContextRegex = regexp.MustCompile(`(?i)\b(no errors?|without errors?|0 errors?|error(s)?\s*[:=]\s*0)\b`)
 	benignShutdownRegex     = regexp.MustCompile(`(?i)\b(polite quit request|warm shutdown|graceful shutdown|draining worker|shutting down worker|terminated by signal sigterm|received sigterm)\b`)
+	traceFrameRegex         = regexp.MustCompile(`^(?:\s+\S|at\s|File "|Caused by:|\.\.\. \d+ more|goroutine \d+ \[)`)
+	traceTailRegex          = regexp.MustCompile(`^[A-Za-z_][\w.]*(?:Error|Exception|Exit|Interrupt)\b`)
 )
 
 func (m logsModel) View() string {
@@ -83,13 +114,23 @@ func (m logsModel) View() string {
 		titleStyle = serviceSelected
 	}
 	title := focusArrow + " " + titleStyle.Render(m.service)
+	if scope, _ := m.searchScope(); len(scope) > 0 {
+		title += " " + searchLabelStyle.Render("svc:"+strings.Join(scope, ","))
+	}
 	status := m.statusText()
 	header := title + "  " + descStyle.Render(status)
 
+	label := "/"
+	if m.searchRegex {
+		label = "/re:"
+	}
 	if m.searching {
-		header += "  " + searchLabelStyle.Render("/") + " " + searchBarStyle.Render(m.search+"\u2588")
+		header += "  " + searchLabelStyle.Render(label) + " " + searchBarStyle.Render(m.search+"\u2588")
 	} else if m.search != "" {
-		header += "  " + searchLabelStyle.Render("/"+m.search) + "  " + searchHintStyle.Render("[esc to clear]")
+		header += "  " + searchLabelStyle.Render(label+m.search) + "  " + searchHintStyle.Render("[esc to clear]")
+	}
+	if m.hasInfoLine() {
+		header += "\n" + m.renderInfoLine()
 	}
 
 	visible := m.visibleRows()
@@ -105,22 +146,12 @@ func (m logsModel) View() string {
 		return strings.Join(lines, "\n")
 	}
 
-	start := m.offset
-	if m.autoScroll {
-		start = len(rows) - visible
-	}
-	if start < 0 {
-		start = 0
-	}
-	if maxStart := maxInt(0, len(rows)-visible); start > maxStart {
-		start = maxStart
-	}
-	end := start + visible
-	if end > len(rows) {
-		end = len(rows)
-	}
+	start, end := m.visibleRange(len(rows), visible)
 
 	selStart, selEnd, hasSelection := m.selectionBounds(len(rows))
+	if hasSelection {
+		selStart, selEnd = m.expandRowsToGroups(rows, selStart, selEnd)
+	}
 
 	lines := []string{header, ""}
 	for i := start; i < end; i++ {
@@ -133,6 +164,9 @@ func (m logsModel) View() string {
 		}
 
 		markerGlyph := "  "
+		if m.groupTraces && row.groupID != row.lineIndex {
+			markerGlyph = "┆ "
+		}
 		if isFocused {
 			if row.continuation {
 				markerGlyph = "│ "
@@ -158,7 +192,7 @@ func (m logsModel) View() string {
 		tsStyle := lineStyleWithState(logTimestamp, isSelected, flashPhase)
 		sep := lineStyleWithState(lipgloss.NewStyle(), isSelected, flashPhase).Render(" ")
 		textStyle := lineStyleWithState(styleForSeverity(row.severity), isSelected, flashPhase)
-		rendered := marker + tsStyle.Render(ts) + sep + textStyle.Render(row.text)
+		rendered := marker + tsStyle.Render(ts) + sep + renderHighlighted(row.text, row.highlights, textStyle, searchMatchStyle)
 		if isSelected {
 			padWidth := m.width - lipgloss.Width(rendered)
 			if padWidth > 0 {
@@ -172,6 +206,25 @@ func (m logsModel) View() string {
 	return strings.Join(lines, "\n")
 }
 
+// visibleRange returns the [start, end) rendered rows shown in the viewport.
+func (m logsModel) visibleRange(total, visible int) (int, int) {
+	start := m.offset
+	if m.autoScroll {
+		start = total - visible
+	}
+	if start < 0 {
+		start = 0
+	}
+	if maxStart := maxInt(0, total-visible); start > maxStart {
+		start = maxStart
+	}
+	end := start + visible
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
 func (m logsModel) renderStoppedState(header string) string {
 	panelWidth := m.width - 4
 	if panelWidth < 28 {
@@ -200,6 +253,12 @@ func (m logsModel) statusText() string {
 	if m.autoScroll {
 		live = "LIVE"
 	}
+	if m.sticky {
+		live = "TAIL"
+		if !m.autoScroll {
+			live = "TAIL \u2191"
+		}
+	}
 	wrap := "TRUNC"
 	if m.wrap {
 		wrap = "WRAP"
@@ -210,14 +269,52 @@ func (m logsModel) statusText() string {
 			parts = append(parts, fmt.Sprintf("+%d new", m.unread))
 		}
 	}
+	if m.groupTraces {
+		parts = append(parts, "GROUP")
+	}
+	if m.autoFollow {
+		parts = append(parts, "AUTO")
+	}
+	if m.relativeTime {
+		parts = append(parts, "REL")
+	}
+	if label := severityFilterLabel(m.minSeverity); label != "" {
+		parts = append(parts, label)
+	}
 	if m.selectionMode {
 		parts = append(parts, "SELECT")
 	}
 	return strings.Join(parts, "  ")
 }
 
+func (m logsModel) hasInfoLine() bool {
+	return m.showInfo && m.service != "" && m.service != "all" && m.serviceCmd != ""
+}
+
+func (m logsModel) renderInfoLine() string {
+	prefix := "  $ "
+	maxWidth := m.width - len(prefix)
+	if maxWidth < 1 {
+		maxWidth = 1
+	}
+	text := m.serviceCmd
+	if m.serviceCwd != "" {
+		text += "  (" + m.serviceCwd + ")"
+	}
+	return descStyle.Render(prefix + truncateDisplayWidth(text, maxWidth))
+}
+
+// headerRows is the number of rows above the first log row: the header, the
+// optional command info line, and a spacer.
+func (m logsModel) headerRows() int {
+	if m.hasInfoLine() {
+		return 3
+	}
+	return 2
+}
+
 func (m logsModel) visibleRows() int {
-	visible := m.height - 2 // header + spacer
+	visible := m.height - m.headerRows()
 	if visible < 1 {
 		visible = 1
 	}
@@ -225,13 +322,24 @@ func (m logsModel) visibleRows() int {
 }
 
 func (m logsModel) filteredLines() []daemon.LogLine {
-	if m.search == "" {
+	if m.search == "" && !m.hideMeta && m.minSeverity == logSeverityNeutral {
 		return m.lines
 	}
 	result := make([]daemon.LogLine, 0, len(m.lines))
-	lower := strings.ToLower(m.search)
+	matches := m.searchMatcher()
+	scope, _ := m.searchScope()
 	for _, line := range m.lines {
-		if strings.Contains(strings.ToLower(sanitizeLogText(line.Text)), lower) {
+		if m.hideMeta && line.IsMeta {
+			continue
+		}
+		if len(scope) > 0 && !slices.Contains(scope, line.Service) {
+			continue
+		}
+		text := sanitizeLogText(line.Text)
+		if m.minSeverity != logSeverityNeutral && severityRank(m.lineSeverity(line, text)) < severityRank(m.minSeverity) {
+			continue
+		}
+		if matches(text) {
 			result = append(result, line)
 		}
 	}
@@ -243,6 +351,7 @@ func (m logsModel) buildRenderedRows(filtered []daemon.LogLine) []renderedLogRow
 		return nil
 	}
 
+	// Relative stamps are padded to the same width as clock ones.
 	tsWidth := len("[15:04:05]")
 	maxTextWidth := m.width - 2 - tsWidth - 1 // marker + timestamp + spacing
 	if maxTextWidth < 1 {
@@ -250,28 +359,45 @@ func (m logsModel) buildRenderedRows(filtered []daemon.LogLine) []renderedLogRow
 	}
 
 	rows := make([]renderedLogRow, 0, len(filtered))
+	_, query := m.searchScope()
+	match := daemon.ParseLogMatch(query)
+	groupID := 0
 	for i, line := range filtered {
+		if i == 0 || !m.groupTraces || !continuesGroup(filtered[i-1], line, i-1 > groupID || isTraceFrame(filtered[i-1].Text)) {
+			groupID = i
+		}
 		text := sanitizeLogText(line.Text)
+		sev := m.lineSeverity(line, text)
 		if m.service == "all" {
 			text = "[" + line.Service + "] " + text
 		}
-		sev := classifyLogSeverity(text, line.IsErr)
-		wrapped := []string{text}
+		var chunks [][]textRange
 		if m.wrap {
-			wrapped = wrapLogText(text, maxTextWidth)
+			chunks = wrapLogRanges(text, maxTextWidth)
+		} else if truncated := truncateDisplayWidth(text, maxTextWidth); truncated == text {
+			chunks = [][]textRange{{{0, len(text)}}}
 		} else {
-			wrapped = []string{truncateDisplayWidth(text, maxTextWidth)}
+			chunks = [][]textRange{{{0, len(strings.TrimSuffix(truncated, "…"))}}}
 		}
-		if len(wrapped) == 0 {
-			wrapped = []string{""}
+		if len(chunks) == 0 {
+			chunks = [][]textRange{nil}
 		}
-
-		ts := fmt.Sprintf("[%s]", line.Timestamp.Format("15:04:05"))
-		for j, chunk := range wrapped {
+		// Highlights are found on the whole line first, then projected onto each
+		// chunk, so a match split by a wrap boundary stays lit on both rows.
+		mask := m.searchMask(text, match)
+
+		ts := m.lineStamp(line)
+		for j, chunk := range chunks {
+			chunkText := joinTextRanges(text, chunk)
+			if !m.wrap && chunkText != text {
+				chunkText += "…"
+			}
 			rows = append(rows, renderedLogRow{
 				lineIndex:    i,
+				groupID:      groupID,
 				timestamp:    ts,
-				text:         chunk,
+				text:         chunkText,
+				highlights:   projectMatchMask(mask, chunk),
 				severity:     sev,
 				continuation: j > 0,
 			})
@@ -296,6 +422,11 @@ func (m *logsModel) setLines(lines []daemon.LogLine) {
 		return
 	}
 
+	// In sticky tail mode, being parked on the last line is the same as following.
+	if !m.autoScroll && m.sticky && !m.selectionMode && oldLen > 0 && m.cursor == oldLen-1 {
+		m.autoScroll = true
+	}
+
 	if m.autoScroll {
 		m.cursor = newLen - 1
 		m.cursorRow = len(rows) - 1
@@ -307,9 +438,72 @@ func (m *logsModel) setLines(lines []daemon.LogLine) {
 	m.normalize()
 }
 
-func (m *logsModel) setSearch(search string) {
+// setSearch updates the search text. In regex mode a pattern that doesn't
+// compile returns its error and leaves the previous pattern filtering.
+func (m *logsModel) setSearch(search string) error {
 	m.search = search
+	var err error
+	if m.searchRegex {
+		err = m.compileSearch()
+	}
 	m.normalize()
+	return err
+}
+
+// toggleSearchRegex switches between substring and regex search, reporting
+// whether the current text compiles as a regex.
+func (m *logsModel) toggleSearchRegex() error {
+	m.searchRegex = !m.searchRegex
+	m.searchRe = nil
+	return m.setSearch(m.search)
+}
+
+// compileSearch compiles the search text, case-insensitively like substring
+// search, keeping the previous pattern when it doesn't compile.
+func (m *logsModel) compileSearch() error {
+	_, query := m.searchScope()
+	if query == "" {
+		m.searchRe = nil
+		return nil
+	}
+	re, err := regexp.Compile("(?i)" + query)
+	if err != nil {
+		return err
+	}
+	m.searchRe = re
+	return nil
+}
+
+// searchMatcher returns the predicate filteredLines keeps lines by.
+func (m logsModel) searchMatcher() func(string) bool {
+	if m.searchRegex {
+		if m.searchRe == nil {
+			return func(string) bool { return true }
+		}
+		return m.searchRe.MatchString
+	}
+	_, query := m.searchScope()
+	return daemon.ParseLogMatch(query).Matches
+}
+
+// searchScopeRe matches a svc:<name>[,<name>...] term in the search text.
+var searchScopeRe = regexp.MustCompile(`(?:^|\s)svc:(\S*)`)
+
+// searchScope splits svc: terms off the search in the all view, returning
+// the services they name and the text left to match. Elsewhere the search is
+// returned untouched, since a single service's view has nothing to scope.
+func (m logsModel) searchScope() (services []string, query string) {
+	if m.service != "all" || !strings.Contains(m.search, "svc:") {
+		return nil, m.search
+	}
+	for _, match := range searchScopeRe.FindAllStringSubmatch(m.search, -1) {
+		for _, name := range strings.Split(match[1], ",") {
+			if name != "" && !slices.Contains(services, name) {
+				services = append(services, name)
+			}
+		}
+	}
+	return services, strings.TrimSpace(searchScopeRe.ReplaceAllString(m.search, ""))
 }
 
 func (m *logsModel) toggleWrap() {
@@ -317,6 +511,14 @@ func (m *logsModel) toggleWrap() {
 	m.normalize()
 }
 
+func (m *logsModel) setWrap(wrap bool) {
+	if m.wrap == wrap {
+		return
+	}
+	m.wrap = wrap
+	m.normalize()
+}
+
 func (m *logsModel) toggleLive() {
 	if m.autoScroll {
 		m.autoScroll = false
@@ -326,6 +528,19 @@ func (m *logsModel) toggleLive() {
 	m.jumpBottom()
 }
 
+// toggleSticky switches between the explicit LIVE/PAUSED toggle and sticky
+// tail, where following resumes on its own whenever the cursor reaches the end.
+func (m *logsModel) toggleSticky() {
+	m.sticky = !m.sticky
+	if !m.sticky || m.autoScroll || m.selectionMode {
+		return
+	}
+	filtered := m.filteredLines()
+	if len(filtered) > 0 && m.cursor == len(filtered)-1 {
+		m.jumpBottom()
+	}
+}
+
 func (m *logsModel) jumpTop() {
 	rows := m.buildRenderedRows(m.filteredLines())
 	if len(rows) == 0 {
@@ -369,6 +584,47 @@ func (m *logsModel) jumpBottom() {
 	m.normalize()
 }
 
+// nextMatch moves the cursor to the next rendered row with a search match,
+// wrapping past the last row. It reports whether a match was found and
+// whether reaching it wrapped around.
+func (m *logsModel) nextMatch() (found, wrapped bool) {
+	return m.jumpToMatch(1)
+}
+
+// prevMatch is nextMatch searching upwards.
+func (m *logsModel) prevMatch() (found, wrapped bool) {
+	return m.jumpToMatch(-1)
+}
+
+func (m *logsModel) jumpToMatch(dir int) (found, wrapped bool) {
+	rows := m.buildRenderedRows(m.filteredLines())
+	if len(rows) == 0 {
+		return false, false
+	}
+	from := m.cursorRow
+	if m.autoScroll || from < 0 || from >= len(rows) {
+		from = len(rows) - 1
+	}
+	for step := 1; step <= len(rows); step++ {
+		idx := from + dir*step
+		wrapped = idx < 0 || idx >= len(rows)
+		idx = (idx%len(rows) + len(rows)) % len(rows)
+		if len(rows[idx].highlights) == 0 {
+			continue
+		}
+		m.autoScroll = false
+		m.cursorRow = idx
+		m.cursor = rows[idx].lineIndex
+		if m.selectionMode {
+			m.selectionEnd = m.cursorRow
+			m.selectionPrimed = false
+		}
+		m.normalize()
+		return true, wrapped
+	}
+	return false, false
+}
+
 func (m *logsModel) moveCursor(delta int) {
 	filtered := m.filteredLines()
 	rows := m.buildRenderedRows(filtered)
@@ -399,6 +655,12 @@ func (m *logsModel) moveCursor(delta int) {
 		m.autoScroll = false
 	}
 
+	if m.selectionMode && m.selectionByLine {
+		m.moveSelectionByLine(rows, delta)
+		m.normalize()
+		return
+	}
+
 	m.cursorRow += delta
 	if m.cursorRow < 0 {
 		m.cursorRow = 0
@@ -419,6 +681,26 @@ func (m *logsModel) moveCursor(delta int) {
 	m.normalize()
 }
 
+// moveSelectionByLine moves the cursor delta log lines and widens the
+// selection to whole lines, so a wrapped entry takes one step to cover.
+func (m *logsModel) moveSelectionByLine(rows []renderedLogRow, delta int) {
+	anchor := rows[max(0, min(m.selectionAnchor, len(rows)-1))].lineIndex
+	target := max(rows[0].lineIndex, min(m.cursor+delta, rows[len(rows)-1].lineIndex))
+	first, last, ok := rowBoundsForLine(rows, target)
+	anchorFirst, anchorLast, anchorOK := rowBoundsForLine(rows, anchor)
+	if !ok || !anchorOK {
+		return
+	}
+	if target >= anchor {
+		m.selectionAnchor, m.cursorRow = anchorFirst, last
+	} else {
+		m.selectionAnchor, m.cursorRow = anchorLast, first
+	}
+	m.cursor = target
+	m.selectionEnd = m.cursorRow
+	m.selectionPrimed = false
+}
+
 func (m *logsModel) page(delta int) {
 	step := m.visibleRows() / 2
 	if step < 1 {
@@ -471,7 +753,9 @@ func (m *logsModel) scrollRows(delta int) {
 	m.normalize()
 }
 
-func (m *logsModel) startSelectionMode() {
+// startSelectionMode starts a range selection at the cursor. byLine makes
+// j/k extend it a whole log line at a time however many rows the line wraps to.
+func (m *logsModel) startSelectionMode(byLine bool) {
 	rows := m.buildRenderedRows(m.filteredLines())
 	if len(rows) == 0 {
 		m.clearSelection()
@@ -499,6 +783,11 @@ func (m *logsModel) startSelectionMode() {
 	m.selectionAnchor = m.cursorRow
 	m.selectionEnd = m.cursorRow
 	m.selectionPrimed = true
+	m.selectionByLine = byLine
+	if first, last, ok := rowBoundsForLine(rows, m.cursor); byLine && ok {
+		m.selectionAnchor, m.cursorRow, m.selectionEnd = first, last, last
+		m.selectionPrimed = false
+	}
 	m.normalize()
 }
 
@@ -507,6 +796,7 @@ func (m *logsModel) clearSelection() {
 	m.selectionAnchor = 0
 	m.selectionEnd = 0
 	m.selectionPrimed = false
+	m.selectionByLine = false
 }
 
 func (m *logsModel) setCursorFromVisibleRow(relativeRow int, extendSelection bool) {
@@ -625,20 +915,20 @@ func (m logsModel) copyPayload() (string, int) {
 		return "", 0
 	}
 
-	includeService := m.service == "all"
-	lines := make([]string, 0, 8)
+	picked := make([]daemon.LogLine, 0, 8)
 
 	if start, end, ok := m.selectionBounds(len(rows)); ok {
+		start, end = m.expandRowsToGroups(rows, start, end)
 		lastLineIdx := -1
 		for i := start; i <= end && i < len(rows); i++ {
 			lineIdx := rows[i].lineIndex
 			if lineIdx < 0 || lineIdx >= len(filtered) || lineIdx == lastLineIdx {
 				continue
 			}
-			lines = append(lines, formatCopyLine(filtered[lineIdx], includeService))
+			picked = append(picked, filtered[lineIdx])
 			lastLineIdx = lineIdx
 		}
-		return strings.Join(lines, "\n"), len(lines)
+		return m.formatCopyBlock(picked), len(picked)
 	}
 
 	idx := m.cursor
@@ -648,13 +938,154 @@ func (m logsModel) copyPayload() (string, int) {
 	if idx < 0 || idx >= len(filtered) {
 		idx = rows[len(rows)-1].lineIndex
 	}
-	lines = append(lines, formatCopyLine(filtered[idx], includeService))
-	return strings.Join(lines, "\n"), len(lines)
+	if m.groupTraces {
+		if first, last, ok := rowBoundsForLine(rows, idx); ok {
+			first, last = m.expandRowsToGroups(rows, first, last)
+			picked = append(picked, filtered[rows[first].lineIndex:rows[last].lineIndex+1]...)
+			return m.formatCopyBlock(picked), len(picked)
+		}
+	}
+	picked = append(picked, filtered[idx])
+	return m.formatCopyBlock(picked), len(picked)
+}
+
+// formatCopyBlock renders copied lines one per line. The all view prefixes
+// each line with its service and puts the block in the daemon's write order,
+// so lines from different services paste in the order they happened; the
+// project is added too once the block spans more than one project.
+func (m logsModel) formatCopyBlock(picked []daemon.LogLine) string {
+	includeService := m.service == "all"
+	includeProject := false
+	if includeService {
+		picked = sortedBySeq(picked)
+		for _, line := range picked {
+			if line.Project != picked[0].Project {
+				includeProject = true
+				break
+			}
+		}
+	}
+	lines := make([]string, len(picked))
+	for i, line := range picked {
+		lines[i] = formatCopyLine(line, includeService, includeProject)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sortedBySeq returns lines ordered by their daemon sequence number. Lines
+// from a daemon that predates sequence numbers keep their display order.
+func sortedBySeq(lines []daemon.LogLine) []daemon.LogLine {
+	for _, line := range lines {
+		if line.Seq == 0 {
+			return lines
+		}
+	}
+	sorted := append([]daemon.LogLine(nil), lines...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Seq < sorted[j].Seq })
+	return sorted
+}
+
+// expandRowsToGroups widens a row range so it never splits a multi-line group.
+func (m logsModel) expandRowsToGroups(rows []renderedLogRow, start, end int) (int, int) {
+	if !m.groupTraces || len(rows) == 0 {
+		return start, end
+	}
+	for start > 0 && rows[start-1].groupID == rows[start].groupID {
+		start--
+	}
+	for end < len(rows)-1 && rows[end+1].groupID == rows[end].groupID {
+		end++
+	}
+	return start, end
+}
+
+// lineStamp is the timestamp column for line: its clock time, or in
+// relative mode the time since its service started when that is known.
+func (m logsModel) lineStamp(line daemon.LogLine) string {
+	if m.relativeTime {
+		if started, ok := m.serviceStarts[projectServiceKey(line.Project, line.Service)]; ok {
+			return fmt.Sprintf("[%8s]", relativeStamp(line.Timestamp.Sub(started)))
+		}
+	}
+	return fmt.Sprintf("[%s]", line.Timestamp.Format("15:04:05"))
+}
+
+// relativeStamp renders an offset from service start in at most 8 columns:
+// +2.3s, +4m05s, +2h10m, +3d04h. Lines from before the start read negative.
+func relativeStamp(d time.Duration) string {
+	sign := "+"
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	switch {
+	case d < time.Minute:
+		return sign + fmt.Sprintf("%.1fs", d.Seconds())
+	case d < time.Hour:
+		return sign + fmt.Sprintf("%dm%02ds", int(d/time.Minute), int(d%time.Minute/time.Second))
+	case d < 100*time.Hour:
+		return sign + fmt.Sprintf("%dh%02dm", int(d/time.Hour), int(d%time.Hour/time.Minute))
+	default:
+		return sign + fmt.Sprintf("%dd%02dh", int(d/(24*time.Hour)), int(d%(24*time.Hour)/time.Hour))
+	}
+}
+
+func (m *logsModel) toggleRelativeTime() {
+	m.relativeTime = !m.relativeTime
+	m.normalize()
+}
+
+func (m *logsModel) toggleHideMeta() {
+	m.hideMeta = !m.hideMeta
+	m.normalize()
+}
+
+// setMinSeverity hides lines ranked below sev; choosing the active threshold
+// again turns the filter off.
+func (m *logsModel) setMinSeverity(sev logSeverity) {
+	if m.minSeverity == sev {
+		sev = logSeverityNeutral
+	}
+	m.minSeverity = sev
+	m.normalize()
+}
+
+func (m *logsModel) toggleGroupTraces() {
+	m.groupTraces = !m.groupTraces
+	m.normalize()
+}
+
+// continuesGroup reports whether next belongs to the same logical event as
+// prev, e.g. a stack frame following an exception header. inTrace is true when
+// prev is itself part of a trace, which lets a trailing "FooError: msg" join it.
+func continuesGroup(prev, next daemon.LogLine, inTrace bool) bool {
+	if prev.Project != next.Project || prev.Service != next.Service {
+		return false
+	}
+	if isTraceFrame(next.Text) {
+		return true
+	}
+	return inTrace && traceTailRegex.MatchString(stripANSI(next.Text))
 }
 
-func formatCopyLine(line daemon.LogLine, includeService bool) string {
+func isTraceFrame(text string) bool {
+	text = stripANSI(text)
+	if strings.TrimSpace(text) == "" {
+		return false
+	}
+	return traceFrameRegex.MatchString(text)
+}
+
+func stripANSI(text string) string {
+	text = ansiOSCRegex.ReplaceAllString(text, "")
+	return ansiCSIRegex.ReplaceAllString(text, "")
+}
+
+func formatCopyLine(line daemon.LogLine, includeService, includeProject bool) string {
 	ts := line.Timestamp.Format("15:04:05")
 	text := sanitizeLogText(line.Text)
+	if includeProject {
+		return fmt.Sprintf("[%s] [%s:%s] %s", ts, line.Project, line.Service, text)
+	}
 	if includeService {
 		return fmt.Sprintf("[%s] [%s] %s", ts, line.Service, text)
 	}
@@ -788,13 +1219,13 @@ func lineStyleWithState(style lipgloss.Style, selected bool, flashPhase int) lip
 	// applying per-row state so global palette styles don't get contaminated.
 	s := style.Copy()
 	if selected {
-		return s.Background(lipgloss.Color("#173026"))
+		return s.Background(theme.Selection)
 	}
 	switch flashPhase {
 	case 2:
-		return s.Background(lipgloss.Color("#153027"))
+		return s.Background(theme.FlashStrong)
 	case 1:
-		return s.Background(lipgloss.Color("#11241d"))
+		return s.Background(theme.FlashWeak)
 	}
 	return s
 }
@@ -808,8 +1239,7 @@ func (m *logsModel) queueCopyFlash() bool {
 	}
 
 	if start, end, ok := m.selectionBounds(len(rows)); ok {
-		m.copyFlashStart = start
-		m.copyFlashEnd = end
+		m.copyFlashStart, m.copyFlashEnd = m.expandRowsToGroups(rows, start, end)
 	} else {
 		rowIdx := m.cursorRow
 		if rowIdx < 0 || rowIdx >= len(rows) {
@@ -818,8 +1248,7 @@ func (m *logsModel) queueCopyFlash() bool {
 				rowIdx = len(rows) - 1
 			}
 		}
-		m.copyFlashStart = rowIdx
-		m.copyFlashEnd = rowIdx
+		m.copyFlashStart, m.copyFlashEnd = m.expandRowsToGroups(rows, rowIdx, rowIdx)
 	}
 	if m.copyFlashStart > m.copyFlashEnd {
 		m.copyFlashStart, m.copyFlashEnd = m.copyFlashEnd, m.copyFlashStart
@@ -879,65 +1308,207 @@ func rowBoundsForLine(rows []renderedLogRow, lineIdx int) (first int, last int,
 }
 
 func wrapLogText(text string, width int) []string {
-	if width <= 0 {
+	chunks := wrapLogRanges(text, width)
+	if len(chunks) == 0 {
 		return []string{""}
 	}
-	if text == "" {
-		return []string{""}
+	lines := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		lines[i] = joinTextRanges(text, chunk)
 	}
+	return lines
+}
 
-	words := strings.Fields(text)
-	if len(words) == 0 {
-		return []string{""}
+// wrapLogRanges word-wraps text to width and returns each row as the byte
+// ranges of the words it holds; a row's text is those words joined by single
+// spaces. Words wider than width are split.
+func wrapLogRanges(text string, width int) [][]textRange {
+	if width <= 0 || text == "" {
+		return nil
 	}
 
-	lines := make([]string, 0, len(words))
-	current := ""
+	var lines [][]textRange
+	var current []textRange
+	currentWidth := 0
 
 	flush := func() {
-		if current != "" {
+		if len(current) > 0 {
 			lines = append(lines, current)
-			current = ""
+			current = nil
+			currentWidth = 0
 		}
 	}
 
-	for _, word := range words {
-		for runewidth.StringWidth(word) > width {
-			part := runewidth.Truncate(word, width, "")
+	for _, word := range fieldRanges(text) {
+		for runewidth.StringWidth(text[word.start:word.end]) > width {
+			part := runewidth.Truncate(text[word.start:word.end], width, "")
 			if part == "" {
-				_, size := utf8.DecodeRuneInString(word)
+				_, size := utf8.DecodeRuneInString(text[word.start:word.end])
 				if size <= 0 {
 					break
 				}
-				part = word[:size]
-			}
-			word = strings.TrimPrefix(word, part)
-			if current == "" {
-				lines = append(lines, part)
-			} else {
-				flush()
-				lines = append(lines, part)
+				part = text[word.start : word.start+size]
 			}
+			flush()
+			lines = append(lines, []textRange{{word.start, word.start + len(part)}})
+			word.start += len(part)
 		}
 
-		if current == "" {
-			current = word
+		wordWidth := runewidth.StringWidth(text[word.start:word.end])
+		if len(current) == 0 {
+			current = []textRange{word}
+			currentWidth = wordWidth
 			continue
 		}
-		candidate := current + " " + word
-		if runewidth.StringWidth(candidate) <= width {
-			current = candidate
+		if currentWidth+1+wordWidth <= width {
+			current = append(current, word)
+			currentWidth += 1 + wordWidth
 			continue
 		}
 		flush()
-		current = word
+		current = []textRange{word}
+		currentWidth = wordWidth
 	}
 	flush()
+	return lines
+}
 
-	if len(lines) == 0 {
-		return []string{""}
+// fieldRanges returns the byte ranges of the whitespace-separated words in text.
+func fieldRanges(text string) []textRange {
+	var fields []textRange
+	start := -1
+	for i, r := range text {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				fields = append(fields, textRange{start, i})
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
 	}
-	return lines
+	if start >= 0 {
+		fields = append(fields, textRange{start, len(text)})
+	}
+	return fields
+}
+
+func joinTextRanges(text string, ranges []textRange) string {
+	parts := make([]string, len(ranges))
+	for i, r := range ranges {
+		parts[i] = text[r.start:r.end]
+	}
+	return strings.Join(parts, " ")
+}
+
+// searchMatchMask marks the bytes of text covered by match's patterns. It
+// returns nil when nothing should be highlighted (no search or an inverted one).
+func searchMatchMask(text string, match daemon.LogMatch) []bool {
+	if match.Invert || len(match.Patterns) == 0 {
+		return nil
+	}
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		return nil // case folding changed byte offsets; skip rather than mislight
+	}
+	var mask []bool
+	for _, p := range match.Patterns {
+		for from := 0; from < len(lower); {
+			idx := strings.Index(lower[from:], p)
+			if idx < 0 {
+				break
+			}
+			if mask == nil {
+				mask = make([]bool, len(text))
+			}
+			for k := from + idx; k < from+idx+len(p); k++ {
+				mask[k] = true
+			}
+			from += idx + len(p)
+		}
+	}
+	return mask
+}
+
+// searchMask marks the bytes of text the current search highlights; match is
+// the parsed substring search, used outside regex mode.
+func (m logsModel) searchMask(text string, match daemon.LogMatch) []bool {
+	if m.searchRegex {
+		return regexMatchMask(text, m.searchRe)
+	}
+	return searchMatchMask(text, match)
+}
+
+// regexMatchMask marks the bytes of text covered by re's matches; nil when
+// re is nil or finds nothing.
+func regexMatchMask(text string, re *regexp.Regexp) []bool {
+	if re == nil {
+		return nil
+	}
+	var mask []bool
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		if mask == nil {
+			mask = make([]bool, len(text))
+		}
+		for k := loc[0]; k < loc[1]; k++ {
+			mask[k] = true
+		}
+	}
+	return mask
+}
+
+// projectMatchMask maps a whole-line match mask onto one wrapped row built from
+// ranges, returning highlighted byte ranges of the row text. The single space
+// joining two words is lit when the whitespace it replaces was part of a match.
+func projectMatchMask(mask []bool, ranges []textRange) []textRange {
+	if mask == nil {
+		return nil
+	}
+	var spans []textRange
+	mark := func(pos int, lit bool) {
+		if !lit {
+			return
+		}
+		if n := len(spans); n > 0 && spans[n-1].end == pos {
+			spans[n-1].end++
+			return
+		}
+		spans = append(spans, textRange{pos, pos + 1})
+	}
+	pos := 0
+	for i, r := range ranges {
+		if i > 0 {
+			mark(pos, r.start > 0 && mask[r.start-1])
+			pos++
+		}
+		for k := r.start; k < r.end; k++ {
+			mark(pos, mask[k])
+			pos++
+		}
+	}
+	return spans
+}
+
+// renderHighlighted renders text with base style, switching to lit for spans.
+func renderHighlighted(text string, spans []textRange, base, lit lipgloss.Style) string {
+	if len(spans) == 0 {
+		return base.Render(text)
+	}
+	var b strings.Builder
+	pos := 0
+	for _, span := range spans {
+		if span.start > pos {
+			b.WriteString(base.Render(text[pos:span.start]))
+		}
+		b.WriteString(lit.Render(text[span.start:span.end]))
+		pos = span.end
+	}
+	if pos < len(text) {
+		b.WriteString(base.Render(text[pos:]))
+	}
+	return b.String()
 }
 
 func truncateDisplayWidth(text string, width int) string {
@@ -1006,19 +1577,8 @@ func classifyLogSeverity(text string, isErr bool) logSeverity {
 		return logSeverityNeutral
 	}
 	lower := strings.ToLower(text)
-	level := extractLevelHint(lower)
-	switch level {
-	case "error", "fatal", "critical", "panic":
-		if benignShutdownRegex.MatchString(lower) {
-			return logSeverityWarning
-		}
-		return logSeverityError
-	case "warn", "warning":
-		return logSeverityWarning
-	case "info":
-		return logSeverityInfo
-	case "debug", "trace", "verbose":
-		return logSeverityDebug
+	if sev, ok := levelSeverity(extractLevelHint(lower), lower); ok {
+		return sev
 	}
 
 	if benignShutdownRegex.MatchString(lower) {
@@ -1050,6 +1610,163 @@ func classifyLogSeverity(text string, isErr bool) logSeverity {
 	return logSeverityNeutral
 }
 
+// lineSeverity classifies a log line the way it is colored: by the level field
+// of a declared log_format, else by guessing from text (the sanitized line).
+// In the all-services view the guess sees the [service] prefix, as rendered.
+func (m logsModel) lineSeverity(line daemon.LogLine, text string) logSeverity {
+	if sev, ok := structuredLogSeverity(text, m.logFormats[line.Service]); ok {
+		return sev
+	}
+	if m.service == "all" {
+		text = "[" + line.Service + "] " + text
+	}
+	return classifyLogSeverity(text, line.IsErr)
+}
+
+// severityRank orders severities for the minimum-severity filter. Lines with
+// no recognizable level rank with info, so plain output survives INFO+.
+func severityRank(sev logSeverity) int {
+	switch sev {
+	case logSeverityDebug:
+		return 0
+	case logSeverityWarning:
+		return 2
+	case logSeverityError:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// severityFilterLabel is the status text for a minimum-severity filter.
+func severityFilterLabel(sev logSeverity) string {
+	switch sev {
+	case logSeverityInfo:
+		return "INFO+"
+	case logSeverityWarning:
+		return "WARN+"
+	case logSeverityError:
+		return "ERR+"
+	}
+	return ""
+}
+
+// levelSeverity maps a level name to a severity; ok is false for unknown levels.
+func levelSeverity(level, lower string) (logSeverity, bool) {
+	switch level {
+	case "error", "err", "fatal", "critical", "crit", "panic", "alert", "emerg", "emergency":
+		if benignShutdownRegex.MatchString(lower) {
+			return logSeverityWarning, true
+		}
+		return logSeverityError, true
+	case "warn", "warning":
+		return logSeverityWarning, true
+	case "info", "notice":
+		return logSeverityInfo, true
+	case "debug", "trace", "verbose":
+		return logSeverityDebug, true
+	}
+	return logSeverityNeutral, false
+}
+
+// structuredLogSeverity reads the level field of a json or logfmt line for a
+// service that declared its log_format. ok is false for plain services, lines
+// that don't parse, or lines without a level, so callers fall back to guessing.
+func structuredLogSeverity(text, format string) (logSeverity, bool) {
+	var level string
+	switch format {
+	case "json":
+		level = jsonLogLevel(text)
+	case "logfmt":
+		level = logfmtLevel(text)
+	default:
+		return logSeverityNeutral, false
+	}
+	if level == "" {
+		return logSeverityNeutral, false
+	}
+	return levelSeverity(level, strings.ToLower(text))
+}
+
+var structuredLevelKeys = []string{"level", "lvl", "severity", "levelname", "log.level"}
+
+func jsonLogLevel(text string) string {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "{") {
+		return ""
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(text), &fields); err != nil {
+		return ""
+	}
+	for _, key := range structuredLevelKeys {
+		switch v := fields[key].(type) {
+		case string:
+			return strings.ToLower(strings.TrimSpace(v))
+		case float64:
+			return pinoLevelName(v)
+		}
+	}
+	return ""
+}
+
+// pinoLevelName maps pino/bunyan numeric levels to names.
+func pinoLevelName(n float64) string {
+	switch {
+	case n >= 60:
+		return "fatal"
+	case n >= 50:
+		return "error"
+	case n >= 40:
+		return "warn"
+	case n >= 30:
+		return "info"
+	case n >= 20:
+		return "debug"
+	case n > 0:
+		return "trace"
+	}
+	return ""
+}
+
+func logfmtLevel(text string) string {
+	for len(text) > 0 {
+		text = strings.TrimLeft(text, " ")
+		eq := strings.IndexAny(text, "= ")
+		if eq < 0 || text[eq] != '=' {
+			if eq < 0 {
+				return ""
+			}
+			text = text[eq:]
+			continue
+		}
+		key := strings.ToLower(text[:eq])
+		text = text[eq+1:]
+		var value string
+		if strings.HasPrefix(text, `"`) {
+			end := 1
+			for end < len(text) && (text[end] != '"' || text[end-1] == '\\') {
+				end++
+			}
+			value = strings.Trim(text[:min(end+1, len(text))], `"`)
+			text = text[min(end+1, len(text)):]
+		} else {
+			end := strings.IndexByte(text, ' ')
+			if end < 0 {
+				end = len(text)
+			}
+			value = text[:end]
+			text = text[end:]
+		}
+		for _, want := range structuredLevelKeys {
+			if key == want {
+				return strings.ToLower(strings.TrimSpace(value))
+			}
+		}
+	}
+	return ""
+}
+
 func extractLevelHint(lower string) string {
 	if m := jsonLevelRegex.FindStringSubmatch(lower); len(m) == 2 {
 		return strings.TrimSpace(m[1])
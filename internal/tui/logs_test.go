@@ -121,6 +121,41 @@ func TestClassifyLogSeverity(t *testing.T) {
 	}
 }
 
+func TestStructuredLogSeverityTrustsDeclaredFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		text   string
+		want   logSeverity
+		ok     bool
+	}{
+		{"json level beats error in url", "json", `{"level":"info","msg":"GET /api/error-report 200"}`, logSeverityInfo, true},
+		{"json pino numeric level", "json", `{"level":50,"msg":"db down"}`, logSeverityError, true},
+		{"logfmt quoted message", "logfmt", `ts=2026-01-02 msg="failed to parse error=1" level=warn`, logSeverityWarning, true},
+		{"logfmt lvl alias", "logfmt", `lvl=debug msg=tick`, logSeverityDebug, true},
+		{"json parse failure falls back", "json", `panic: runtime error`, logSeverityNeutral, false},
+		{"plain always falls back", "plain", `level=error`, logSeverityNeutral, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := structuredLogSeverity(tc.text, tc.format)
+			if got != tc.want || ok != tc.ok {
+				t.Fatalf("structuredLogSeverity() = %v, %v; want %v, %v", got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+
+	m := logsModel{
+		service:    "api",
+		width:      120,
+		logFormats: map[string]string{"api": "json"},
+		lines:      []daemon.LogLine{{Service: "api", Timestamp: time.Now(), Text: `{"level":"info","msg":"GET /error 200"}`}},
+	}
+	if rows := m.buildRenderedRows(m.filteredLines()); rows[0].severity != logSeverityInfo {
+		t.Fatalf("row severity = %v, want info from declared json format", rows[0].severity)
+	}
+}
+
 func TestLogsWrapToggleBuildsMultipleRows(t *testing.T) {
 	m := logsModel{
 		service: "svc",
@@ -144,6 +179,113 @@ func TestLogsWrapToggleBuildsMultipleRows(t *testing.T) {
 	}
 }
 
+func TestSearchScopesAllViewToNamedServices(t *testing.T) {
+	now := time.Now()
+	m := logsModel{
+		service: "all",
+		width:   100,
+		height:  12,
+		lines: []daemon.LogLine{
+			{Service: "api", Text: "error: db timeout", Timestamp: now},
+			{Service: "web", Text: "error: chunk failed", Timestamp: now},
+			{Service: "web", Text: "compiled ok", Timestamp: now},
+			{Service: "worker", Text: "error: job lost", Timestamp: now},
+		},
+	}
+	texts := func() string {
+		var out []string
+		for _, line := range m.filteredLines() {
+			out = append(out, line.Text)
+		}
+		return strings.Join(out, "|")
+	}
+
+	if err := m.setSearch("svc:web error"); err != nil {
+		t.Fatalf("set search: %v", err)
+	}
+	if got := texts(); got != "error: chunk failed" {
+		t.Fatalf("svc:web error kept %q", got)
+	}
+	if !strings.Contains(m.View(), "svc:web") {
+		t.Fatalf("header should show the service scope:\n%s", m.View())
+	}
+	rows := m.buildRenderedRows(m.filteredLines())
+	if len(rows) != 1 || len(rows[0].highlights) != 1 || rows[0].text[rows[0].highlights[0].start:rows[0].highlights[0].end] != "error" {
+		t.Fatalf("only the text part should be highlighted: %+v", rows)
+	}
+
+	if err := m.setSearch("error svc:api,worker"); err != nil {
+		t.Fatalf("set search: %v", err)
+	}
+	if got := texts(); got != "error: db timeout|error: job lost" {
+		t.Fatalf("svc:api,worker kept %q", got)
+	}
+
+	m.searchRegex = true
+	if err := m.setSearch("svc:web ^comp"); err != nil {
+		t.Fatalf("regex with scope should compile: %v", err)
+	}
+	if got := texts(); got != "compiled ok" {
+		t.Fatalf("regex svc:web ^comp kept %q", got)
+	}
+
+	m.service = "web"
+	m.searchRegex = false
+	if err := m.setSearch("svc:web"); err != nil {
+		t.Fatalf("set search: %v", err)
+	}
+	if got := texts(); got != "" {
+		t.Fatalf("outside the all view svc: is plain text, kept %q", got)
+	}
+}
+
+func TestSearchHighlightFollowsMatchAcrossWrapBoundary(t *testing.T) {
+	m := logsModel{
+		service: "svc",
+		width:   33, // 20 columns of text after marker and timestamp
+		height:  12,
+		wrap:    true,
+		search:  "alpha omega",
+		lines: []daemon.LogLine{{
+			Timestamp: time.Now(),
+			Text:      "xxxxxxxxxx ALPHA omega tail",
+		}},
+	}
+
+	lit := func(row renderedLogRow) string {
+		var parts []string
+		for _, span := range row.highlights {
+			parts = append(parts, row.text[span.start:span.end])
+		}
+		return strings.Join(parts, "|")
+	}
+
+	rows := m.buildRenderedRows(m.filteredLines())
+	if len(rows) != 2 || rows[0].text != "xxxxxxxxxx ALPHA" || rows[1].text != "omega tail" {
+		t.Fatalf("unexpected wrap: %+v", rows)
+	}
+	if lit(rows[0]) != "ALPHA" || lit(rows[1]) != "omega" {
+		t.Fatalf("highlights = %q / %q, want ALPHA / omega", lit(rows[0]), lit(rows[1]))
+	}
+
+	// A long word split mid-match keeps both halves lit.
+	m.search = "stuvw"
+	m.lines[0].Text = "abcdefghijklmnopqrstuvwxyz"
+	rows = m.buildRenderedRows(m.filteredLines())
+	if len(rows) != 2 || lit(rows[0]) != "st" || lit(rows[1]) != "uvw" {
+		t.Fatalf("split-word highlights = %q / %q", lit(rows[0]), lit(rows[1]))
+	}
+
+	// When the match stays on one row the joining space is lit too.
+	m.search = "alpha omega"
+	m.width = 60
+	m.lines[0].Text = "xxxxxxxxxx ALPHA omega tail"
+	rows = m.buildRenderedRows(m.filteredLines())
+	if len(rows) != 1 || lit(rows[0]) != "ALPHA omega" {
+		t.Fatalf("single-row highlight = %q", lit(rows[0]))
+	}
+}
+
 func TestLogsRowsClampDisplayWidthForWideRunes(t *testing.T) {
 	m := logsModel{
 		service: "svc",
@@ -192,7 +334,7 @@ func TestSelectionWithWrapMovesByRenderedRow(t *testing.T) {
 		},
 	}
 	m.jumpTop()
-	m.startSelectionMode()
+	m.startSelectionMode(false)
 	m.moveCursor(1)
 
 	if m.selectionAnchor != 0 || m.selectionEnd != 1 {
@@ -218,7 +360,7 @@ func TestCopyPayloadDedupesWrappedRowsInSelection(t *testing.T) {
 		},
 	}
 	m.jumpTop()
-	m.startSelectionMode()
+	m.startSelectionMode(false)
 	for i := 0; i < 3; i++ {
 		m.moveCursor(1)
 	}
@@ -232,6 +374,42 @@ func TestCopyPayloadDedupesWrappedRowsInSelection(t *testing.T) {
 	}
 }
 
+func TestLogsLineSelectionStepsOverWrappedRows(t *testing.T) {
+	base := time.Now()
+	long := "this is a very long log line that wraps across multiple rendered rows"
+	m := logsModel{
+		service:    "svc",
+		width:      46,
+		height:     12,
+		autoScroll: false,
+		wrap:       true,
+		lines: []daemon.LogLine{
+			{Timestamp: base, Text: long},
+			{Timestamp: base.Add(time.Second), Text: long},
+			{Timestamp: base.Add(2 * time.Second), Text: "third"},
+		},
+	}
+	m.jumpTop()
+	m.startSelectionMode(true)
+	m.moveCursor(1)
+
+	if m.cursor != 1 {
+		t.Fatalf("one press should move to the next log line, cursor = %d", m.cursor)
+	}
+	rows := m.buildRenderedRows(m.filteredLines())
+	if _, last, _ := rowBoundsForLine(rows, 1); m.cursorRow != last {
+		t.Fatalf("cursor row = %d, want the last row of line 1 (%d)", m.cursorRow, last)
+	}
+	if _, count := m.copyPayload(); count != 2 {
+		t.Fatalf("copy count = %d, want 2", count)
+	}
+
+	m.moveCursor(-1)
+	if start, end, _ := m.selectionBounds(len(rows)); start != 0 || rows[end].lineIndex != 0 {
+		t.Fatalf("moving back should shrink to the whole first line, got rows %d-%d", start, end)
+	}
+}
+
 func TestLogsAllViewCopyIncludesServiceName(t *testing.T) {
 	m := logsModel{
 		service: "all",
@@ -252,6 +430,38 @@ func TestLogsAllViewCopyIncludesServiceName(t *testing.T) {
 	}
 }
 
+func TestLogsAllViewCopyFollowsSequenceOrder(t *testing.T) {
+	base := time.Now()
+	m := logsModel{
+		service: "all",
+		width:   80,
+		height:  10,
+		lines: []daemon.LogLine{
+			{Timestamp: base, Project: "shop", Service: "api", Text: "second", Seq: 2},
+			{Timestamp: base, Project: "shop", Service: "web", Text: "first", Seq: 1},
+			{Timestamp: base, Project: "shop", Service: "api", Text: "third", Seq: 3},
+		},
+	}
+	m.jumpTop()
+	m.startSelectionMode(false)
+	m.moveCursor(2)
+
+	payload, count := m.copyPayload()
+	if count != 3 {
+		t.Fatalf("copy count = %d, want 3", count)
+	}
+	got := strings.Split(payload, "\n")
+	if !strings.HasSuffix(got[0], "[web] first") || !strings.HasSuffix(got[2], "[api] third") {
+		t.Fatalf("copied lines should follow seq order, got %q", payload)
+	}
+
+	m.lines[1].Project = "blog"
+	payload, _ = m.copyPayload()
+	if !strings.Contains(payload, "[blog:web] first") || !strings.Contains(payload, "[shop:api] second") {
+		t.Fatalf("lines spanning projects should carry the project, got %q", payload)
+	}
+}
+
 func TestLogsUnreadLifecycleWithFollowPauseAndResume(t *testing.T) {
 	base := time.Now()
 	m := logsModel{
@@ -518,7 +728,7 @@ func TestStartSelectionModeResetsRangeToCursor(t *testing.T) {
 	}
 	m.normalize()
 
-	m.startSelectionMode()
+	m.startSelectionMode(false)
 	if !m.selectionMode {
 		t.Fatal("expected selection mode enabled")
 	}
@@ -540,7 +750,7 @@ func TestStartSelectionModePausesLive(t *testing.T) {
 	}
 	m.setLines(m.lines)
 
-	m.startSelectionMode()
+	m.startSelectionMode(false)
 	if m.autoScroll {
 		t.Fatal("expected live paused when starting selection")
 	}
@@ -563,7 +773,7 @@ func TestPrimedSelectionStaysSingleRowOnIncomingLogs(t *testing.T) {
 		},
 	}
 	m.jumpBottom()
-	m.startSelectionMode()
+	m.startSelectionMode(false)
 	if !m.selectionPrimed {
 		t.Fatal("expected primed selection after starting selection mode")
 	}
@@ -589,3 +799,244 @@ func TestLineStyleWithStateDoesNotMutateGlobalSeverityStyle(t *testing.T) {
 		t.Fatalf("expected logInfo background to remain unchanged, before=%v after=%v", originalBg, afterBg)
 	}
 }
+
+func TestStickyTailResumesFollowWhenParkedOnLastLine(t *testing.T) {
+	base := time.Now()
+	m := logsModel{
+		service:    "svc",
+		width:      80,
+		height:     12,
+		autoScroll: true,
+		sticky:     true,
+	}
+	m.setLines([]daemon.LogLine{
+		{Timestamp: base, Text: "l1"},
+		{Timestamp: base.Add(time.Second), Text: "l2"},
+	})
+
+	m.moveCursor(-1)
+	if m.autoScroll {
+		t.Fatal("expected scrolling up to stop following")
+	}
+	m.setLines([]daemon.LogLine{
+		{Timestamp: base, Text: "l1"},
+		{Timestamp: base.Add(time.Second), Text: "l2"},
+		{Timestamp: base.Add(2 * time.Second), Text: "l3"},
+	})
+	if m.autoScroll {
+		t.Fatal("expected no follow while cursor is above the last line")
+	}
+	if m.unread != 1 {
+		t.Fatalf("unread = %d, want 1", m.unread)
+	}
+
+	// Park on the last line without resuming explicitly, then receive more output.
+	m.cursorRow = 2
+	m.cursor = 2
+	m.setLines([]daemon.LogLine{
+		{Timestamp: base, Text: "l1"},
+		{Timestamp: base.Add(time.Second), Text: "l2"},
+		{Timestamp: base.Add(2 * time.Second), Text: "l3"},
+		{Timestamp: base.Add(3 * time.Second), Text: "l4"},
+	})
+	if !m.autoScroll {
+		t.Fatal("expected sticky tail to follow when parked on the last line")
+	}
+	if m.cursor != 3 {
+		t.Fatalf("cursor = %d, want 3", m.cursor)
+	}
+	if !strings.Contains(m.statusText(), "TAIL") {
+		t.Fatalf("status = %q, want TAIL label", m.statusText())
+	}
+}
+
+func TestGroupTracesCopiesWholeStackTrace(t *testing.T) {
+	base := time.Now()
+	m := logsModel{
+		service:     "api",
+		width:       120,
+		height:      20,
+		groupTraces: true,
+	}
+	m.setLines([]daemon.LogLine{
+		{Timestamp: base, Service: "api", Text: "request ok"},
+		{Timestamp: base, Service: "api", Text: "Traceback (most recent call last):"},
+		{Timestamp: base, Service: "api", Text: `  File "app.py", line 3, in handler`},
+		{Timestamp: base, Service: "api", Text: "    run()"},
+		{Timestamp: base, Service: "api", Text: "ValueError: bad input"},
+		{Timestamp: base, Service: "api", Text: "request ok"},
+	})
+	m.cursor = 3
+	m.cursorRow = 3
+
+	payload, count := m.copyPayload()
+	if count != 4 {
+		t.Fatalf("copied %d lines, want 4:\n%s", count, payload)
+	}
+	if !strings.Contains(payload, "Traceback") || !strings.Contains(payload, "ValueError: bad input") {
+		t.Fatalf("expected whole trace in payload, got:\n%s", payload)
+	}
+	if strings.Contains(payload, "request ok") {
+		t.Fatalf("expected surrounding lines to be excluded, got:\n%s", payload)
+	}
+
+	m.toggleGroupTraces()
+	if _, count := m.copyPayload(); count != 1 {
+		t.Fatalf("copied %d lines with grouping off, want 1", count)
+	}
+}
+
+func TestRegexSearchFiltersAndKeepsLastGoodPattern(t *testing.T) {
+	now := time.Now()
+	m := logsModel{
+		service: "svc",
+		width:   80,
+		height:  12,
+		lines: []daemon.LogLine{
+			{Timestamp: now, Text: "ERROR 42 disk full"},
+			{Timestamp: now, Text: "err   7 retrying"},
+			{Timestamp: now, Text: "error: no code"},
+		},
+	}
+	if err := m.toggleSearchRegex(); err != nil {
+		t.Fatalf("toggle with empty search: %v", err)
+	}
+	if err := m.setSearch(`err(or)?\s+\d+`); err != nil {
+		t.Fatalf("setSearch: %v", err)
+	}
+	if got := len(m.filteredLines()); got != 2 {
+		t.Fatalf("regex kept %d lines, want 2", got)
+	}
+	if !strings.Contains(m.View(), "/re:") {
+		t.Fatalf("header should show the regex label")
+	}
+
+	if err := m.setSearch(`err(or`); err == nil {
+		t.Fatalf("expected a compile error")
+	}
+	if got := len(m.filteredLines()); got != 2 {
+		t.Fatalf("bad pattern kept %d lines, want the previous 2", got)
+	}
+
+	// Back in substring mode the same text is matched literally.
+	if err := m.toggleSearchRegex(); err != nil {
+		t.Fatalf("toggle back: %v", err)
+	}
+	if got := len(m.filteredLines()); got != 0 {
+		t.Fatalf("substring search kept %d lines, want 0", got)
+	}
+}
+
+func TestNextMatchWrapsAroundMatchingRows(t *testing.T) {
+	now := time.Now()
+	m := logsModel{
+		service:    "svc",
+		width:      80,
+		height:     12,
+		autoScroll: true,
+		search:     "!nothing", // negated search keeps every line but lights none
+		lines: []daemon.LogLine{
+			{Timestamp: now, Text: "boot"},
+			{Timestamp: now, Text: "timeout one"},
+			{Timestamp: now, Text: "ok"},
+			{Timestamp: now, Text: "timeout two"},
+		},
+	}
+	if found, _ := m.nextMatch(); found {
+		t.Fatalf("negated search has no highlighted rows to jump to")
+	}
+
+	m.setSearch("timeout")
+	found, wrapped := m.nextMatch()
+	if !found || !wrapped || m.autoScroll {
+		t.Fatalf("from live mode n should wrap to the first match, got found=%v wrapped=%v autoScroll=%v", found, wrapped, m.autoScroll)
+	}
+	first := m.cursor
+	if found, wrapped = m.nextMatch(); !found || wrapped || m.cursor == first {
+		t.Fatalf("second n should move forward without wrapping")
+	}
+	if found, wrapped = m.prevMatch(); !found || wrapped || m.cursor != first {
+		t.Fatalf("N should return to the first match")
+	}
+	if found, wrapped = m.prevMatch(); !found || !wrapped {
+		t.Fatalf("N at the first match should wrap to the last")
+	}
+}
+
+func TestMinSeverityComposesWithSearch(t *testing.T) {
+	now := time.Now()
+	m := logsModel{
+		service: "svc",
+		width:   80,
+		height:  12,
+		lines: []daemon.LogLine{
+			{Timestamp: now, Text: "DEBUG: cache warm"},
+			{Timestamp: now, Text: "listening on :3000"},
+			{Timestamp: now, Text: "WARN: cache slow"},
+			{Timestamp: now, Text: "ERROR: cache down"},
+		},
+	}
+	count := func() int { return len(m.filteredLines()) }
+
+	m.setMinSeverity(logSeverityInfo)
+	if count() != 3 || !strings.Contains(m.statusText(), "INFO+") {
+		t.Fatalf("INFO+ kept %d lines (status %q), want 3 without debug", count(), m.statusText())
+	}
+	m.setMinSeverity(logSeverityWarning)
+	if count() != 2 {
+		t.Fatalf("WARN+ kept %d lines, want 2", count())
+	}
+	m.setSearch("cache")
+	m.setMinSeverity(logSeverityError)
+	if got := m.filteredLines(); len(got) != 1 || got[0].Text != "ERROR: cache down" || !strings.Contains(m.statusText(), "ERR+") {
+		t.Fatalf("ERR+ with search = %+v", got)
+	}
+
+	// Choosing the active threshold again clears it.
+	m.setMinSeverity(logSeverityError)
+	if count() != 3 || strings.Contains(m.statusText(), "+") {
+		t.Fatalf("cleared filter kept %d cache lines, want 3", count())
+	}
+}
+
+func TestRelativeTimestampsKeepTheColumnWidth(t *testing.T) {
+	started := time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local)
+	m := logsModel{
+		service:       "api",
+		width:         40,
+		height:        10,
+		serviceStarts: map[string]time.Time{"shop:api": started},
+		lines: []daemon.LogLine{
+			{Project: "shop", Service: "api", Timestamp: started.Add(2300 * time.Millisecond), Text: "booted"},
+			{Project: "shop", Service: "api", Timestamp: started.Add(62*time.Minute + 5*time.Second), Text: "later"},
+			{Project: "shop", Service: "other", Timestamp: started, Text: "no start known"},
+		},
+	}
+	m.toggleRelativeTime()
+	rows := m.buildRenderedRows(m.filteredLines())
+	want := []string{"[   +2.3s]", "[  +1h02m]", "[10:00:00]"}
+	for i, row := range rows {
+		if row.timestamp != want[i] {
+			t.Fatalf("row %d stamp = %q, want %q", i, row.timestamp, want[i])
+		}
+	}
+	if !strings.Contains(m.statusText(), "REL") {
+		t.Fatalf("status %q should show REL", m.statusText())
+	}
+	for _, line := range strings.Split(m.View(), "\n") {
+		if w := lipgloss.Width(line); w > m.width {
+			t.Fatalf("row is %d wide, over %d: %q", w, m.width, line)
+		}
+	}
+
+	for d, want := range map[time.Duration]string{
+		-1500 * time.Millisecond:           "-1.5s",
+		4*time.Minute + 5*time.Second:      "+4m05s",
+		99*time.Hour + 59*time.Minute:      "+99h59m",
+		30*24*time.Hour + 4*time.Hour + 10: "+30d04h",
+	} {
+		if got := relativeStamp(d); got != want || len(got) > 8 {
+			t.Fatalf("relativeStamp(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -14,12 +15,18 @@ type pickerModel struct {
 	input    string
 	width    int
 	height   int
+
+	// favoritesGrouped sorts favorites first among running and among
+	// stopped projects instead of above both (picker.favorites: grouped).
+	favoritesGrouped bool
 }
 
 type pickerItem struct {
-	name    string
-	running bool
-	svcs    int
+	name     string
+	running  bool
+	favorite bool
+	svcs     int
+	group    int // section the item is listed in; a separator splits sections
 }
 
 func (m *pickerModel) filter() {
@@ -39,17 +46,58 @@ func (m *pickerModel) filter() {
 	m.clampOffset()
 }
 
-// buildFiltered returns items sorted: running first, then stopped.
+// buildFiltered returns items sorted: favorites, then running, then stopped.
+// With favoritesGrouped, favorites instead lead the running and the stopped
+// sections.
 func (m *pickerModel) buildFiltered(items []pickerItem) []pickerItem {
-	var running, stopped []pickerItem
-	for _, item := range items {
-		if item.running {
-			running = append(running, item)
-		} else {
-			stopped = append(stopped, item)
+	sorted := make([]pickerItem, len(items))
+	copy(sorted, items)
+	for i := range sorted {
+		item := &sorted[i]
+		switch {
+		case item.favorite && !m.favoritesGrouped:
+			item.group = 0
+		case item.running:
+			item.group = 1
+		default:
+			item.group = 2
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].group != sorted[j].group {
+			return sorted[i].group < sorted[j].group
+		}
+		return sorted[i].favorite && !sorted[j].favorite
+	})
+	return sorted
+}
+
+// toggleFavorite flips the selected project's favorite mark and re-sorts,
+// keeping the cursor on that project.
+func (m *pickerModel) toggleFavorite() (pickerItem, bool) {
+	item, ok := m.selectedItem()
+	if !ok {
+		return pickerItem{}, false
+	}
+	item.favorite = !item.favorite
+	m.setFavorite(item.name, item.favorite)
+	for i, it := range m.filtered {
+		if it.name == item.name {
+			m.selected = i
 		}
 	}
-	return append(running, stopped...)
+	m.clampOffset()
+	return item, true
+}
+
+// setFavorite marks project as a favorite, or not, and re-sorts.
+func (m *pickerModel) setFavorite(project string, favorite bool) {
+	for i := range m.items {
+		if m.items[i].name == project {
+			m.items[i].favorite = favorite
+		}
+	}
+	m.filter()
 }
 
 func (m *pickerModel) clampSelected() {
@@ -161,7 +209,7 @@ func (m pickerModel) View() string {
 
 		for i := start; i < end; i++ {
 			item := m.filtered[i]
-			if i > 0 && item.running != m.filtered[i-1].running {
+			if i > 0 && item.group != m.filtered[i-1].group {
 				lines = append(lines, descStyle.Render("  ──────────"))
 			}
 
@@ -172,18 +220,22 @@ func (m pickerModel) View() string {
 				style = pickerItemActive
 			}
 
+			name := style.Render(item.name)
+			if item.favorite {
+				name = pickerFavorite.Render("★ ") + name
+			}
 			if item.running {
 				dot := pickerItemRunning.Render("● ")
 				svcs := descStyle.Render(fmt.Sprintf("%d svcs", item.svcs))
-				lines = append(lines, cursor+dot+style.Render(item.name)+"    "+svcs)
+				lines = append(lines, cursor+dot+name+"    "+svcs)
 			} else {
-				lines = append(lines, cursor+style.Render(item.name))
+				lines = append(lines, cursor+name)
 			}
 		}
 	}
 
 	lines = append(lines, "")
-	lines = append(lines, descStyle.Render("[enter] start/focus  [esc] cancel"))
+	lines = append(lines, descStyle.Render("[enter] start/focus  [ctrl+f] favorite  [esc] cancel"))
 
 	content := strings.Join(lines, "\n")
 	style := pickerStyle
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sourabhrathourr/hun/internal/daemon"
+)
+
+// reproErrorLines caps how many error lines a reproduction bundle carries.
+const reproErrorLines = 20
+
+// reproBundle formats a markdown snippet for asking someone else for help: the
+// service's command and cwd, how it ended, and its recent error lines. When
+// no line classifies as an error, the last few lines of output are used.
+func reproBundle(project, service string, info daemon.ServiceInfo, svc projectServiceInfo, lines []daemon.LogLine) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s / %s**: %s\n\n", project, service, reproStatus(info))
+
+	if svc.Cmd != "" {
+		b.WriteString("```sh\n")
+		if svc.Cwd != "" {
+			fmt.Fprintf(&b, "cd %s\n", svc.Cwd)
+		}
+		b.WriteString(svc.Cmd + "\n```\n\n")
+	}
+
+	var errors []daemon.LogLine
+	for _, line := range lines {
+		text := sanitizeLogText(line.Text)
+		sev, ok := structuredLogSeverity(text, svc.LogFormat)
+		if !ok {
+			sev = classifyLogSeverity(text, line.IsErr)
+		}
+		if sev == logSeverityError {
+			errors = append(errors, line)
+		}
+	}
+	heading := "Recent errors"
+	if len(errors) == 0 {
+		heading = "Last output"
+		errors = lines
+		if len(errors) > 10 {
+			errors = errors[len(errors)-10:]
+		}
+	}
+	if len(errors) > reproErrorLines {
+		errors = errors[len(errors)-reproErrorLines:]
+	}
+	if len(errors) == 0 {
+		b.WriteString("_No log output captured._\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "%s:\n\n```\n", heading)
+	for _, line := range errors {
+		b.WriteString(formatCopyLine(line, false, false) + "\n")
+	}
+	b.WriteString("```\n")
+	return b.String()
+}
+
+func reproStatus(info daemon.ServiceInfo) string {
+	status := strings.TrimSpace(info.Status)
+	if status == "" {
+		status = "stopped"
+		if info.Running {
+			status = "running"
+		}
+	}
+	switch {
+	case status == "crashed" && info.ExitCode > 0:
+		return fmt.Sprintf("crashed (exit code %d)", info.ExitCode)
+	case status == "crashed" && info.ExitCode < 0:
+		return "crashed (killed by a signal)"
+	}
+	return status
+}
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourabhrathourr/hun/internal/daemon"
+)
+
+func TestReproBundleIncludesCommandExitCodeAndErrors(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	lines := []daemon.LogLine{
+		{Service: "api", Timestamp: now, Text: "listening on :3000"},
+		{Service: "api", Timestamp: now, Text: "Error: connect ECONNREFUSED 127.0.0.1:5432"},
+		{Service: "api", Timestamp: now, Text: `{"level":"info","msg":"retrying"}`},
+	}
+	info := daemon.ServiceInfo{Status: "crashed", ExitCode: 1}
+	svc := projectServiceInfo{Cmd: "npm run dev", Cwd: "/src/shop/api", LogFormat: "json"}
+
+	got := reproBundle("shop", "api", info, svc, lines)
+	for _, want := range []string{
+		"**shop / api**: crashed (exit code 1)",
+		"```sh\ncd /src/shop/api\nnpm run dev\n```",
+		"Recent errors:",
+		"[15:04:05] Error: connect ECONNREFUSED 127.0.0.1:5432",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("bundle missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "listening on") || strings.Contains(got, "retrying") {
+		t.Fatalf("bundle should only carry error lines:\n%s", got)
+	}
+}
+
+func TestReproBundleFallsBackToLastOutput(t *testing.T) {
+	lines := []daemon.LogLine{{Service: "web", Timestamp: time.Now(), Text: "ready in 120ms"}}
+	got := reproBundle("shop", "web", daemon.ServiceInfo{Running: true}, projectServiceInfo{}, lines)
+	if !strings.Contains(got, "**shop / web**: running") || !strings.Contains(got, "Last output:") || !strings.Contains(got, "ready in 120ms") {
+		t.Fatalf("unexpected bundle:\n%s", got)
+	}
+	if strings.Contains(got, "```sh") {
+		t.Fatalf("bundle without a command should omit the sh block:\n%s", got)
+	}
+}
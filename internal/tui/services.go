@@ -2,17 +2,27 @@ package tui
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
 type serviceItem struct {
-	name    string
-	port    int
-	running bool
-	ready   bool
-	crashed bool
-	stopped bool
+	name     string
+	port     int
+	running  bool
+	ready    bool
+	crashed  bool
+	stopped  bool
+	paused   bool
+	disabled bool // skipped on start because its when: condition is false
+	holding  bool // shown from before a project restart until it reports again
+	restarts int
+	activity string // sparkline of recent log-line rate; empty when quiet
+	cpu      float64
+	memBytes int64 // resident memory of the process group; 0 when stopped or unknown
+	pinned   bool  // sorted above unpinned services
 }
 
 type servicesModel struct {
@@ -21,6 +31,11 @@ type servicesModel struct {
 	height   int
 	width    int
 	active   bool
+
+	tagFilter string
+
+	jumping   bool   // type-to-jump is active
+	jumpQuery string // text typed so far while jumping
 }
 
 func (m servicesModel) View() string {
@@ -31,14 +46,24 @@ func (m servicesModel) View() string {
 		titleStyle = serviceTitleStyle
 	}
 	title := focusArrow + " " + titleStyle.Render("Services") + " " + serviceTitleCount.Render(fmt.Sprintf("(%d)", len(m.items)))
+	if m.tagFilter != "" {
+		title += " " + serviceTitleCount.Render("#"+m.tagFilter)
+	}
+	if m.jumping {
+		title += " " + searchLabelStyle.Render("'") + searchBarStyle.Render(m.jumpQuery+"\u2588")
+	}
 	lines := []string{title, ""}
 
 	for i, item := range m.items {
 		dot := dotStopped
 		if item.crashed {
 			dot = dotCrashed
+		} else if item.paused {
+			dot = dotPaused
 		} else if item.running {
 			dot = dotRunning
+		} else if item.disabled {
+			dot = dotSkipped
 		} else if item.stopped {
 			dot = dotStopped
 		}
@@ -64,10 +89,129 @@ func (m servicesModel) View() string {
 			ready = " " + readyCheck
 		}
 
-		line := fmt.Sprintf("%s%s %s%s%s", cursor, dot, style.Render(item.name), ready, port)
+		restarts := ""
+		if item.restarts > 0 {
+			restarts = " " + restartBadge.Render(fmt.Sprintf("\u21bb%d", item.restarts))
+		}
+
+		if item.disabled {
+			restarts = " " + serviceTitleCount.Render("off")
+		}
+		if item.holding {
+			if i != m.selected {
+				style = serviceTitleCount
+			}
+			ready = ""
+			restarts = " " + serviceTitleCount.Render("restarting\u2026")
+		}
+
+		pin := ""
+		if item.pinned {
+			pin = " " + pinMark
+		}
+
+		line := fmt.Sprintf("%s%s %s%s%s%s%s", cursor, dot, style.Render(item.name), pin, ready, restarts, port)
+		room := m.width - serviceListStyle.GetHorizontalPadding()
+		if usage := item.usage(); usage != "" && item.running && !item.holding {
+			// Usage only shows when it fits; the name and port come first.
+			if lipgloss.Width(line)+1+len(usage) <= room {
+				line += " " + portStyle.Render(usage)
+			}
+		}
+		if item.activity != "" && !item.holding {
+			// Right-align the sparkline when the row has room for it.
+			gap := room - lipgloss.Width(line) - lipgloss.Width(item.activity)
+			if gap >= 1 {
+				line += strings.Repeat(" ", gap) + sparkStyle.Render(item.activity)
+			}
+		}
 		lines = append(lines, line)
 	}
 
 	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
 	return serviceListStyle.Width(m.width).Height(m.height).Render(content)
 }
+
+// usage renders the service's CPU and memory compactly, e.g. "12% 143M", or
+// "" before the daemon has sampled it.
+func (item serviceItem) usage() string {
+	if item.memBytes <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.0f%% %s", item.cpu, compactBytes(item.memBytes))
+}
+
+// compactBytes renders bytes in at most four columns with a binary unit,
+// e.g. 812K, 143M, 1.2G.
+func compactBytes(bytes int64) string {
+	const k = 1024
+	switch {
+	case bytes < k:
+		return fmt.Sprintf("%dB", bytes)
+	case bytes < k*k:
+		return fmt.Sprintf("%dK", bytes/k)
+	case bytes < k*k*k:
+		return fmt.Sprintf("%dM", bytes/(k*k))
+	case bytes < 10*k*k*k:
+		return fmt.Sprintf("%.1fG", float64(bytes)/(k*k*k))
+	default:
+		return fmt.Sprintf("%dG", bytes/(k*k*k))
+	}
+}
+
+// logStatus is how the logs pane describes the service: crashed, running,
+// or stopped.
+func (item serviceItem) logStatus() string {
+	switch {
+	case item.crashed:
+		return "crashed"
+	case item.running:
+		return "running"
+	default:
+		return "stopped"
+	}
+}
+
+// sortServiceItems orders pinned services first, then by name.
+func sortServiceItems(items []serviceItem) {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].pinned != items[j].pinned {
+			return items[i].pinned
+		}
+		return items[i].name < items[j].name
+	})
+}
+
+// indexOf returns the position of the named service, or -1 if it is not listed.
+func (m servicesModel) indexOf(name string) int {
+	for i, item := range m.items {
+		if item.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// jumpTarget picks the service to jump to for query: the first name with that
+// prefix starting from the current selection, else the first name containing
+// it. It returns -1 when nothing matches.
+func (m servicesModel) jumpTarget(query string) int {
+	query = strings.ToLower(query)
+	n := len(m.items)
+	if query == "" || n == 0 {
+		return -1
+	}
+	start := m.selected
+	if start < 0 || start >= n {
+		start = 0
+	}
+	for _, match := range []func(string, string) bool{strings.HasPrefix, strings.Contains} {
+		for i := 0; i < n; i++ {
+			idx := (start + i) % n
+			if match(strings.ToLower(m.items[idx].name), query) {
+				return idx
+			}
+		}
+	}
+	return -1
+}
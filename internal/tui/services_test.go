@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourabhrathourr/hun/internal/daemon"
+)
+
+func TestServicesViewShowsRestartBadge(t *testing.T) {
+	m := servicesModel{
+		items: []serviceItem{
+			{name: "api", running: true, restarts: 3},
+			{name: "web", running: true},
+		},
+		width:  30,
+		height: 10,
+	}
+	view := m.View()
+	if !strings.Contains(view, "↻3") {
+		t.Fatalf("expected restart badge for api, got:\n%s", view)
+	}
+	if strings.Count(view, "↻") != 1 {
+		t.Fatalf("expected only one restart badge, got:\n%s", view)
+	}
+}
+
+func TestServicesViewShowsUsageWhenItFits(t *testing.T) {
+	m := servicesModel{
+		items: []serviceItem{
+			{name: "api", running: true, port: 3000, cpu: 12.4, memBytes: 143 << 20},
+			{name: "db", cpu: 50, memBytes: 1 << 30}, // stopped: stale numbers stay hidden
+		},
+		width:  36,
+		height: 10,
+	}
+	view := m.View()
+	if !strings.Contains(view, ":3000 12% 143M") {
+		t.Fatalf("expected api usage next to its port, got:\n%s", view)
+	}
+	if strings.Contains(view, "1.0G") {
+		t.Fatalf("stopped service shows usage:\n%s", view)
+	}
+
+	m.width = 16
+	if view := m.View(); strings.Contains(view, "143M") {
+		t.Fatalf("usage should drop out of a narrow sidebar, got:\n%s", view)
+	}
+}
+
+func TestActivitySparklineTracksRecentLineRate(t *testing.T) {
+	m := New(false)
+	m.client = nil
+	m.focusedProject = "proj"
+	m.latestStatus = statusUpdateMsg{
+		"proj": {
+			"api": daemon.ServiceInfo{Running: true},
+			"web": daemon.ServiceInfo{Running: true},
+		},
+	}
+	m.refreshServices()
+	m.services.width = 24
+	m.services.height = 10
+
+	start := time.Now().Add(-25 * time.Second)
+	for i := 0; i < 20; i++ {
+		m.recordActivity(logMsg{Project: "proj", Service: "api"}, start)
+	}
+	m.recordActivity(logMsg{Project: "proj", Service: "api"}, start.Add(20*time.Second))
+	m.syncActivity(start.Add(25 * time.Second))
+
+	api := m.services.items[m.services.indexOf("api")]
+	if api.activity != "█▁▁▁▂▁" {
+		t.Fatalf("api sparkline = %q, want the burst scaled above the single line", api.activity)
+	}
+	if web := m.services.items[m.services.indexOf("web")]; web.activity != "" {
+		t.Fatalf("quiet service should have no sparkline, got %q", web.activity)
+	}
+	if !strings.Contains(m.services.View(), api.activity) {
+		t.Fatalf("sidebar should render the sparkline:\n%s", m.services.View())
+	}
+
+	// Once the burst ages out of the window the sparkline goes away.
+	m.syncActivity(start.Add(time.Minute))
+	if got := m.services.items[m.services.indexOf("api")].activity; got != "" {
+		t.Fatalf("stale activity should clear, got %q", got)
+	}
+}
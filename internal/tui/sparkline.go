@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	activityWindow = 30 // seconds of per-second line counts kept per service
+	sparkCells     = 6  // sidebar cells the window is folded into
+)
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// activityRing counts a service's log lines per second over the last
+// activityWindow seconds.
+type activityRing struct {
+	counts [activityWindow]int
+	newest int64 // unix second counts[newest%activityWindow] belongs to
+}
+
+// add records one line at now.
+func (r *activityRing) add(now time.Time) {
+	r.advance(now.Unix())
+	r.counts[r.newest%activityWindow]++
+}
+
+// advance moves the ring forward to sec, zeroing the seconds skipped over.
+func (r *activityRing) advance(sec int64) {
+	if sec <= r.newest {
+		return
+	}
+	if sec-r.newest >= activityWindow {
+		r.counts = [activityWindow]int{}
+	} else {
+		for s := r.newest + 1; s <= sec; s++ {
+			r.counts[s%activityWindow] = 0
+		}
+	}
+	r.newest = sec
+}
+
+// series returns the per-second counts as of now, oldest first.
+func (r *activityRing) series(now time.Time) []int {
+	r.advance(now.Unix())
+	out := make([]int, activityWindow)
+	for i := range out {
+		out[i] = r.counts[(r.newest+1+int64(i))%activityWindow]
+	}
+	return out
+}
+
+// sparkline folds counts into cells block characters scaled to the busiest
+// cell. Quiet cells render as the lowest block so a silent service still
+// shows a flat line; an entirely silent window renders nothing.
+func sparkline(counts []int, cells int) string {
+	if cells <= 0 || len(counts) == 0 {
+		return ""
+	}
+	sums := make([]int, cells)
+	peak := 0
+	for i, c := range counts {
+		cell := i * cells / len(counts)
+		sums[cell] += c
+		if sums[cell] > peak {
+			peak = sums[cell]
+		}
+	}
+	if peak == 0 {
+		return ""
+	}
+	var b strings.Builder
+	top := len(sparkBlocks) - 1
+	for _, sum := range sums {
+		level := 0
+		if sum > 0 {
+			level = 1 + (sum*top-1)/peak
+			if level > top {
+				level = top
+			}
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+// recordActivity counts line toward its service's sparkline.
+func (m *Model) recordActivity(line logMsg, now time.Time) {
+	if m.activity == nil {
+		m.activity = make(map[string]*activityRing)
+	}
+	key := projectServiceKey(line.Project, line.Service)
+	ring := m.activity[key]
+	if ring == nil {
+		ring = &activityRing{}
+		m.activity[key] = ring
+	}
+	ring.add(now)
+}
+
+// syncActivity copies each sidebar service's recent line rate onto its item.
+func (m *Model) syncActivity(now time.Time) {
+	for i := range m.services.items {
+		item := &m.services.items[i]
+		item.activity = ""
+		if ring := m.activity[projectServiceKey(m.focusedProject, item.name)]; ring != nil {
+			item.activity = sparkline(ring.series(now), sparkCells)
+		}
+	}
+}
@@ -7,6 +7,29 @@ type statusBarModel struct {
 	width         int
 	activePane    string
 	selectionMode bool
+	health        daemonHealth
+}
+
+// daemonHealth tracks whether the TUI is getting answers from the daemon.
+type daemonHealth int
+
+const (
+	daemonHealthUnknown daemonHealth = iota
+	daemonConnected
+	daemonReconnecting
+	daemonDisconnected
+)
+
+func (h daemonHealth) indicator() string {
+	switch h {
+	case daemonConnected:
+		return dotRunning + " " + descStyle.Render("daemon")
+	case daemonReconnecting:
+		return dotWarning + " " + descStyle.Render("reconnecting")
+	case daemonDisconnected:
+		return dotCrashed + " " + descStyle.Render("daemon down: run hun doctor")
+	}
+	return ""
 }
 
 func (m statusBarModel) View() string {
@@ -14,7 +37,14 @@ func (m statusBarModel) View() string {
 	if m.activePane == paneLogs {
 		movement = "log"
 	}
-	keys := []string{
+	var keys []string
+	if indicator := m.health.indicator(); indicator != "" {
+		keys = append(keys, indicator)
+	}
+	// Help and quit lead so narrow terminals, which drop keys off the end,
+	// still show how to find the rest.
+	keys = append(keys, keyBind("?", "help"), keyBind("q", "quit"))
+	keys = append(keys,
 		keyBind("\u2190\u2192", "pane"),
 		keyBind("\u2191\u2193", movement),
 		keyBind("u/d", "fast scroll"),
@@ -27,9 +57,22 @@ func (m statusBarModel) View() string {
 		keyBind("R", "restart project"),
 		keyBind("s", "stop project"),
 		keyBind("x", "stop service"),
-	}
+		keyBind("P", "pause"),
+		keyBind("#", "tag filter"),
+	)
 	if m.activePane == paneLogs {
 		keys = append(keys, keyBind("l", "live"))
+		keys = append(keys, keyBind("t", "sticky tail"))
+		keys = append(keys, keyBind("i", "cmd info"))
+		keys = append(keys, keyBind("z", "group traces"))
+		keys = append(keys, keyBind("A", "auto-follow"))
+		keys = append(keys, keyBind("H", "export html"))
+		keys = append(keys, keyBind("e", "export log"))
+	} else {
+		keys = append(keys, keyBind("'", "jump to service"))
+		keys = append(keys, keyBind("B", "copy repro"))
+		keys = append(keys, keyBind("o", "open url"))
+		keys = append(keys, keyBind("C", "copy log path"))
 	}
 	if m.mode == "multitask" {
 		keys = append(keys, keyBind("tab", "project"))
@@ -37,7 +80,6 @@ func (m statusBarModel) View() string {
 	} else {
 		keys = append(keys, keyBind("m", "multitask"))
 	}
-	keys = append(keys, keyBind("q", "quit"))
 	if m.selectionMode {
 		keys = append(keys, keyBind("enter", "copy range"))
 	}
@@ -2,201 +2,391 @@ package tui
 
 import "github.com/charmbracelet/lipgloss"
 
-var (
-	// Colors — neutral + green palette
-	colorPrimary      = lipgloss.Color("#04B575")
-	colorSecondary    = lipgloss.Color("#888888")
-	colorSuccess      = lipgloss.Color("#04B575")
-	colorDanger       = lipgloss.Color("#D86A72")
-	colorWarning      = lipgloss.Color("#D9B86A")
-	colorMuted        = lipgloss.Color("#888888")
-	colorDim          = lipgloss.Color("#555555")
-	colorBg           = lipgloss.Color("#101010")
-	colorPanelBg      = lipgloss.Color("#1C1C1C")
-	colorFg           = lipgloss.Color("#C8C0B6")
-	colorBorder       = lipgloss.Color("#333333")
-	colorHighlight    = lipgloss.Color("#04B575")
-	colorLogTimestamp = lipgloss.Color("#7A7268")
-	colorLogText      = lipgloss.Color("#B8B0A6")
-	colorLogInfo      = lipgloss.Color("#C2B8AA")
-	colorLogDebug     = lipgloss.Color("#8A8178")
-	colorLogWarning   = lipgloss.Color("#D8BA72")
-	colorLogError     = lipgloss.Color("#D88178")
+// Theme is the TUI's palette. Every style below is built from the active
+// theme by applyTheme, so nothing outside this file hardcodes a color.
+type Theme struct {
+	Primary      lipgloss.Color
+	Secondary    lipgloss.Color
+	Success      lipgloss.Color
+	Danger       lipgloss.Color
+	Warning      lipgloss.Color
+	Muted        lipgloss.Color
+	Dim          lipgloss.Color
+	Bg           lipgloss.Color
+	PanelBg      lipgloss.Color
+	Fg           lipgloss.Color
+	Border       lipgloss.Color
+	Highlight    lipgloss.Color
+	PaneInactive lipgloss.Color
+	BadgeFg      lipgloss.Color
+	Stopped      lipgloss.Color
+	OverlayBg    lipgloss.Color // toasts and the focused log line
+	BoxBorder    lipgloss.Color // stopped-state box
+	BoxTitle     lipgloss.Color
+
+	LogTimestamp lipgloss.Color
+	LogText      lipgloss.Color
+	LogInfo      lipgloss.Color
+	LogDebug     lipgloss.Color
+	LogWarning   lipgloss.Color
+	LogError     lipgloss.Color
+
+	// Selected log lines, and the two fading phases of the copy flash.
+	Selection   lipgloss.Color
+	FlashStrong lipgloss.Color
+	FlashWeak   lipgloss.Color
+}
+
+// darkTheme is the neutral + green palette hun has always used.
+var darkTheme = Theme{
+	Primary:      "#04B575",
+	Secondary:    "#888888",
+	Success:      "#04B575",
+	Danger:       "#D86A72",
+	Warning:      "#D9B86A",
+	Muted:        "#888888",
+	Dim:          "#555555",
+	Bg:           "#101010",
+	PanelBg:      "#1C1C1C",
+	Fg:           "#C8C0B6",
+	Border:       "#333333",
+	Highlight:    "#04B575",
+	PaneInactive: "#A59E95",
+	BadgeFg:      "#101010",
+	Stopped:      "#8A8178",
+	OverlayBg:    "#1A1A1A",
+	BoxBorder:    "#2F3431",
+	BoxTitle:     "#CFC7BD",
+	LogTimestamp: "#7A7268",
+	LogText:      "#B8B0A6",
+	LogInfo:      "#C2B8AA",
+	LogDebug:     "#8A8178",
+	LogWarning:   "#D8BA72",
+	LogError:     "#D88178",
+	Selection:    "#173026",
+	FlashStrong:  "#153027",
+	FlashWeak:    "#11241d",
+}
+
+// lightTheme keeps the same hues, darkened for contrast on a light background.
+var lightTheme = Theme{
+	Primary:      "#027A4E",
+	Secondary:    "#6B6B6B",
+	Success:      "#027A4E",
+	Danger:       "#B3261E",
+	Warning:      "#8A6100",
+	Muted:        "#6B6B6B",
+	Dim:          "#9A9A9A",
+	Bg:           "#FAFAFA",
+	PanelBg:      "#F0F0F0",
+	Fg:           "#2B2622",
+	Border:       "#C8C8C8",
+	Highlight:    "#027A4E",
+	PaneInactive: "#6E675F",
+	BadgeFg:      "#FFFFFF",
+	Stopped:      "#8A8178",
+	OverlayBg:    "#ECECEC",
+	BoxBorder:    "#C9CFCB",
+	BoxTitle:     "#3A342E",
+	LogTimestamp: "#857A6E",
+	LogText:      "#3A342E",
+	LogInfo:      "#2F2A25",
+	LogDebug:     "#8A8178",
+	LogWarning:   "#8A6100",
+	LogError:     "#B3261E",
+	Selection:    "#CDEBDD",
+	FlashStrong:  "#D9F0E5",
+	FlashWeak:    "#E8F6EF",
+}
+
+// themeByName resolves the global config's theme setting: "dark" (default),
+// "light", or "auto", which asks the terminal for its background and falls
+// back to dark when it can't tell.
+func themeByName(name string) Theme {
+	switch name {
+	case "light":
+		return lightTheme
+	case "auto":
+		if !lipgloss.HasDarkBackground() {
+			return lightTheme
+		}
+	}
+	return darkTheme
+}
+
+// theme is the active palette; applyTheme replaces it.
+var theme Theme
 
+var (
 	// Dot indicators
-	dotRunning = lipgloss.NewStyle().Foreground(colorSuccess).Render("\u25cf")
-	dotCrashed = lipgloss.NewStyle().Foreground(colorDanger).Render("\u25cf")
-	dotStopped = lipgloss.NewStyle().Foreground(lipgloss.Color("#8A8178")).Render("\u25a0")
+	dotRunning string
+	dotCrashed string
+	dotStopped string
+	dotPaused  string
+	dotWarning string
+	dotSkipped string
 
 	// Top bar
+	topBarStyle           lipgloss.Style
+	topBarProjectActive   lipgloss.Style
+	topBarProjectInactive lipgloss.Style
+	paneFocusActive       lipgloss.Style
+	paneFocusInactive     lipgloss.Style
+
+	// Mode badges
+	modeFocusBadge     lipgloss.Style
+	modeMultitaskBadge lipgloss.Style
+
+	// Service list
+	serviceListStyle  lipgloss.Style
+	serviceTitleStyle lipgloss.Style
+	serviceTitleCount lipgloss.Style
+	serviceSelected   lipgloss.Style
+	serviceNormal     lipgloss.Style
+	serviceCursor     lipgloss.Style
+	portStyle         lipgloss.Style
+	restartBadge      lipgloss.Style
+	sparkStyle        lipgloss.Style
+	readyCheck        string
+	pinMark           string
+
+	// Log viewer
+	logTimestamp           lipgloss.Style
+	logText                lipgloss.Style
+	logInfo                lipgloss.Style
+	logDebug               lipgloss.Style
+	logWarning             lipgloss.Style
+	logError               lipgloss.Style
+	logFocusedLine         lipgloss.Style
+	logSelectedLine        lipgloss.Style
+	stoppedStateBoxStyle   lipgloss.Style
+	stoppedStateTitleStyle lipgloss.Style
+	logEmptyStyle          lipgloss.Style
+
+	// Search bar
+	searchLabelStyle lipgloss.Style
+	searchBarStyle   lipgloss.Style
+	searchHintStyle  lipgloss.Style
+	searchMatchStyle lipgloss.Style
+
+	// Status bar
+	statusBarStyle lipgloss.Style
+	keyStyle       lipgloss.Style
+	descStyle      lipgloss.Style
+
+	// Borders
+	borderStyle lipgloss.Style
+
+	// Picker
+	pickerStyle       lipgloss.Style
+	pickerTitle       lipgloss.Style
+	pickerInput       lipgloss.Style
+	pickerItemActive  lipgloss.Style
+	pickerItemNormal  lipgloss.Style
+	pickerItemRunning lipgloss.Style
+	pickerFavorite    lipgloss.Style
+	pickerEmpty       lipgloss.Style
+
+	// Toast
+	toastStyle      lipgloss.Style
+	toastErrorStyle lipgloss.Style
+
+	// Welcome / empty state
+	welcomeTitleStyle lipgloss.Style
+	welcomeTextStyle  lipgloss.Style
+	welcomeKeyStyle   lipgloss.Style
+)
+
+func init() {
+	applyTheme(darkTheme)
+}
+
+// applyTheme makes t the active palette and rebuilds every style from it.
+func applyTheme(t Theme) {
+	theme = t
+
+	dotRunning = lipgloss.NewStyle().Foreground(t.Success).Render("\u25cf")
+	dotCrashed = lipgloss.NewStyle().Foreground(t.Danger).Render("\u25cf")
+	dotStopped = lipgloss.NewStyle().Foreground(t.Stopped).Render("\u25a0")
+	dotPaused = lipgloss.NewStyle().Foreground(t.Warning).Render("\u2759")
+	dotWarning = lipgloss.NewStyle().Foreground(t.Warning).Render("\u25cf")
+	dotSkipped = lipgloss.NewStyle().Foreground(t.Dim).Render("\u25cb")
+
 	topBarStyle = lipgloss.NewStyle().
-			Padding(0, 1).
-			Bold(true)
+		Padding(0, 1).
+		Bold(true)
 
 	topBarProjectActive = lipgloss.NewStyle().
-				Foreground(colorFg).
-				Bold(true)
+		Foreground(t.Fg).
+		Bold(true)
 
 	topBarProjectInactive = lipgloss.NewStyle().
-				Foreground(colorMuted)
+		Foreground(t.Muted)
 
 	paneFocusActive = lipgloss.NewStyle().
-			Foreground(colorHighlight)
+		Foreground(t.Highlight)
 
 	paneFocusInactive = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#A59E95"))
+		Foreground(t.PaneInactive)
 
-	// Mode badges
 	modeFocusBadge = lipgloss.NewStyle().
-			Background(colorSuccess).
-			Foreground(lipgloss.Color("#101010")).
-			Padding(0, 1).
-			Bold(true)
+		Background(t.Success).
+		Foreground(t.BadgeFg).
+		Padding(0, 1).
+		Bold(true)
 
 	modeMultitaskBadge = lipgloss.NewStyle().
-				Background(colorWarning).
-				Foreground(lipgloss.Color("#101010")).
-				Padding(0, 1).
-				Bold(true)
+		Background(t.Warning).
+		Foreground(t.BadgeFg).
+		Padding(0, 1).
+		Bold(true)
 
-	// Service list
 	serviceListStyle = lipgloss.NewStyle().
-				Padding(0, 1)
+		Padding(0, 1)
 
 	serviceTitleStyle = lipgloss.NewStyle().
-				Foreground(colorHighlight).
-				Bold(true)
+		Foreground(t.Highlight).
+		Bold(true)
 
 	serviceTitleCount = lipgloss.NewStyle().
-				Foreground(colorMuted)
+		Foreground(t.Muted)
 
 	serviceSelected = lipgloss.NewStyle().
-			Foreground(colorHighlight).
-			Bold(true)
+		Foreground(t.Highlight).
+		Bold(true)
 
 	serviceNormal = lipgloss.NewStyle().
-			Foreground(colorFg)
+		Foreground(t.Fg)
 
 	serviceCursor = lipgloss.NewStyle().
-			Foreground(colorHighlight)
+		Foreground(t.Highlight)
 
 	portStyle = lipgloss.NewStyle().
-			Foreground(colorMuted)
+		Foreground(t.Muted)
+
+	restartBadge = lipgloss.NewStyle().
+		Foreground(t.Warning)
+
+	sparkStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
 
 	readyCheck = lipgloss.NewStyle().
-			Foreground(colorSuccess).Render("\u2713")
+		Foreground(t.Success).Render("\u2713")
+
+	pinMark = lipgloss.NewStyle().
+		Foreground(t.Warning).Render("*")
 
-	// Log viewer
 	logTimestamp = lipgloss.NewStyle().
-			Foreground(colorLogTimestamp)
+		Foreground(t.LogTimestamp)
 
 	logText = lipgloss.NewStyle().
-		Foreground(colorLogText)
+		Foreground(t.LogText)
 
 	logInfo = lipgloss.NewStyle().
-		Foreground(colorLogInfo)
+		Foreground(t.LogInfo)
 
 	logDebug = lipgloss.NewStyle().
-			Foreground(colorLogDebug)
+		Foreground(t.LogDebug)
 
 	logWarning = lipgloss.NewStyle().
-			Foreground(colorLogWarning)
+		Foreground(t.LogWarning)
 
 	logError = lipgloss.NewStyle().
-			Foreground(colorLogError)
+		Foreground(t.LogError)
 
 	logFocusedLine = lipgloss.NewStyle().
-			Background(lipgloss.Color("#1A1A1A"))
+		Background(t.OverlayBg)
 
 	logSelectedLine = lipgloss.NewStyle().
-			Background(lipgloss.Color("#173026"))
+		Background(t.Selection)
 
 	stoppedStateBoxStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("#2F3431")).
-				Padding(1, 2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.BoxBorder).
+		Padding(1, 2)
 
 	stoppedStateTitleStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#CFC7BD")).
-				Bold(true)
+		Foreground(t.BoxTitle).
+		Bold(true)
 
 	logEmptyStyle = lipgloss.NewStyle().
-			Foreground(colorDim)
+		Foreground(t.Dim)
 
-	// Search bar
 	searchLabelStyle = lipgloss.NewStyle().
-				Foreground(colorHighlight).
-				Bold(true)
+		Foreground(t.Highlight).
+		Bold(true)
 
 	searchBarStyle = lipgloss.NewStyle().
-			Bold(true)
+		Bold(true)
 
 	searchHintStyle = lipgloss.NewStyle().
-			Foreground(colorDim)
+		Foreground(t.Dim)
+
+	searchMatchStyle = lipgloss.NewStyle().
+		Foreground(t.Bg).
+		Background(t.LogWarning)
 
-	// Status bar
 	statusBarStyle = lipgloss.NewStyle().
-			Padding(0, 1).
-			Foreground(colorMuted)
+		Padding(0, 1).
+		Foreground(t.Muted)
 
 	keyStyle = lipgloss.NewStyle().
-			Foreground(colorHighlight).
-			Bold(true)
+		Foreground(t.Highlight).
+		Bold(true)
 
 	descStyle = lipgloss.NewStyle().
-			Foreground(colorMuted)
+		Foreground(t.Muted)
 
-	// Borders
 	borderStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(colorBorder)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border)
 
-	// Picker
 	pickerStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(colorHighlight).
-			Padding(1, 2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Highlight).
+		Padding(1, 2)
 
 	pickerTitle = lipgloss.NewStyle().
-			Foreground(colorHighlight).
-			Bold(true)
+		Foreground(t.Highlight).
+		Bold(true)
 
 	pickerInput = lipgloss.NewStyle().
-			Foreground(colorFg)
+		Foreground(t.Fg)
 
 	pickerItemActive = lipgloss.NewStyle().
-				Foreground(colorHighlight).
-				Bold(true)
+		Foreground(t.Highlight).
+		Bold(true)
 
 	pickerItemNormal = lipgloss.NewStyle().
-				Foreground(colorFg)
+		Foreground(t.Fg)
 
 	pickerItemRunning = lipgloss.NewStyle().
-				Foreground(colorSuccess)
+		Foreground(t.Success)
+
+	pickerFavorite = lipgloss.NewStyle().
+		Foreground(t.Warning)
 
 	pickerEmpty = lipgloss.NewStyle().
-			Foreground(colorDim)
+		Foreground(t.Dim)
 
-	// Toast
 	toastStyle = lipgloss.NewStyle().
-			Foreground(colorFg).
-			Background(lipgloss.Color("#1A1A1A")).
-			Padding(0, 1)
+		Foreground(t.Fg).
+		Background(t.OverlayBg).
+		Padding(0, 1)
 
 	toastErrorStyle = lipgloss.NewStyle().
-			Foreground(colorDanger).
-			Background(lipgloss.Color("#1A1A1A")).
-			Padding(0, 1)
+		Foreground(t.Danger).
+		Background(t.OverlayBg).
+		Padding(0, 1)
 
-	// Welcome / empty state
 	welcomeTitleStyle = lipgloss.NewStyle().
-				Foreground(colorHighlight).
-				Bold(true)
+		Foreground(t.Highlight).
+		Bold(true)
 
 	welcomeTextStyle = lipgloss.NewStyle().
-				Foreground(colorMuted)
+		Foreground(t.Muted)
 
 	welcomeKeyStyle = lipgloss.NewStyle().
-			Foreground(colorHighlight).
-			Bold(true)
-)
+		Foreground(t.Highlight).
+		Bold(true)
+}
@@ -20,7 +20,7 @@ type projectTab struct {
 }
 
 func (m topBarModel) View() string {
-	left := lipgloss.NewStyle().Bold(true).Foreground(colorFg).Render("hun")
+	left := lipgloss.NewStyle().Bold(true).Foreground(theme.Fg).Render("hun")
 
 	var badge string
 	if m.mode == "focus" {
@@ -38,7 +38,7 @@ func (m topBarModel) View() string {
 
 		style := topBarProjectInactive
 		if i == m.focused {
-			dot = lipgloss.NewStyle().Foreground(colorPrimary).Render("\u25cf")
+			dot = lipgloss.NewStyle().Foreground(theme.Primary).Render("\u25cf")
 			style = topBarProjectActive
 		}
 
@@ -62,7 +62,7 @@ func (m topBarModel) projectIndexAtX(x int) int {
 		return -1
 	}
 
-	left := lipgloss.NewStyle().Bold(true).Foreground(colorFg).Render("hun")
+	left := lipgloss.NewStyle().Bold(true).Foreground(theme.Fg).Render("hun")
 	badge := modeFocusBadge.Render("FOCUS")
 	if m.mode != "focus" {
 		badge = modeMultitaskBadge.Render("MULTI")